@@ -1,17 +1,30 @@
 package main
 
 import (
+	"context"
 	"net/http"
 
 	"github.com/go-chi/chi/v5"
 
 	"github.com/bmstu-itstech/itsreg-bots/internal/common/server"
+	"github.com/bmstu-itstech/itsreg-bots/internal/common/tracing"
 	"github.com/bmstu-itstech/itsreg-bots/internal/ports/httpport"
 	"github.com/bmstu-itstech/itsreg-bots/internal/service"
 )
 
 func main() {
-	app, _, _, closeFunc := service.NewApplication()
+	closeTracing, err := tracing.Init(context.Background(), "itsreg-bots-http")
+	if err != nil {
+		panic(err)
+	}
+	defer func() {
+		err := closeTracing()
+		if err != nil {
+			panic(err)
+		}
+	}()
+
+	app, _, _, checker, closeFunc := service.NewApplication()
 	defer func() {
 		err := closeFunc()
 		if err != nil {
@@ -19,7 +32,7 @@ func main() {
 		}
 	}()
 
-	server.RunHTTPServer(func(router chi.Router) http.Handler {
+	server.RunHTTPServer(checker, func(router chi.Router) http.Handler {
 		return httpport.HandlerFromMux(httpport.NewHTTPServer(app), router)
 	})
 }
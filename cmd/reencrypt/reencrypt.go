@@ -0,0 +1,44 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"os"
+
+	"github.com/jmoiron/sqlx"
+
+	"github.com/bmstu-itstech/itsreg-bots/internal/common/logs"
+	"github.com/bmstu-itstech/itsreg-bots/internal/infra"
+)
+
+func main() {
+	decrypt := flag.Bool("decrypt", false, "write plaintext tokens back before rolling back token encryption")
+	flag.Parse()
+
+	log := logs.DefaultLogger()
+
+	db := sqlx.MustConnect("postgres", os.Getenv("DATABASE_URI"))
+	defer func() {
+		err := db.Close()
+		if err != nil {
+			panic(err)
+		}
+	}()
+
+	if *decrypt {
+		updated, err := infra.DecryptBotTokens(context.Background(), db, infra.NewAESTokenCipher())
+		if err != nil {
+			panic(err)
+		}
+
+		log.Info("bot tokens decrypted", "updated", updated)
+		return
+	}
+
+	updated, err := infra.ReencryptBotTokens(context.Background(), db, infra.NewAESTokenCipher())
+	if err != nil {
+		panic(err)
+	}
+
+	log.Info("bot tokens re-encrypted", "updated", updated)
+}
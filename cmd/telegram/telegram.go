@@ -1,12 +1,31 @@
 package main
 
 import (
+	"context"
+	"os"
+	"os/signal"
+	"syscall"
+
+	"github.com/bmstu-itstech/itsreg-bots/internal/common/server"
+	"github.com/bmstu-itstech/itsreg-bots/internal/common/tracing"
+	"github.com/bmstu-itstech/itsreg-bots/internal/infra"
 	"github.com/bmstu-itstech/itsreg-bots/internal/ports/telegram"
 	"github.com/bmstu-itstech/itsreg-bots/internal/service"
 )
 
 func main() {
-	app, msgCh, runCh, closeFunc := service.NewApplication()
+	closeTracing, err := tracing.Init(context.Background(), "itsreg-bots-telegram")
+	if err != nil {
+		panic(err)
+	}
+	defer func() {
+		err := closeTracing()
+		if err != nil {
+			panic(err)
+		}
+	}()
+
+	app, msgCh, runCh, checker, closeFunc := service.NewApplication()
 	defer func() {
 		err := closeFunc()
 		if err != nil {
@@ -14,5 +33,12 @@ func main() {
 		}
 	}()
 
-	telegram.RunTelegramPort(app, msgCh, runCh)
+	port := telegram.NewPort(app, infra.NewAESTokenCipher())
+
+	go server.RunAdminServer(checker, port.AdminRoutes)
+
+	ctx, stop := signal.NotifyContext(context.Background(), syscall.SIGTERM, os.Interrupt)
+	defer stop()
+
+	port.Run(ctx, msgCh, runCh)
 }
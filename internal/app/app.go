@@ -15,16 +15,65 @@ type Commands struct {
 	DeleteBot     command.DeleteBotHandler
 	StartBot      command.StartBotHandler
 	StopBot       command.StopBotHandler
+	RestartBot    command.RestartBotHandler
 	UpdateStatus  command.UpdateStatusHandler
 	Entry         command.EntryHandler
 	Process       command.ProcessHandler
 	CreateMailing command.CreateMailingHandler
 	StartMailing  command.StartMailingHandler
+
+	SetBotMember    command.SetBotMemberHandler
+	DeleteBotMember command.DeleteBotMemberHandler
+
+	CreateWebhook command.CreateWebhookHandler
+	DeleteWebhook command.DeleteWebhookHandler
+
+	ContactOperator     command.ContactOperatorHandler
+	SendOperatorMessage command.SendOperatorMessageHandler
+	PauseParticipant    command.PauseParticipantHandler
+	ResumeParticipant   command.ResumeParticipantHandler
+
+	ResetParticipant   command.ResetParticipantHandler
+	BlockParticipant   command.BlockParticipantHandler
+	UnblockParticipant command.UnblockParticipantHandler
+	DeleteParticipant  command.DeleteParticipantHandler
+
+	CancelSeat  command.CancelSeatHandler
+	ReleaseSeat command.ReleaseSeatHandler
+
+	CheckIn command.CheckInHandler
+
+	UploadFile    command.UploadFileHandler
+	ProcessUpload command.ProcessUploadHandler
+
+	RemindParticipants command.RemindParticipantsHandler
+	ExpireParticipants command.ExpireParticipantsHandler
+
+	ClaimBot    command.ClaimBotHandler
+	ReleaseBot  command.ReleaseBotHandler
+	RenewLeases command.RenewLeasesHandler
 }
 
 type Queries struct {
-	AllAnswers  query.GetAnswersTableHandler
-	GetBot      query.GetBotHandler
-	GetBots     query.GetBotsHandler
-	StartedBots query.GetStartedBotsHandler
+	AllAnswers    query.GetAnswersTableHandler
+	AnswersStream query.StreamAnswersHandler
+	GetBot        query.GetBotHandler
+	GetBots       query.GetBotsHandler
+	StartedBots   query.GetStartedBotsHandler
+	BotMembers    query.GetBotMembersHandler
+
+	Webhooks          query.GetWebhooksHandler
+	WebhookDeliveries query.GetWebhookDeliveriesHandler
+
+	Threads        query.GetThreadsHandler
+	ThreadMessages query.GetThreadMessagesHandler
+
+	Participant  query.GetParticipantHandler
+	Participants query.GetParticipantsHandler
+
+	Seats query.GetSeatsHandler
+
+	File query.GetFileHandler
+
+	Leases query.GetLeasesHandler
 }
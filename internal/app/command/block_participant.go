@@ -0,0 +1,66 @@
+package command
+
+import (
+	"context"
+	"log/slog"
+
+	"github.com/bmstu-itstech/itsreg-bots/internal/common/decorator"
+	"github.com/bmstu-itstech/itsreg-bots/internal/domain/bots"
+)
+
+type BlockParticipant struct {
+	AuthorUUID string
+	BotUUID    string
+	UserID     int64
+}
+
+type BlockParticipantHandler decorator.CommandHandler[BlockParticipant]
+
+type blockParticipantHandler struct {
+	bots         bots.Repository
+	participants bots.ParticipantRepository
+}
+
+func NewBlockParticipantHandler(
+	bots bots.Repository,
+	participants bots.ParticipantRepository,
+
+	logger *slog.Logger,
+	metricsClient decorator.MetricsClient,
+) BlockParticipantHandler {
+	if bots == nil {
+		panic("bots repository is nil")
+	}
+
+	if participants == nil {
+		panic("participants repository is nil")
+	}
+
+	return decorator.ApplyCommandDecorators[BlockParticipant](
+		blockParticipantHandler{bots: bots, participants: participants},
+		logger,
+		metricsClient,
+	)
+}
+
+func (h blockParticipantHandler) Handle(ctx context.Context, cmd BlockParticipant) error {
+	bot, err := h.bots.Bot(ctx, cmd.BotUUID)
+	if err != nil {
+		return err
+	}
+
+	if err = bot.CanOperateBot(cmd.AuthorUUID); err != nil {
+		return err
+	}
+
+	if _, err = h.participants.Participant(ctx, cmd.BotUUID, cmd.UserID); err != nil {
+		return err
+	}
+
+	return h.participants.UpdateOrCreate(ctx, cmd.BotUUID, cmd.UserID, func(
+		_ context.Context, prt *bots.Participant,
+	) error {
+		prt.Block()
+		return nil
+	})
+}
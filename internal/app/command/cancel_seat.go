@@ -0,0 +1,85 @@
+package command
+
+import (
+	"context"
+	"log/slog"
+
+	"github.com/bmstu-itstech/itsreg-bots/internal/common/decorator"
+	"github.com/bmstu-itstech/itsreg-bots/internal/domain/bots"
+)
+
+type CancelSeat struct {
+	BotUUID  string
+	UserID   int64
+	EntryKey string
+}
+
+type CancelSeatHandler decorator.CommandHandler[CancelSeat]
+
+type cancelSeatHandler struct {
+	bots         bots.Repository
+	participants bots.ParticipantRepository
+	seats        bots.SeatsRepository
+	msgPublisher bots.MessagesPublisher
+}
+
+func NewCancelSeatHandler(
+	bots bots.Repository,
+	participants bots.ParticipantRepository,
+	seats bots.SeatsRepository,
+	msgPublisher bots.MessagesPublisher,
+
+	logger *slog.Logger,
+	metricsClient decorator.MetricsClient,
+) CancelSeatHandler {
+	if bots == nil {
+		panic("bots repository is nil")
+	}
+
+	if participants == nil {
+		panic("participants repository is nil")
+	}
+
+	if seats == nil {
+		panic("seats repository is nil")
+	}
+
+	if msgPublisher == nil {
+		panic("message publisher is nil")
+	}
+
+	return decorator.ApplyCommandDecorators[CancelSeat](
+		cancelSeatHandler{
+			bots:         bots,
+			participants: participants,
+			seats:        seats,
+			msgPublisher: msgPublisher,
+		},
+		logger,
+		metricsClient,
+	)
+}
+
+func (h cancelSeatHandler) Handle(ctx context.Context, cmd CancelSeat) error {
+	bot, err := h.bots.Bot(ctx, cmd.BotUUID)
+	if err != nil {
+		return err
+	}
+
+	keys := []string{cmd.EntryKey}
+	if cmd.EntryKey == "" {
+		keys, err = h.seats.UserEntries(ctx, cmd.BotUUID, cmd.UserID)
+		if err != nil {
+			return err
+		}
+	}
+
+	for _, key := range keys {
+		err = releaseSeat(ctx, h.seats, h.participants, h.msgPublisher, bot, key, cmd.UserID)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
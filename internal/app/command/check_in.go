@@ -0,0 +1,68 @@
+package command
+
+import (
+	"context"
+	"log/slog"
+	"strings"
+
+	"github.com/bmstu-itstech/itsreg-bots/internal/common/commonerrs"
+	"github.com/bmstu-itstech/itsreg-bots/internal/common/decorator"
+	"github.com/bmstu-itstech/itsreg-bots/internal/domain/bots"
+)
+
+type CheckIn struct {
+	AuthorUUID string
+	BotUUID    string
+	Code       string
+}
+
+type CheckInHandler decorator.CommandHandler[CheckIn]
+
+type checkInHandler struct {
+	bots    bots.Repository
+	tickets bots.TicketRepository
+}
+
+func NewCheckInHandler(
+	bots bots.Repository,
+	tickets bots.TicketRepository,
+
+	logger *slog.Logger,
+	metricsClient decorator.MetricsClient,
+) CheckInHandler {
+	if bots == nil {
+		panic("bots repository is nil")
+	}
+
+	if tickets == nil {
+		panic("tickets repository is nil")
+	}
+
+	return decorator.ApplyCommandDecorators[CheckIn](
+		checkInHandler{bots: bots, tickets: tickets},
+		logger,
+		metricsClient,
+	)
+}
+
+func (h checkInHandler) Handle(ctx context.Context, cmd CheckIn) error {
+	code := strings.ToUpper(strings.TrimSpace(cmd.Code))
+	if code == "" {
+		return commonerrs.NewInvalidInputError("expected not empty ticket code")
+	}
+
+	bot, err := h.bots.Bot(ctx, cmd.BotUUID)
+	if err != nil {
+		return err
+	}
+
+	if err = bot.CanOperateBot(cmd.AuthorUUID); err != nil {
+		return err
+	}
+
+	return h.tickets.UpdateTicket(ctx, cmd.BotUUID, code, func(
+		_ context.Context, ticket *bots.Ticket,
+	) error {
+		return ticket.CheckIn()
+	})
+}
@@ -0,0 +1,47 @@
+package command
+
+import (
+	"context"
+	"log/slog"
+	"time"
+
+	"github.com/bmstu-itstech/itsreg-bots/internal/common/decorator"
+	"github.com/bmstu-itstech/itsreg-bots/internal/domain/bots"
+)
+
+type ClaimBot struct {
+	BotUUID    string
+	InstanceID string
+}
+
+type ClaimBotHandler decorator.CommandHandler[ClaimBot]
+
+type claimBotHandler struct {
+	leases bots.LeaseRepository
+}
+
+func NewClaimBotHandler(
+	leases bots.LeaseRepository,
+
+	logger *slog.Logger,
+	metricsClient decorator.MetricsClient,
+) ClaimBotHandler {
+	if leases == nil {
+		panic("leases repository is nil")
+	}
+
+	return decorator.ApplyCommandDecorators[ClaimBot](
+		claimBotHandler{leases: leases},
+		logger,
+		metricsClient,
+	)
+}
+
+func (h claimBotHandler) Handle(ctx context.Context, cmd ClaimBot) error {
+	lease, err := bots.NewLease(cmd.BotUUID, cmd.InstanceID, time.Now().Add(bots.LeaseTTL))
+	if err != nil {
+		return err
+	}
+
+	return h.leases.Acquire(ctx, lease)
+}
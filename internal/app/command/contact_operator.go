@@ -0,0 +1,68 @@
+package command
+
+import (
+	"context"
+	"log/slog"
+
+	"github.com/bmstu-itstech/itsreg-bots/internal/common/decorator"
+	"github.com/bmstu-itstech/itsreg-bots/internal/domain/bots"
+)
+
+type ContactOperator struct {
+	BotUUID string
+	UserID  int64
+	Text    string
+}
+
+type ContactOperatorHandler decorator.CommandHandler[ContactOperator]
+
+type contactOperatorHandler struct {
+	participants bots.ParticipantRepository
+	threads      bots.ThreadRepository
+}
+
+func NewContactOperatorHandler(
+	participants bots.ParticipantRepository,
+	threads bots.ThreadRepository,
+
+	logger *slog.Logger,
+	metricsClient decorator.MetricsClient,
+) ContactOperatorHandler {
+	if participants == nil {
+		panic("participants repository is nil")
+	}
+
+	if threads == nil {
+		panic("threads repository is nil")
+	}
+
+	return decorator.ApplyCommandDecorators[ContactOperator](
+		contactOperatorHandler{participants: participants, threads: threads},
+		logger,
+		metricsClient,
+	)
+}
+
+func (h contactOperatorHandler) Handle(ctx context.Context, cmd ContactOperator) error {
+	msg, err := bots.NewIncomingThreadMessage(cmd.BotUUID, cmd.UserID, cmd.Text)
+	if err != nil {
+		return err
+	}
+
+	blocked := false
+	err = h.participants.UpdateOrCreate(ctx, cmd.BotUUID, cmd.UserID, func(
+		_ context.Context, prt *bots.Participant,
+	) error {
+		blocked = prt.IsBlocked()
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	if blocked {
+		return nil
+	}
+
+	return h.threads.AddMessage(ctx, msg)
+}
@@ -2,6 +2,8 @@ package command
 
 import (
 	"context"
+	"errors"
+	"fmt"
 	"log/slog"
 
 	"github.com/bmstu-itstech/itsreg-bots/internal/app/types"
@@ -21,14 +23,21 @@ type CreateBot struct {
 	Blocks   []types.Block
 }
 
+func (c CreateBot) LogValue() slog.Value {
+	c.Token = bots.MaskToken(c.Token)
+	return slog.StringValue(fmt.Sprintf("%v", c))
+}
+
 type CreateBotHandler decorator.CommandHandler[CreateBot]
 
 type createBotHandler struct {
-	bots bots.Repository
+	bots     bots.Repository
+	verifier bots.TokenVerifier
 }
 
 func NewCreateBotHandler(
 	bots bots.Repository,
+	verifier bots.TokenVerifier,
 
 	logger *slog.Logger,
 	metricsClient decorator.MetricsClient,
@@ -37,8 +46,12 @@ func NewCreateBotHandler(
 		panic("bots repository is nil")
 	}
 
+	if verifier == nil {
+		panic("token verifier is nil")
+	}
+
 	return decorator.ApplyCommandDecorators[CreateBot](
-		createBotHandler{bots: bots},
+		createBotHandler{bots: bots, verifier: verifier},
 		logger,
 		metricsClient,
 	)
@@ -60,11 +73,37 @@ func (h createBotHandler) Handle(ctx context.Context, cmd CreateBot) error {
 		return err
 	}
 
-	bot, err := bots.NewBot(cmd.BotUUID, cmd.AuthorUUID, entries, mailings, blocks, cmd.Name, cmd.Token)
+	ownerUUID := cmd.AuthorUUID
+	var members []bots.Member
+
+	existing, err := h.bots.Bot(ctx, cmd.BotUUID)
+	if err == nil {
+		if err = existing.CanEditBot(cmd.AuthorUUID); err != nil {
+			return err
+		}
+		ownerUUID = existing.OwnerUUID
+		members = existing.Members()
+	} else if !errors.As(err, &bots.BotNotFoundError{}) {
+		return err
+	}
+
+	bot, err := bots.NewBot(cmd.BotUUID, ownerUUID, entries, mailings, blocks, cmd.Name, cmd.Token)
 	if err != nil {
 		return err
 	}
 
+	identity, err := h.verifier.Verify(ctx, bot.Token)
+	if err != nil {
+		return err
+	}
+	bot.SetTelegramIdentity(identity)
+
+	for _, member := range members {
+		if err = bot.SetMember(member); err != nil {
+			return err
+		}
+	}
+
 	err = h.bots.UpdateOrCreate(ctx, bot)
 	if err != nil {
 		return err
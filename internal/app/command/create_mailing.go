@@ -44,7 +44,7 @@ func NewCreateMailingHandler(
 func (h createMailingHandler) Handle(ctx context.Context, cmd CreateMailing) error {
 	return h.bots.Update(ctx, cmd.BotUUID, func(innerCtx context.Context, bot *bots.Bot) error {
 		var err error
-		if err = bot.CanSeeBot(cmd.AuthorUUID); err != nil {
+		if err = bot.CanEditBot(cmd.AuthorUUID); err != nil {
 			return err
 		}
 
@@ -0,0 +1,72 @@
+package command
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+
+	"github.com/bmstu-itstech/itsreg-bots/internal/common/decorator"
+	"github.com/bmstu-itstech/itsreg-bots/internal/domain/bots"
+)
+
+type CreateWebhook struct {
+	AuthorUUID string
+	BotUUID    string
+
+	WebhookUUID string
+	URL         string
+	Secret      string
+	Events      []string
+}
+
+func (c CreateWebhook) LogValue() slog.Value {
+	c.Secret = "<redacted>"
+	return slog.StringValue(fmt.Sprintf("%v", c))
+}
+
+type CreateWebhookHandler decorator.CommandHandler[CreateWebhook]
+
+type createWebhookHandler struct {
+	bots     bots.Repository
+	webhooks bots.WebhookRepository
+}
+
+func NewCreateWebhookHandler(
+	bots bots.Repository,
+	webhooks bots.WebhookRepository,
+
+	logger *slog.Logger,
+	metricsClient decorator.MetricsClient,
+) CreateWebhookHandler {
+	if bots == nil {
+		panic("bots repository is nil")
+	}
+
+	if webhooks == nil {
+		panic("webhooks repository is nil")
+	}
+
+	return decorator.ApplyCommandDecorators[CreateWebhook](
+		createWebhookHandler{bots: bots, webhooks: webhooks},
+		logger,
+		metricsClient,
+	)
+}
+
+func (h createWebhookHandler) Handle(ctx context.Context, cmd CreateWebhook) error {
+	bot, err := h.bots.Bot(ctx, cmd.BotUUID)
+	if err != nil {
+		return err
+	}
+
+	if err = bot.CanEditBot(cmd.AuthorUUID); err != nil {
+		return err
+	}
+
+	webhook, err := bots.NewWebhook(cmd.WebhookUUID, cmd.BotUUID, cmd.URL, cmd.Secret, cmd.Events)
+	if err != nil {
+		return err
+	}
+
+	return h.webhooks.Create(ctx, webhook)
+}
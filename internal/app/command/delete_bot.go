@@ -42,7 +42,7 @@ func (h deleteBotHandler) Handle(ctx context.Context, cmd DeleteBot) error {
 		return err
 	}
 
-	if err = bot.CanSeeBot(cmd.AuthorUUID); err != nil {
+	if err = bot.CanManageBot(cmd.AuthorUUID); err != nil {
 		return err
 	}
 
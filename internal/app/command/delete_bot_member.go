@@ -0,0 +1,48 @@
+package command
+
+import (
+	"context"
+	"log/slog"
+
+	"github.com/bmstu-itstech/itsreg-bots/internal/common/decorator"
+	"github.com/bmstu-itstech/itsreg-bots/internal/domain/bots"
+)
+
+type DeleteBotMember struct {
+	AuthorUUID string
+	BotUUID    string
+	UserUUID   string
+}
+
+type DeleteBotMemberHandler decorator.CommandHandler[DeleteBotMember]
+
+type deleteBotMemberHandler struct {
+	bots bots.Repository
+}
+
+func NewDeleteBotMemberHandler(
+	bots bots.Repository,
+
+	logger *slog.Logger,
+	metricsClient decorator.MetricsClient,
+) DeleteBotMemberHandler {
+	if bots == nil {
+		panic("bots repository is nil")
+	}
+
+	return decorator.ApplyCommandDecorators[DeleteBotMember](
+		deleteBotMemberHandler{bots: bots},
+		logger,
+		metricsClient,
+	)
+}
+
+func (h deleteBotMemberHandler) Handle(ctx context.Context, cmd DeleteBotMember) error {
+	return h.bots.Update(ctx, cmd.BotUUID, func(innerCtx context.Context, bot *bots.Bot) error {
+		if err := bot.CanManageBot(cmd.AuthorUUID); err != nil {
+			return err
+		}
+
+		return bot.RemoveMember(cmd.UserUUID)
+	})
+}
@@ -0,0 +1,112 @@
+package command
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+
+	"github.com/bmstu-itstech/itsreg-bots/internal/common/decorator"
+	"github.com/bmstu-itstech/itsreg-bots/internal/domain/bots"
+)
+
+type DeleteParticipant struct {
+	AuthorUUID string
+	BotUUID    string
+	UserID     int64
+}
+
+type DeleteParticipantHandler decorator.CommandHandler[DeleteParticipant]
+
+type deleteParticipantHandler struct {
+	bots         bots.Repository
+	participants bots.ParticipantRepository
+	seats        bots.SeatsRepository
+	files        bots.FileStorage
+	msgPublisher bots.MessagesPublisher
+}
+
+func NewDeleteParticipantHandler(
+	bots bots.Repository,
+	participants bots.ParticipantRepository,
+	seats bots.SeatsRepository,
+	files bots.FileStorage,
+	msgPublisher bots.MessagesPublisher,
+
+	logger *slog.Logger,
+	metricsClient decorator.MetricsClient,
+) DeleteParticipantHandler {
+	if bots == nil {
+		panic("bots repository is nil")
+	}
+
+	if participants == nil {
+		panic("participants repository is nil")
+	}
+
+	if seats == nil {
+		panic("seats repository is nil")
+	}
+
+	if files == nil {
+		panic("file storage is nil")
+	}
+
+	if msgPublisher == nil {
+		panic("message publisher is nil")
+	}
+
+	return decorator.ApplyCommandDecorators[DeleteParticipant](
+		deleteParticipantHandler{
+			bots:         bots,
+			participants: participants,
+			seats:        seats,
+			files:        files,
+			msgPublisher: msgPublisher,
+		},
+		logger,
+		metricsClient,
+	)
+}
+
+func (h deleteParticipantHandler) Handle(ctx context.Context, cmd DeleteParticipant) error {
+	bot, err := h.bots.Bot(ctx, cmd.BotUUID)
+	if err != nil {
+		return err
+	}
+
+	if err = bot.CanEditBot(cmd.AuthorUUID); err != nil {
+		return err
+	}
+
+	prt, err := h.participants.Participant(ctx, cmd.BotUUID, cmd.UserID)
+	if err != nil {
+		return err
+	}
+
+	keys, err := h.seats.UserEntries(ctx, cmd.BotUUID, cmd.UserID)
+	if err != nil {
+		return err
+	}
+
+	for _, key := range keys {
+		err = releaseSeat(ctx, h.seats, h.participants, h.msgPublisher, bot, key, cmd.UserID)
+		if errors.As(err, &bots.EntryNotFoundError{}) {
+			continue
+		}
+		if err != nil {
+			return err
+		}
+	}
+
+	for _, ans := range prt.Answers() {
+		if ans.Upload.FileID == "" {
+			continue
+		}
+		err = h.files.Delete(ctx, cmd.BotUUID, ans.Upload.FileID)
+		if err != nil {
+			return err
+		}
+	}
+
+	return h.participants.Delete(ctx, cmd.BotUUID, cmd.UserID)
+}
@@ -0,0 +1,99 @@
+package command_test
+
+import (
+	"context"
+	"io"
+	"log/slog"
+	"strings"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+
+	"github.com/bmstu-itstech/itsreg-bots/internal/app/command"
+	"github.com/bmstu-itstech/itsreg-bots/internal/common/metrics"
+	"github.com/bmstu-itstech/itsreg-bots/internal/domain/bots"
+	"github.com/bmstu-itstech/itsreg-bots/internal/service/mocks"
+)
+
+func TestDeleteParticipantHandler(t *testing.T) {
+	ctx := context.Background()
+
+	botsR := mocks.NewMockBotRepository(mocks.NewMockTokenCipher())
+	participants := mocks.NewMockParticipantsRepository()
+	seats := mocks.NewMockSeatsRepository()
+	files := mocks.NewMockFileStorage()
+	msgPub, msgCh := mocks.NewMockMessagesPublisher()
+
+	ownerUUID := uuid.NewString()
+	capacity := bots.MustNewCapacity(1, 3, 4, 5)
+	bot := bots.MustNewBot(
+		uuid.NewString(), ownerUUID,
+		[]bots.EntryPoint{
+			bots.MustNewEntryPoint("start", 1).WithCapacity(capacity),
+		},
+		nil,
+		[]bots.Block{
+			bots.MustNewQuestionBlock(1, 2, "CV", "Send your CV"),
+			bots.MustNewMessageBlock(2, 0, "Finish", "Thanks"),
+			bots.MustNewMessageBlock(3, 0, "Full", "No seats left"),
+			bots.MustNewMessageBlock(4, 0, "Waitlist", "You are on the waitlist"),
+			bots.MustNewMessageBlock(5, 0, "Promoted", "A seat is free for you"),
+		},
+		"Test bot", "12345678:XXXXXXXXXXXXXXXXXXXXXXXXXXXXXXXXXXX",
+	)
+	require.NoError(t, botsR.UpdateOrCreate(ctx, bot))
+
+	const (
+		deletedID    = 1
+		waitlistedID = 2
+	)
+
+	file, err := files.Save(ctx, bots.MustNewFile(uuid.NewString(), bot.UUID, "cv.pdf", "application/pdf", 0),
+		strings.NewReader("content"))
+	require.NoError(t, err)
+
+	upload := bots.MustNewUpload("document", uuid.NewString(), file.ID, file.Name, file.MimeType, file.Size)
+	require.NoError(t, participants.UpdateOrCreate(ctx, bot.UUID, deletedID, func(_ context.Context, prt *bots.Participant) error {
+		prt.SwitchTo(1)
+		return prt.AddUpload(upload)
+	}))
+	require.NoError(t, participants.UpdateOrCreate(ctx, bot.UUID, waitlistedID, func(_ context.Context, prt *bots.Participant) error {
+		prt.SwitchTo(4)
+		return nil
+	}))
+	require.NoError(t, seats.UpdateSeats(ctx, bot.UUID, "start", func(_ context.Context, s *bots.Seats) error {
+		s.Take(capacity, deletedID)
+		s.Take(capacity, waitlistedID)
+		return nil
+	}))
+
+	handler := command.NewDeleteParticipantHandler(
+		botsR, participants, seats, files, msgPub,
+		slog.New(slog.NewTextHandler(io.Discard, nil)), metrics.NoOp{},
+	)
+	require.NoError(t, handler.Handle(ctx, command.DeleteParticipant{
+		AuthorUUID: ownerUUID,
+		BotUUID:    bot.UUID,
+		UserID:     deletedID,
+	}))
+
+	t.Run("should delete participant", func(t *testing.T) {
+		_, err := participants.Participant(ctx, bot.UUID, deletedID)
+		require.ErrorAs(t, err, &bots.ParticipantNotFoundError{})
+	})
+
+	t.Run("should delete uploaded files", func(t *testing.T) {
+		_, _, err := files.Open(ctx, bot.UUID, file.ID)
+		require.ErrorAs(t, err, &bots.FileNotFoundError{})
+	})
+
+	t.Run("should promote waitlisted participant", func(t *testing.T) {
+		s, err := seats.Seats(ctx, bot.UUID, "start")
+		require.NoError(t, err)
+		require.Equal(t, []int64{waitlistedID}, s.Registered())
+		require.Empty(t, s.Waitlisted())
+
+		require.Equal(t, "A seat is free for you", receiveText(t, msgCh))
+	})
+}
@@ -0,0 +1,57 @@
+package command
+
+import (
+	"context"
+	"log/slog"
+
+	"github.com/bmstu-itstech/itsreg-bots/internal/common/decorator"
+	"github.com/bmstu-itstech/itsreg-bots/internal/domain/bots"
+)
+
+type DeleteWebhook struct {
+	AuthorUUID  string
+	BotUUID     string
+	WebhookUUID string
+}
+
+type DeleteWebhookHandler decorator.CommandHandler[DeleteWebhook]
+
+type deleteWebhookHandler struct {
+	bots     bots.Repository
+	webhooks bots.WebhookRepository
+}
+
+func NewDeleteWebhookHandler(
+	bots bots.Repository,
+	webhooks bots.WebhookRepository,
+
+	logger *slog.Logger,
+	metricsClient decorator.MetricsClient,
+) DeleteWebhookHandler {
+	if bots == nil {
+		panic("bots repository is nil")
+	}
+
+	if webhooks == nil {
+		panic("webhooks repository is nil")
+	}
+
+	return decorator.ApplyCommandDecorators[DeleteWebhook](
+		deleteWebhookHandler{bots: bots, webhooks: webhooks},
+		logger,
+		metricsClient,
+	)
+}
+
+func (h deleteWebhookHandler) Handle(ctx context.Context, cmd DeleteWebhook) error {
+	bot, err := h.bots.Bot(ctx, cmd.BotUUID)
+	if err != nil {
+		return err
+	}
+
+	if err = bot.CanEditBot(cmd.AuthorUUID); err != nil {
+		return err
+	}
+
+	return h.webhooks.Delete(ctx, cmd.BotUUID, cmd.WebhookUUID)
+}
@@ -3,6 +3,7 @@ package command
 import (
 	"context"
 	"log/slog"
+	"time"
 
 	"github.com/bmstu-itstech/itsreg-bots/internal/common/decorator"
 	"github.com/bmstu-itstech/itsreg-bots/internal/domain/bots"
@@ -12,6 +13,7 @@ type Entry struct {
 	BotUUID string
 	UserID  int64
 	Key     string
+	Locale  string
 }
 
 type EntryHandler decorator.CommandHandler[Entry]
@@ -20,12 +22,16 @@ type entryHandler struct {
 	bots         bots.Repository
 	participants bots.ParticipantRepository
 	msgPublisher bots.MessagesPublisher
+	evtPublisher bots.EventPublisher
+	seats        bots.SeatsRepository
 }
 
 func NewEntryHandler(
 	bots bots.Repository,
 	participants bots.ParticipantRepository,
 	msgPublisher bots.MessagesPublisher,
+	evtPublisher bots.EventPublisher,
+	seats bots.SeatsRepository,
 
 	logger *slog.Logger,
 	metricsClient decorator.MetricsClient,
@@ -42,8 +48,22 @@ func NewEntryHandler(
 		panic("message publisher is nil")
 	}
 
+	if evtPublisher == nil {
+		panic("event publisher is nil")
+	}
+
+	if seats == nil {
+		panic("seats repository is nil")
+	}
+
 	return decorator.ApplyCommandDecorators[Entry](
-		entryHandler{bots: bots, participants: participants, msgPublisher: msgPublisher},
+		entryHandler{
+			bots:         bots,
+			participants: participants,
+			msgPublisher: msgPublisher,
+			evtPublisher: evtPublisher,
+			seats:        seats,
+		},
 		logger,
 		metricsClient,
 	)
@@ -55,21 +75,74 @@ func (h entryHandler) Handle(ctx context.Context, cmd Entry) error {
 		return err
 	}
 
-	return h.participants.UpdateOrCreate(ctx, cmd.BotUUID, cmd.UserID, func(
-		innerCtx context.Context, prt *bots.Participant,
+	entry, err := bot.EntryPoint(cmd.Key)
+	if err != nil {
+		return err
+	}
+
+	var seats *bots.Seats
+	if entry.Capacity.IsLimited() {
+		seats, err = h.seats.Seats(ctx, cmd.BotUUID, cmd.Key)
+		if err != nil {
+			return err
+		}
+	}
+
+	now := time.Now()
+	open := bot.IsEntryOpen(cmd.Key, now)
+
+	requiresSeat := false
+	var messages []bots.Message
+	var events []bots.Event
+	err = h.participants.UpdateOrCreate(ctx, cmd.BotUUID, cmd.UserID, func(
+		_ context.Context, prt *bots.Participant,
 	) error {
-		messages, err := bot.Entry(prt, cmd.Key)
+		prt.DetectLocale(cmd.Locale)
+		prt.Touch(now)
+
+		if open && seats != nil && bot.IsEntryFull(prt, cmd.Key, seats) {
+			messages, err = bot.RejectEntry(prt, cmd.Key)
+			return err
+		}
+
+		messages, err = bot.EntryAt(prt, cmd.Key, now)
 		if err != nil {
 			return err
 		}
 
-		for _, message := range messages {
-			err = h.msgPublisher.Publish(innerCtx, cmd.BotUUID, cmd.UserID, message)
-			if err != nil {
-				return err
-			}
+		if !open {
+			return nil
 		}
 
+		events = bot.EntryEvents(prt, cmd.Key)
+		requiresSeat = bot.RequiresSeat(prt)
+
 		return nil
 	})
+	if err != nil {
+		return err
+	}
+
+	var seatErr error
+	if requiresSeat {
+		var seatMessages []bots.Message
+		seatMessages, seatErr = takeParticipantSeat(ctx, h.participants, h.seats, bot, cmd.UserID)
+		messages = append(messages, seatMessages...)
+	}
+
+	for _, message := range messages {
+		err = h.msgPublisher.Publish(ctx, cmd.BotUUID, cmd.UserID, message)
+		if err != nil {
+			return err
+		}
+	}
+
+	for _, event := range events {
+		err = h.evtPublisher.Publish(ctx, event)
+		if err != nil {
+			return err
+		}
+	}
+
+	return seatErr
 }
@@ -0,0 +1,121 @@
+package command
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"time"
+
+	"github.com/bmstu-itstech/itsreg-bots/internal/common/decorator"
+	"github.com/bmstu-itstech/itsreg-bots/internal/domain/bots"
+)
+
+type ExpireParticipants struct {
+	Now time.Time
+}
+
+type ExpireParticipantsHandler decorator.CommandHandler[ExpireParticipants]
+
+type expireParticipantsHandler struct {
+	bots         bots.Repository
+	participants bots.ParticipantRepository
+	msgPublisher bots.MessagesPublisher
+	log          *slog.Logger
+}
+
+func NewExpireParticipantsHandler(
+	bots bots.Repository,
+	participants bots.ParticipantRepository,
+	msgPublisher bots.MessagesPublisher,
+
+	logger *slog.Logger,
+	metricsClient decorator.MetricsClient,
+) ExpireParticipantsHandler {
+	if bots == nil {
+		panic("bots repository is nil")
+	}
+
+	if participants == nil {
+		panic("participants repository is nil")
+	}
+
+	if msgPublisher == nil {
+		panic("message publisher is nil")
+	}
+
+	return decorator.ApplyCommandDecorators[ExpireParticipants](
+		expireParticipantsHandler{
+			bots:         bots,
+			participants: participants,
+			msgPublisher: msgPublisher,
+			log:          logger,
+		},
+		logger,
+		metricsClient,
+	)
+}
+
+func (h expireParticipantsHandler) Handle(ctx context.Context, cmd ExpireParticipants) error {
+	now := cmd.Now
+	if now.IsZero() {
+		now = time.Now()
+	}
+
+	started, err := h.bots.BotsWithStatus(ctx, bots.Started)
+	if err != nil {
+		return err
+	}
+
+	var errs []error
+	for _, bot := range started {
+		ttl := bot.ExpiryTTL()
+		if ttl == 0 {
+			continue
+		}
+
+		prts, err := h.participants.ExpiredParticipants(ctx, bot.UUID, now.Add(-ttl))
+		if err != nil {
+			h.log.Error("failed to find participants to expire", "bot_uuid", bot.UUID, "error", err.Error())
+			errs = append(errs, err)
+			continue
+		}
+
+		for _, prt := range prts {
+			err = h.expire(ctx, bot, prt.UserID, now)
+			if err != nil {
+				h.log.Error(
+					"failed to expire participant",
+					"bot_uuid", bot.UUID,
+					"user_id", prt.UserID,
+					"error", err.Error(),
+				)
+				errs = append(errs, err)
+			}
+		}
+	}
+
+	return errors.Join(errs...)
+}
+
+func (h expireParticipantsHandler) expire(ctx context.Context, bot *bots.Bot, userID int64, now time.Time) error {
+	var messages []bots.Message
+	err := h.participants.UpdateOrCreate(ctx, bot.UUID, userID, func(
+		_ context.Context, prt *bots.Participant,
+	) error {
+		var err error
+		messages, err = bot.Expire(prt, now)
+		return err
+	})
+	if err != nil {
+		return err
+	}
+
+	for _, message := range messages {
+		err = h.msgPublisher.Publish(ctx, bot.UUID, userID, message)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
@@ -2,7 +2,9 @@ package command
 
 import (
 	"context"
+	"errors"
 	"log/slog"
+	"time"
 
 	"github.com/bmstu-itstech/itsreg-bots/internal/common/decorator"
 	"github.com/bmstu-itstech/itsreg-bots/internal/domain/bots"
@@ -12,6 +14,7 @@ type Process struct {
 	BotUUID string
 	UserID  int64
 	Text    string
+	Locale  string
 }
 
 type ProcessHandler decorator.CommandHandler[Process]
@@ -20,12 +23,18 @@ type processHandler struct {
 	bots         bots.Repository
 	participants bots.ParticipantRepository
 	msgPublisher bots.MessagesPublisher
+	evtPublisher bots.EventPublisher
+	seats        bots.SeatsRepository
+	threads      bots.ThreadRepository
 }
 
 func NewProcessHandler(
 	bots bots.Repository,
 	participants bots.ParticipantRepository,
 	msgPublisher bots.MessagesPublisher,
+	evtPublisher bots.EventPublisher,
+	seats bots.SeatsRepository,
+	threads bots.ThreadRepository,
 
 	logger *slog.Logger,
 	metricsClient decorator.MetricsClient,
@@ -42,8 +51,27 @@ func NewProcessHandler(
 		panic("message publisher is nil")
 	}
 
+	if evtPublisher == nil {
+		panic("event publisher is nil")
+	}
+
+	if seats == nil {
+		panic("seats repository is nil")
+	}
+
+	if threads == nil {
+		panic("threads repository is nil")
+	}
+
 	return decorator.ApplyCommandDecorators[Process](
-		processHandler{bots: bots, participants: participants, msgPublisher: msgPublisher},
+		processHandler{
+			bots:         bots,
+			participants: participants,
+			msgPublisher: msgPublisher,
+			evtPublisher: evtPublisher,
+			seats:        seats,
+			threads:      threads,
+		},
 		logger,
 		metricsClient,
 	)
@@ -55,21 +83,105 @@ func (h processHandler) Handle(ctx context.Context, cmd Process) error {
 		return err
 	}
 
-	return h.participants.UpdateOrCreate(ctx, cmd.BotUUID, cmd.UserID, func(
-		innerCtx context.Context, prt *bots.Participant,
+	toOperator := false
+	requiresSeat := false
+	var messages []bots.Message
+	var events []bots.Event
+	err = h.participants.UpdateOrCreate(ctx, cmd.BotUUID, cmd.UserID, func(
+		_ context.Context, prt *bots.Participant,
 	) error {
-		messages, err := bot.Process(prt, cmd.Text)
-		if err != nil {
+		now := time.Now()
+		prt.DetectLocale(cmd.Locale)
+		prt.Touch(now)
+
+		if prt.IsBlocked() {
+			return nil
+		}
+
+		if prt.AwaitsOperator() {
+			toOperator = true
+			return nil
+		}
+
+		if bot.IsExpired(prt, now) {
+			messages, err = bot.Expire(prt, now)
 			return err
 		}
 
-		for _, message := range messages {
-			err = h.msgPublisher.Publish(innerCtx, cmd.BotUUID, cmd.UserID, message)
-			if err != nil {
-				return err
-			}
+		prevState := prt.State
+
+		messages, err = bot.Process(prt, cmd.Text)
+		if err != nil {
+			return err
 		}
 
+		events = bot.ProcessEvents(prt, prevState)
+		requiresSeat = bot.RequiresSeat(prt)
+
 		return nil
 	})
+	var notUnique bots.AnswerNotUniqueError
+	if errors.As(err, &notUnique) {
+		return h.rejectAnswer(ctx, bot, cmd, notUnique.State)
+	}
+	if err != nil {
+		return err
+	}
+
+	var seatErr error
+	if requiresSeat {
+		var seatMessages []bots.Message
+		seatMessages, seatErr = takeParticipantSeat(ctx, h.participants, h.seats, bot, cmd.UserID)
+		messages = append(messages, seatMessages...)
+	}
+
+	for _, message := range messages {
+		err = h.msgPublisher.Publish(ctx, cmd.BotUUID, cmd.UserID, message)
+		if err != nil {
+			return err
+		}
+	}
+
+	for _, event := range events {
+		err = h.evtPublisher.Publish(ctx, event)
+		if err != nil {
+			return err
+		}
+	}
+
+	if seatErr != nil {
+		return seatErr
+	}
+
+	if !toOperator || cmd.Text == "" {
+		return nil
+	}
+
+	msg, err := bots.NewIncomingThreadMessage(cmd.BotUUID, cmd.UserID, cmd.Text)
+	if err != nil {
+		return err
+	}
+
+	return h.threads.AddMessage(ctx, msg)
+}
+
+func (h processHandler) rejectAnswer(ctx context.Context, bot *bots.Bot, cmd Process, state int) error {
+	prt, err := h.participants.Participant(ctx, cmd.BotUUID, cmd.UserID)
+	if err != nil {
+		return err
+	}
+
+	messages, err := bot.RejectAnswer(prt, state)
+	if err != nil {
+		return err
+	}
+
+	for _, message := range messages {
+		err = h.msgPublisher.Publish(ctx, cmd.BotUUID, cmd.UserID, message)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
 }
@@ -0,0 +1,100 @@
+package command_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"log/slog"
+	"testing"
+	"time"
+
+	"github.com/ThreeDotsLabs/watermill/message"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+
+	"github.com/bmstu-itstech/itsreg-bots/internal/app/command"
+	"github.com/bmstu-itstech/itsreg-bots/internal/common/metrics"
+	"github.com/bmstu-itstech/itsreg-bots/internal/domain/bots"
+	"github.com/bmstu-itstech/itsreg-bots/internal/service/mocks"
+)
+
+var errSeatsUnavailable = errors.New("seats are unavailable")
+
+type failingSeatsRepository struct {
+	bots.SeatsRepository
+}
+
+func (r failingSeatsRepository) UpdateSeats(
+	_ context.Context, _ string, _ string, _ func(context.Context, *bots.Seats) error,
+) error {
+	return errSeatsUnavailable
+}
+
+func TestProcessHandler_SeatFailure(t *testing.T) {
+	ctx := context.Background()
+	logger := slog.New(slog.NewTextHandler(io.Discard, nil))
+	metricsClient := metrics.NoOp{}
+
+	botsR := mocks.NewMockBotRepository(mocks.NewMockTokenCipher())
+	participants := mocks.NewMockParticipantsRepository()
+	seats := failingSeatsRepository{mocks.NewMockSeatsRepository()}
+	msgPub, msgCh := mocks.NewMockMessagesPublisher()
+	evtPub, _ := mocks.NewMockEventStream()
+
+	bot := bots.MustNewBot(
+		uuid.NewString(), uuid.NewString(),
+		[]bots.EntryPoint{
+			bots.MustNewEntryPoint("start", 1).WithCapacity(bots.MustNewCapacity(1, 3, 0, 0)),
+		},
+		nil,
+		[]bots.Block{
+			bots.MustNewQuestionBlock(1, 2, "Name", "What's your name?"),
+			bots.MustNewMessageBlock(2, 0, "Finish", "Thanks"),
+			bots.MustNewMessageBlock(3, 0, "Full", "No seats left"),
+		},
+		"Test bot", "12345678:XXXXXXXXXXXXXXXXXXXXXXXXXXXXXXXXXXX",
+	)
+	require.NoError(t, botsR.UpdateOrCreate(ctx, bot))
+
+	entry := command.NewEntryHandler(botsR, participants, msgPub, evtPub, seats, logger, metricsClient)
+	process := command.NewProcessHandler(
+		botsR, participants, msgPub, evtPub, seats, mocks.NewMockThreadRepository(), logger, metricsClient,
+	)
+
+	const userID = 1
+	require.NoError(t, entry.Handle(ctx, command.Entry{BotUUID: bot.UUID, UserID: userID, Key: "start"}))
+	require.Equal(t, "What's your name?", receiveText(t, msgCh))
+
+	err := process.Handle(ctx, command.Process{BotUUID: bot.UUID, UserID: userID, Text: "John"})
+	require.ErrorIs(t, err, errSeatsUnavailable)
+
+	t.Run("should publish committed step messages", func(t *testing.T) {
+		require.Equal(t, "Thanks", receiveText(t, msgCh))
+	})
+
+	t.Run("should keep committed participant state", func(t *testing.T) {
+		prt, err := participants.Participant(ctx, bot.UUID, userID)
+		require.NoError(t, err)
+		ans, ok := prt.Answer(1)
+		require.True(t, ok)
+		require.Equal(t, "John", ans.Text)
+	})
+}
+
+func receiveText(t *testing.T, ch <-chan *message.Message) string {
+	t.Helper()
+
+	select {
+	case msg := <-ch:
+		msg.Ack()
+		var payload struct {
+			Text string `json:"text"`
+		}
+		require.NoError(t, json.Unmarshal(msg.Payload, &payload))
+		return payload.Text
+	case <-time.After(time.Second):
+		t.Fatal("message was not published")
+		return ""
+	}
+}
@@ -0,0 +1,164 @@
+package command
+
+import (
+	"context"
+	"io"
+	"log/slog"
+	"time"
+
+	"github.com/bmstu-itstech/itsreg-bots/internal/common/decorator"
+	"github.com/bmstu-itstech/itsreg-bots/internal/domain/bots"
+)
+
+type ProcessUpload struct {
+	BotUUID string
+	UserID  int64
+	Locale  string
+
+	Type           string
+	TelegramFileID string
+	FileID         string
+	Name           string
+	MimeType       string
+	Size           int64
+	Content        io.Reader
+}
+
+type ProcessUploadHandler decorator.CommandHandler[ProcessUpload]
+
+type processUploadHandler struct {
+	bots         bots.Repository
+	participants bots.ParticipantRepository
+	files        bots.FileStorage
+	msgPublisher bots.MessagesPublisher
+	evtPublisher bots.EventPublisher
+	seats        bots.SeatsRepository
+}
+
+func NewProcessUploadHandler(
+	bots bots.Repository,
+	participants bots.ParticipantRepository,
+	files bots.FileStorage,
+	msgPublisher bots.MessagesPublisher,
+	evtPublisher bots.EventPublisher,
+	seats bots.SeatsRepository,
+
+	logger *slog.Logger,
+	metricsClient decorator.MetricsClient,
+) ProcessUploadHandler {
+	if bots == nil {
+		panic("bots repository is nil")
+	}
+
+	if participants == nil {
+		panic("participants repository is nil")
+	}
+
+	if files == nil {
+		panic("file storage is nil")
+	}
+
+	if msgPublisher == nil {
+		panic("message publisher is nil")
+	}
+
+	if evtPublisher == nil {
+		panic("event publisher is nil")
+	}
+
+	if seats == nil {
+		panic("seats repository is nil")
+	}
+
+	return decorator.ApplyCommandDecorators[ProcessUpload](
+		processUploadHandler{
+			bots:         bots,
+			participants: participants,
+			files:        files,
+			msgPublisher: msgPublisher,
+			evtPublisher: evtPublisher,
+			seats:        seats,
+		},
+		logger,
+		metricsClient,
+	)
+}
+
+func (h processUploadHandler) Handle(ctx context.Context, cmd ProcessUpload) error {
+	upload, err := bots.NewUpload(cmd.Type, cmd.TelegramFileID, cmd.FileID, cmd.Name, cmd.MimeType, cmd.Size)
+	if err != nil {
+		return err
+	}
+
+	bot, err := h.bots.Bot(ctx, cmd.BotUUID)
+	if err != nil {
+		return err
+	}
+
+	requiresSeat := false
+	var messages []bots.Message
+	var events []bots.Event
+	err = h.participants.UpdateOrCreate(ctx, cmd.BotUUID, cmd.UserID, func(
+		innerCtx context.Context, prt *bots.Participant,
+	) error {
+		now := time.Now()
+		prt.DetectLocale(cmd.Locale)
+		prt.Touch(now)
+
+		if bot.IsExpired(prt, now) {
+			var err error
+			messages, err = bot.Expire(prt, now)
+			return err
+		}
+
+		if bot.ExpectsUpload(prt) && upload.FileID != "" && cmd.Content != nil {
+			file, err := bots.NewFile(upload.FileID, cmd.BotUUID, upload.Name, upload.MimeType, upload.Size)
+			if err != nil {
+				return err
+			}
+			file, err = h.files.Save(innerCtx, file, cmd.Content)
+			if err != nil {
+				return err
+			}
+			upload.Size = file.Size
+		}
+
+		prevState := prt.State
+
+		messages, err = bot.ProcessUpload(prt, upload)
+		if err != nil {
+			return err
+		}
+
+		events = bot.ProcessEvents(prt, prevState)
+		requiresSeat = bot.RequiresSeat(prt)
+
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	var seatErr error
+	if requiresSeat {
+		var seatMessages []bots.Message
+		seatMessages, seatErr = takeParticipantSeat(ctx, h.participants, h.seats, bot, cmd.UserID)
+		messages = append(messages, seatMessages...)
+	}
+
+	for _, message := range messages {
+		err = h.msgPublisher.Publish(ctx, cmd.BotUUID, cmd.UserID, message)
+		if err != nil {
+			return err
+		}
+	}
+
+	for _, event := range events {
+		err = h.evtPublisher.Publish(ctx, event)
+		if err != nil {
+			return err
+		}
+	}
+
+	return seatErr
+}
@@ -0,0 +1,41 @@
+package command
+
+import (
+	"context"
+	"log/slog"
+
+	"github.com/bmstu-itstech/itsreg-bots/internal/common/decorator"
+	"github.com/bmstu-itstech/itsreg-bots/internal/domain/bots"
+)
+
+type ReleaseBot struct {
+	BotUUID    string
+	InstanceID string
+}
+
+type ReleaseBotHandler decorator.CommandHandler[ReleaseBot]
+
+type releaseBotHandler struct {
+	leases bots.LeaseRepository
+}
+
+func NewReleaseBotHandler(
+	leases bots.LeaseRepository,
+
+	logger *slog.Logger,
+	metricsClient decorator.MetricsClient,
+) ReleaseBotHandler {
+	if leases == nil {
+		panic("leases repository is nil")
+	}
+
+	return decorator.ApplyCommandDecorators[ReleaseBot](
+		releaseBotHandler{leases: leases},
+		logger,
+		metricsClient,
+	)
+}
+
+func (h releaseBotHandler) Handle(ctx context.Context, cmd ReleaseBot) error {
+	return h.leases.Release(ctx, cmd.BotUUID, cmd.InstanceID)
+}
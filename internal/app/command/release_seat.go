@@ -0,0 +1,75 @@
+package command
+
+import (
+	"context"
+	"log/slog"
+
+	"github.com/bmstu-itstech/itsreg-bots/internal/common/decorator"
+	"github.com/bmstu-itstech/itsreg-bots/internal/domain/bots"
+)
+
+type ReleaseSeat struct {
+	AuthorUUID string
+	BotUUID    string
+	EntryKey   string
+	UserID     int64
+}
+
+type ReleaseSeatHandler decorator.CommandHandler[ReleaseSeat]
+
+type releaseSeatHandler struct {
+	bots         bots.Repository
+	participants bots.ParticipantRepository
+	seats        bots.SeatsRepository
+	msgPublisher bots.MessagesPublisher
+}
+
+func NewReleaseSeatHandler(
+	bots bots.Repository,
+	participants bots.ParticipantRepository,
+	seats bots.SeatsRepository,
+	msgPublisher bots.MessagesPublisher,
+
+	logger *slog.Logger,
+	metricsClient decorator.MetricsClient,
+) ReleaseSeatHandler {
+	if bots == nil {
+		panic("bots repository is nil")
+	}
+
+	if participants == nil {
+		panic("participants repository is nil")
+	}
+
+	if seats == nil {
+		panic("seats repository is nil")
+	}
+
+	if msgPublisher == nil {
+		panic("message publisher is nil")
+	}
+
+	return decorator.ApplyCommandDecorators[ReleaseSeat](
+		releaseSeatHandler{
+			bots:         bots,
+			participants: participants,
+			seats:        seats,
+			msgPublisher: msgPublisher,
+		},
+		logger,
+		metricsClient,
+	)
+}
+
+func (h releaseSeatHandler) Handle(ctx context.Context, cmd ReleaseSeat) error {
+	bot, err := h.bots.Bot(ctx, cmd.BotUUID)
+	if err != nil {
+		return err
+	}
+
+	if err = bot.CanOperateBot(cmd.AuthorUUID); err != nil {
+		return err
+	}
+
+	return releaseSeat(ctx, h.seats, h.participants, h.msgPublisher, bot, cmd.EntryKey, cmd.UserID)
+}
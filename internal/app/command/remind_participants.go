@@ -0,0 +1,121 @@
+package command
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"time"
+
+	"github.com/bmstu-itstech/itsreg-bots/internal/common/decorator"
+	"github.com/bmstu-itstech/itsreg-bots/internal/domain/bots"
+)
+
+type RemindParticipants struct {
+	Now time.Time
+}
+
+type RemindParticipantsHandler decorator.CommandHandler[RemindParticipants]
+
+type remindParticipantsHandler struct {
+	bots         bots.Repository
+	participants bots.ParticipantRepository
+	msgPublisher bots.MessagesPublisher
+	log          *slog.Logger
+}
+
+func NewRemindParticipantsHandler(
+	bots bots.Repository,
+	participants bots.ParticipantRepository,
+	msgPublisher bots.MessagesPublisher,
+
+	logger *slog.Logger,
+	metricsClient decorator.MetricsClient,
+) RemindParticipantsHandler {
+	if bots == nil {
+		panic("bots repository is nil")
+	}
+
+	if participants == nil {
+		panic("participants repository is nil")
+	}
+
+	if msgPublisher == nil {
+		panic("message publisher is nil")
+	}
+
+	return decorator.ApplyCommandDecorators[RemindParticipants](
+		remindParticipantsHandler{
+			bots:         bots,
+			participants: participants,
+			msgPublisher: msgPublisher,
+			log:          logger,
+		},
+		logger,
+		metricsClient,
+	)
+}
+
+func (h remindParticipantsHandler) Handle(ctx context.Context, cmd RemindParticipants) error {
+	now := cmd.Now
+	if now.IsZero() {
+		now = time.Now()
+	}
+
+	started, err := h.bots.BotsWithStatus(ctx, bots.Started)
+	if err != nil {
+		return err
+	}
+
+	var errs []error
+	for _, bot := range started {
+		delay := bot.ReminderDelay()
+		if delay == 0 {
+			continue
+		}
+
+		prts, err := h.participants.StalledParticipants(ctx, bot.UUID, now.Add(-delay))
+		if err != nil {
+			h.log.Error("failed to find participants to remind", "bot_uuid", bot.UUID, "error", err.Error())
+			errs = append(errs, err)
+			continue
+		}
+
+		for _, prt := range prts {
+			err = h.remind(ctx, bot, prt.UserID, now)
+			if err != nil {
+				h.log.Error(
+					"failed to remind participant",
+					"bot_uuid", bot.UUID,
+					"user_id", prt.UserID,
+					"error", err.Error(),
+				)
+				errs = append(errs, err)
+			}
+		}
+	}
+
+	return errors.Join(errs...)
+}
+
+func (h remindParticipantsHandler) remind(ctx context.Context, bot *bots.Bot, userID int64, now time.Time) error {
+	var messages []bots.Message
+	err := h.participants.UpdateOrCreate(ctx, bot.UUID, userID, func(
+		_ context.Context, prt *bots.Participant,
+	) error {
+		var err error
+		messages, err = bot.Remind(prt, now)
+		return err
+	})
+	if err != nil {
+		return err
+	}
+
+	for _, message := range messages {
+		err = h.msgPublisher.Publish(ctx, bot.UUID, userID, message)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,46 @@
+package command
+
+import (
+	"context"
+	"log/slog"
+	"time"
+
+	"github.com/bmstu-itstech/itsreg-bots/internal/common/commonerrs"
+	"github.com/bmstu-itstech/itsreg-bots/internal/common/decorator"
+	"github.com/bmstu-itstech/itsreg-bots/internal/domain/bots"
+)
+
+type RenewLeases struct {
+	InstanceID string
+}
+
+type RenewLeasesHandler decorator.CommandHandler[RenewLeases]
+
+type renewLeasesHandler struct {
+	leases bots.LeaseRepository
+}
+
+func NewRenewLeasesHandler(
+	leases bots.LeaseRepository,
+
+	logger *slog.Logger,
+	metricsClient decorator.MetricsClient,
+) RenewLeasesHandler {
+	if leases == nil {
+		panic("leases repository is nil")
+	}
+
+	return decorator.ApplyCommandDecorators[RenewLeases](
+		renewLeasesHandler{leases: leases},
+		logger,
+		metricsClient,
+	)
+}
+
+func (h renewLeasesHandler) Handle(ctx context.Context, cmd RenewLeases) error {
+	if cmd.InstanceID == "" {
+		return commonerrs.NewInvalidInputError("expected not empty instance id")
+	}
+
+	return h.leases.Renew(ctx, cmd.InstanceID, time.Now().Add(bots.LeaseTTL))
+}
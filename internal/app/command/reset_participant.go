@@ -0,0 +1,130 @@
+package command
+
+import (
+	"context"
+	"log/slog"
+	"time"
+
+	"github.com/bmstu-itstech/itsreg-bots/internal/common/decorator"
+	"github.com/bmstu-itstech/itsreg-bots/internal/domain/bots"
+)
+
+type ResetParticipant struct {
+	AuthorUUID string
+	BotUUID    string
+	UserID     int64
+	EntryKey   string
+}
+
+type ResetParticipantHandler decorator.CommandHandler[ResetParticipant]
+
+type resetParticipantHandler struct {
+	bots         bots.Repository
+	participants bots.ParticipantRepository
+	msgPublisher bots.MessagesPublisher
+	evtPublisher bots.EventPublisher
+	seats        bots.SeatsRepository
+}
+
+func NewResetParticipantHandler(
+	bots bots.Repository,
+	participants bots.ParticipantRepository,
+	msgPublisher bots.MessagesPublisher,
+	evtPublisher bots.EventPublisher,
+	seats bots.SeatsRepository,
+
+	logger *slog.Logger,
+	metricsClient decorator.MetricsClient,
+) ResetParticipantHandler {
+	if bots == nil {
+		panic("bots repository is nil")
+	}
+
+	if participants == nil {
+		panic("participants repository is nil")
+	}
+
+	if msgPublisher == nil {
+		panic("message publisher is nil")
+	}
+
+	if evtPublisher == nil {
+		panic("event publisher is nil")
+	}
+
+	if seats == nil {
+		panic("seats repository is nil")
+	}
+
+	return decorator.ApplyCommandDecorators[ResetParticipant](
+		resetParticipantHandler{
+			bots:         bots,
+			participants: participants,
+			msgPublisher: msgPublisher,
+			evtPublisher: evtPublisher,
+			seats:        seats,
+		},
+		logger,
+		metricsClient,
+	)
+}
+
+func (h resetParticipantHandler) Handle(ctx context.Context, cmd ResetParticipant) error {
+	bot, err := h.bots.Bot(ctx, cmd.BotUUID)
+	if err != nil {
+		return err
+	}
+
+	if err = bot.CanOperateBot(cmd.AuthorUUID); err != nil {
+		return err
+	}
+
+	if _, err = h.participants.Participant(ctx, cmd.BotUUID, cmd.UserID); err != nil {
+		return err
+	}
+
+	requiresSeat := false
+	var messages []bots.Message
+	var events []bots.Event
+	err = h.participants.UpdateOrCreate(ctx, cmd.BotUUID, cmd.UserID, func(
+		_ context.Context, prt *bots.Participant,
+	) error {
+		prt.Touch(time.Now())
+
+		messages, err = bot.Reset(prt, cmd.EntryKey)
+		if err != nil {
+			return err
+		}
+
+		events = bot.EntryEvents(prt, cmd.EntryKey)
+		requiresSeat = bot.RequiresSeat(prt)
+
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	var seatErr error
+	if requiresSeat {
+		var seatMessages []bots.Message
+		seatMessages, seatErr = takeParticipantSeat(ctx, h.participants, h.seats, bot, cmd.UserID)
+		messages = append(messages, seatMessages...)
+	}
+
+	for _, message := range messages {
+		err = h.msgPublisher.Publish(ctx, cmd.BotUUID, cmd.UserID, message)
+		if err != nil {
+			return err
+		}
+	}
+
+	for _, event := range events {
+		err = h.evtPublisher.Publish(ctx, event)
+		if err != nil {
+			return err
+		}
+	}
+
+	return seatErr
+}
@@ -0,0 +1,60 @@
+package command
+
+import (
+	"context"
+	"log/slog"
+
+	"github.com/bmstu-itstech/itsreg-bots/internal/common/decorator"
+	"github.com/bmstu-itstech/itsreg-bots/internal/domain/bots"
+)
+
+type RestartBot struct {
+	AuthorUUID string
+	BotUUID    string
+}
+
+type RestartBotHandler decorator.CommandHandler[RestartBot]
+
+type restartBotHandler struct {
+	bots   bots.Repository
+	runPub bots.RunnerPublisher
+}
+
+func NewRestartBotHandler(
+	bots bots.Repository,
+	runPub bots.RunnerPublisher,
+
+	log *slog.Logger,
+	metricsClient decorator.MetricsClient,
+) RestartBotHandler {
+	if bots == nil {
+		panic("bots repository is nil")
+	}
+
+	if runPub == nil {
+		panic("runner publisher is nil")
+	}
+
+	return decorator.ApplyCommandDecorators[RestartBot](
+		&restartBotHandler{bots: bots, runPub: runPub},
+		log,
+		metricsClient,
+	)
+}
+
+func (h restartBotHandler) Handle(ctx context.Context, cmd RestartBot) error {
+	bot, err := h.bots.Bot(ctx, cmd.BotUUID)
+	if err != nil {
+		return err
+	}
+
+	if err = bot.CanEditBot(cmd.AuthorUUID); err != nil {
+		return err
+	}
+
+	if err = bot.CanRestart(); err != nil {
+		return err
+	}
+
+	return h.runPub.PublishStart(ctx, cmd.BotUUID)
+}
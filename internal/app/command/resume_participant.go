@@ -0,0 +1,91 @@
+package command
+
+import (
+	"context"
+	"log/slog"
+	"time"
+
+	"github.com/bmstu-itstech/itsreg-bots/internal/common/decorator"
+	"github.com/bmstu-itstech/itsreg-bots/internal/domain/bots"
+)
+
+type ResumeParticipant struct {
+	AuthorUUID string
+	BotUUID    string
+	UserID     int64
+}
+
+type ResumeParticipantHandler decorator.CommandHandler[ResumeParticipant]
+
+type resumeParticipantHandler struct {
+	bots         bots.Repository
+	participants bots.ParticipantRepository
+	msgPublisher bots.MessagesPublisher
+}
+
+func NewResumeParticipantHandler(
+	bots bots.Repository,
+	participants bots.ParticipantRepository,
+	msgPublisher bots.MessagesPublisher,
+
+	logger *slog.Logger,
+	metricsClient decorator.MetricsClient,
+) ResumeParticipantHandler {
+	if bots == nil {
+		panic("bots repository is nil")
+	}
+
+	if participants == nil {
+		panic("participants repository is nil")
+	}
+
+	if msgPublisher == nil {
+		panic("message publisher is nil")
+	}
+
+	return decorator.ApplyCommandDecorators[ResumeParticipant](
+		resumeParticipantHandler{bots: bots, participants: participants, msgPublisher: msgPublisher},
+		logger,
+		metricsClient,
+	)
+}
+
+func (h resumeParticipantHandler) Handle(ctx context.Context, cmd ResumeParticipant) error {
+	bot, err := h.bots.Bot(ctx, cmd.BotUUID)
+	if err != nil {
+		return err
+	}
+
+	if err = bot.CanOperateBot(cmd.AuthorUUID); err != nil {
+		return err
+	}
+
+	prt, err := h.participants.Participant(ctx, cmd.BotUUID, cmd.UserID)
+	if err != nil {
+		return err
+	}
+
+	if !prt.IsPaused() {
+		return nil
+	}
+
+	return h.participants.UpdateOrCreate(ctx, cmd.BotUUID, cmd.UserID, func(
+		innerCtx context.Context, prt *bots.Participant,
+	) error {
+		prt.Touch(time.Now())
+
+		messages, err := bot.Resume(prt)
+		if err != nil {
+			return err
+		}
+
+		for _, message := range messages {
+			err = h.msgPublisher.Publish(innerCtx, cmd.BotUUID, cmd.UserID, message)
+			if err != nil {
+				return err
+			}
+		}
+
+		return nil
+	})
+}
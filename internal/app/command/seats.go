@@ -0,0 +1,99 @@
+package command
+
+import (
+	"context"
+
+	"github.com/bmstu-itstech/itsreg-bots/internal/domain/bots"
+)
+
+func takeSeat(
+	ctx context.Context,
+	seats bots.SeatsRepository,
+	bot *bots.Bot,
+	prt *bots.Participant,
+) ([]bots.Message, error) {
+	if !bot.RequiresSeat(prt) {
+		return []bots.Message{}, nil
+	}
+
+	var messages []bots.Message
+	err := seats.UpdateSeats(ctx, bot.UUID, prt.EntryKey, func(
+		_ context.Context, s *bots.Seats,
+	) error {
+		var err error
+		messages, err = bot.TakeSeat(prt, s)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return messages, nil
+}
+
+func takeParticipantSeat(
+	ctx context.Context,
+	participants bots.ParticipantRepository,
+	seats bots.SeatsRepository,
+	bot *bots.Bot,
+	userID int64,
+) ([]bots.Message, error) {
+	var messages []bots.Message
+	err := participants.UpdateOrCreate(ctx, bot.UUID, userID, func(
+		innerCtx context.Context, prt *bots.Participant,
+	) error {
+		var err error
+		messages, err = takeSeat(innerCtx, seats, bot, prt)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return messages, nil
+}
+
+func releaseSeat(
+	ctx context.Context,
+	seats bots.SeatsRepository,
+	participants bots.ParticipantRepository,
+	msgPublisher bots.MessagesPublisher,
+	bot *bots.Bot,
+	entryKey string,
+	userID int64,
+) error {
+	var promoted []int64
+	err := seats.UpdateSeats(ctx, bot.UUID, entryKey, func(
+		_ context.Context, s *bots.Seats,
+	) error {
+		var err error
+		promoted, err = bot.ReleaseSeat(userID, s)
+		return err
+	})
+	if err != nil {
+		return err
+	}
+
+	for _, id := range promoted {
+		var messages []bots.Message
+		err = participants.UpdateOrCreate(ctx, bot.UUID, id, func(
+			_ context.Context, prt *bots.Participant,
+		) error {
+			var err error
+			messages, err = bot.Promote(prt, entryKey)
+			return err
+		})
+		if err != nil {
+			return err
+		}
+
+		for _, message := range messages {
+			err = msgPublisher.Publish(ctx, bot.UUID, id, message)
+			if err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
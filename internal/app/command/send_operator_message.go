@@ -0,0 +1,94 @@
+package command
+
+import (
+	"context"
+	"log/slog"
+
+	"github.com/bmstu-itstech/itsreg-bots/internal/common/decorator"
+	"github.com/bmstu-itstech/itsreg-bots/internal/domain/bots"
+)
+
+type SendOperatorMessage struct {
+	AuthorUUID string
+	BotUUID    string
+	UserID     int64
+	Text       string
+}
+
+type SendOperatorMessageHandler decorator.CommandHandler[SendOperatorMessage]
+
+type sendOperatorMessageHandler struct {
+	bots         bots.Repository
+	participants bots.ParticipantRepository
+	threads      bots.ThreadRepository
+	msgPublisher bots.MessagesPublisher
+}
+
+func NewSendOperatorMessageHandler(
+	bots bots.Repository,
+	participants bots.ParticipantRepository,
+	threads bots.ThreadRepository,
+	msgPublisher bots.MessagesPublisher,
+
+	logger *slog.Logger,
+	metricsClient decorator.MetricsClient,
+) SendOperatorMessageHandler {
+	if bots == nil {
+		panic("bots repository is nil")
+	}
+
+	if participants == nil {
+		panic("participants repository is nil")
+	}
+
+	if threads == nil {
+		panic("threads repository is nil")
+	}
+
+	if msgPublisher == nil {
+		panic("message publisher is nil")
+	}
+
+	return decorator.ApplyCommandDecorators[SendOperatorMessage](
+		sendOperatorMessageHandler{
+			bots:         bots,
+			participants: participants,
+			threads:      threads,
+			msgPublisher: msgPublisher,
+		},
+		logger,
+		metricsClient,
+	)
+}
+
+func (h sendOperatorMessageHandler) Handle(ctx context.Context, cmd SendOperatorMessage) error {
+	bot, err := h.bots.Bot(ctx, cmd.BotUUID)
+	if err != nil {
+		return err
+	}
+
+	if err = bot.CanOperateBot(cmd.AuthorUUID); err != nil {
+		return err
+	}
+
+	if _, err = h.participants.Participant(ctx, cmd.BotUUID, cmd.UserID); err != nil {
+		return err
+	}
+
+	threadMsg, err := bots.NewOutgoingThreadMessage(cmd.BotUUID, cmd.UserID, cmd.AuthorUUID, cmd.Text)
+	if err != nil {
+		return err
+	}
+
+	msg, err := bots.NewPlainMessage(cmd.Text)
+	if err != nil {
+		return err
+	}
+
+	err = h.msgPublisher.Publish(ctx, cmd.BotUUID, cmd.UserID, msg)
+	if err != nil {
+		return err
+	}
+
+	return h.threads.AddMessage(ctx, threadMsg)
+}
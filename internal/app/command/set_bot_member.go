@@ -0,0 +1,54 @@
+package command
+
+import (
+	"context"
+	"log/slog"
+
+	"github.com/bmstu-itstech/itsreg-bots/internal/app/types"
+	"github.com/bmstu-itstech/itsreg-bots/internal/common/decorator"
+	"github.com/bmstu-itstech/itsreg-bots/internal/domain/bots"
+)
+
+type SetBotMember struct {
+	AuthorUUID string
+	BotUUID    string
+	Member     types.Member
+}
+
+type SetBotMemberHandler decorator.CommandHandler[SetBotMember]
+
+type setBotMemberHandler struct {
+	bots bots.Repository
+}
+
+func NewSetBotMemberHandler(
+	bots bots.Repository,
+
+	logger *slog.Logger,
+	metricsClient decorator.MetricsClient,
+) SetBotMemberHandler {
+	if bots == nil {
+		panic("bots repository is nil")
+	}
+
+	return decorator.ApplyCommandDecorators[SetBotMember](
+		setBotMemberHandler{bots: bots},
+		logger,
+		metricsClient,
+	)
+}
+
+func (h setBotMemberHandler) Handle(ctx context.Context, cmd SetBotMember) error {
+	return h.bots.Update(ctx, cmd.BotUUID, func(innerCtx context.Context, bot *bots.Bot) error {
+		if err := bot.CanManageBot(cmd.AuthorUUID); err != nil {
+			return err
+		}
+
+		member, err := types.MapMemberToDomain(cmd.Member)
+		if err != nil {
+			return err
+		}
+
+		return bot.SetMember(member)
+	})
+}
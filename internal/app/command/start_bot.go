@@ -16,13 +16,17 @@ type StartBot struct {
 type StartBotHandler decorator.CommandHandler[StartBot]
 
 type startBotHandler struct {
-	bots   bots.Repository
-	runPub bots.RunnerPublisher
+	bots     bots.Repository
+	runPub   bots.RunnerPublisher
+	verifier bots.TokenVerifier
+	tokens   bots.TokenCipher
 }
 
 func NewStartBotHandler(
 	bots bots.Repository,
 	runPub bots.RunnerPublisher,
+	verifier bots.TokenVerifier,
+	tokens bots.TokenCipher,
 
 	log *slog.Logger,
 	metricsClient decorator.MetricsClient,
@@ -35,8 +39,16 @@ func NewStartBotHandler(
 		panic("runner publisher is nil")
 	}
 
+	if verifier == nil {
+		panic("token verifier is nil")
+	}
+
+	if tokens == nil {
+		panic("token cipher is nil")
+	}
+
 	return decorator.ApplyCommandDecorators[StartBot](
-		&startBotHandler{bots: bots, runPub: runPub},
+		&startBotHandler{bots: bots, runPub: runPub, verifier: verifier, tokens: tokens},
 		log,
 		metricsClient,
 	)
@@ -48,7 +60,25 @@ func (h startBotHandler) Handle(ctx context.Context, cmd StartBot) error {
 		return err
 	}
 
-	if err = bot.CanSeeBot(cmd.AuthorUUID); err != nil {
+	if err = bot.CanEditBot(cmd.AuthorUUID); err != nil {
+		return err
+	}
+
+	token, err := h.tokens.Open(bot.SealedToken)
+	if err != nil {
+		return err
+	}
+
+	identity, err := h.verifier.Verify(ctx, token)
+	if err != nil {
+		return err
+	}
+
+	err = h.bots.Update(ctx, cmd.BotUUID, func(_ context.Context, bot *bots.Bot) error {
+		bot.SetTelegramIdentity(identity)
+		return nil
+	})
+	if err != nil {
 		return err
 	}
 
@@ -3,6 +3,7 @@ package command
 import (
 	"context"
 	"log/slog"
+	"time"
 
 	"github.com/bmstu-itstech/itsreg-bots/internal/common/decorator"
 	"github.com/bmstu-itstech/itsreg-bots/internal/domain/bots"
@@ -20,12 +21,14 @@ type startMailingHandler struct {
 	bots         bots.Repository
 	participants bots.ParticipantRepository
 	msgPublisher bots.MessagesPublisher
+	evtPublisher bots.EventPublisher
 }
 
 func NewStartMailingHandler(
 	bots bots.Repository,
 	participants bots.ParticipantRepository,
 	msgPublisher bots.MessagesPublisher,
+	evtPublisher bots.EventPublisher,
 
 	logger *slog.Logger,
 	metricsClient decorator.MetricsClient,
@@ -42,8 +45,12 @@ func NewStartMailingHandler(
 		panic("message publisher is nil")
 	}
 
+	if evtPublisher == nil {
+		panic("event publisher is nil")
+	}
+
 	return decorator.ApplyCommandDecorators[StartMailing](
-		&startMailingHandler{bots, participants, msgPublisher},
+		&startMailingHandler{bots, participants, msgPublisher, evtPublisher},
 		logger,
 		metricsClient,
 	)
@@ -55,6 +62,10 @@ func (h *startMailingHandler) Handle(ctx context.Context, cmd StartMailing) erro
 		return err
 	}
 
+	if err = bot.CanOperateBot(cmd.AuthorUUID); err != nil {
+		return err
+	}
+
 	mailing, err := bot.Mailing(cmd.EntryKey)
 	if err != nil {
 		return err
@@ -75,6 +86,8 @@ func (h *startMailingHandler) Handle(ctx context.Context, cmd StartMailing) erro
 			cmd.BotUUID, prt.UserID,
 			func(innerCtx context.Context, prt *bots.Participant,
 			) error {
+				prt.Touch(time.Now())
+
 				messages, err := bot.Entry(prt, cmd.EntryKey)
 				if err != nil {
 					return err
@@ -94,7 +107,7 @@ func (h *startMailingHandler) Handle(ctx context.Context, cmd StartMailing) erro
 		}
 	}
 
-	return nil
+	return h.evtPublisher.Publish(ctx, bots.NewMailingFinishedEvent(cmd.BotUUID, cmd.EntryKey, len(prts)))
 }
 
 func filterParticipants(prts []*bots.Participant, predicate func(prt *bots.Participant) bool) []*bots.Participant {
@@ -48,7 +48,7 @@ func (h stopBotHandler) Handle(ctx context.Context, cmd StopBot) error {
 		return err
 	}
 
-	if err = bot.CanSeeBot(cmd.AuthorUUID); err != nil {
+	if err = bot.CanEditBot(cmd.AuthorUUID); err != nil {
 		return err
 	}
 
@@ -0,0 +1,66 @@
+package command
+
+import (
+	"context"
+	"log/slog"
+
+	"github.com/bmstu-itstech/itsreg-bots/internal/common/decorator"
+	"github.com/bmstu-itstech/itsreg-bots/internal/domain/bots"
+)
+
+type UnblockParticipant struct {
+	AuthorUUID string
+	BotUUID    string
+	UserID     int64
+}
+
+type UnblockParticipantHandler decorator.CommandHandler[UnblockParticipant]
+
+type unblockParticipantHandler struct {
+	bots         bots.Repository
+	participants bots.ParticipantRepository
+}
+
+func NewUnblockParticipantHandler(
+	bots bots.Repository,
+	participants bots.ParticipantRepository,
+
+	logger *slog.Logger,
+	metricsClient decorator.MetricsClient,
+) UnblockParticipantHandler {
+	if bots == nil {
+		panic("bots repository is nil")
+	}
+
+	if participants == nil {
+		panic("participants repository is nil")
+	}
+
+	return decorator.ApplyCommandDecorators[UnblockParticipant](
+		unblockParticipantHandler{bots: bots, participants: participants},
+		logger,
+		metricsClient,
+	)
+}
+
+func (h unblockParticipantHandler) Handle(ctx context.Context, cmd UnblockParticipant) error {
+	bot, err := h.bots.Bot(ctx, cmd.BotUUID)
+	if err != nil {
+		return err
+	}
+
+	if err = bot.CanOperateBot(cmd.AuthorUUID); err != nil {
+		return err
+	}
+
+	if _, err = h.participants.Participant(ctx, cmd.BotUUID, cmd.UserID); err != nil {
+		return err
+	}
+
+	return h.participants.UpdateOrCreate(ctx, cmd.BotUUID, cmd.UserID, func(
+		_ context.Context, prt *bots.Participant,
+	) error {
+		prt.Unblock()
+		return nil
+	})
+}
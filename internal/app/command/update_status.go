@@ -3,6 +3,7 @@ package command
 import (
 	"context"
 	"log/slog"
+	"time"
 
 	"github.com/bmstu-itstech/itsreg-bots/internal/common/decorator"
 	"github.com/bmstu-itstech/itsreg-bots/internal/domain/bots"
@@ -11,16 +12,19 @@ import (
 type UpdateStatus struct {
 	BotUUID string
 	Status  string
+	Reason  string
 }
 
 type UpdateStatusHandler decorator.CommandHandler[UpdateStatus]
 
 type updateStatusHandler struct {
-	bots bots.Repository
+	bots         bots.Repository
+	evtPublisher bots.EventPublisher
 }
 
 func NewUpdateStatusHandler(
 	bots bots.Repository,
+	evtPublisher bots.EventPublisher,
 
 	logger *slog.Logger,
 	metricsClient decorator.MetricsClient,
@@ -29,8 +33,12 @@ func NewUpdateStatusHandler(
 		panic("bots repository is nil")
 	}
 
+	if evtPublisher == nil {
+		panic("event publisher is nil")
+	}
+
 	return decorator.ApplyCommandDecorators[UpdateStatus](
-		updateStatusHandler{bots: bots},
+		updateStatusHandler{bots: bots, evtPublisher: evtPublisher},
 		logger,
 		metricsClient,
 	)
@@ -42,5 +50,14 @@ func (h updateStatusHandler) Handle(ctx context.Context, cmd UpdateStatus) error
 		return err
 	}
 
-	return h.bots.UpdateStatus(ctx, cmd.BotUUID, st)
+	if st != bots.Failed {
+		return h.bots.UpdateStatus(ctx, cmd.BotUUID, st)
+	}
+
+	err = h.bots.MarkFailed(ctx, cmd.BotUUID, cmd.Reason, time.Now())
+	if err != nil {
+		return err
+	}
+
+	return h.evtPublisher.Publish(ctx, bots.NewBotFailedEvent(cmd.BotUUID, cmd.Reason))
 }
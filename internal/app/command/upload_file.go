@@ -0,0 +1,68 @@
+package command
+
+import (
+	"context"
+	"io"
+	"log/slog"
+
+	"github.com/bmstu-itstech/itsreg-bots/internal/common/decorator"
+	"github.com/bmstu-itstech/itsreg-bots/internal/domain/bots"
+)
+
+type UploadFile struct {
+	AuthorUUID string
+	BotUUID    string
+
+	FileID   string
+	Name     string
+	MimeType string
+	Content  io.Reader
+}
+
+type UploadFileHandler decorator.CommandHandler[UploadFile]
+
+type uploadFileHandler struct {
+	bots  bots.Repository
+	files bots.FileStorage
+}
+
+func NewUploadFileHandler(
+	bots bots.Repository,
+	files bots.FileStorage,
+
+	logger *slog.Logger,
+	metricsClient decorator.MetricsClient,
+) UploadFileHandler {
+	if bots == nil {
+		panic("bots repository is nil")
+	}
+
+	if files == nil {
+		panic("file storage is nil")
+	}
+
+	return decorator.ApplyCommandDecorators[UploadFile](
+		uploadFileHandler{bots: bots, files: files},
+		logger,
+		metricsClient,
+	)
+}
+
+func (h uploadFileHandler) Handle(ctx context.Context, cmd UploadFile) error {
+	file, err := bots.NewFile(cmd.FileID, cmd.BotUUID, cmd.Name, cmd.MimeType, 0)
+	if err != nil {
+		return err
+	}
+
+	bot, err := h.bots.Bot(ctx, cmd.BotUUID)
+	if err != nil {
+		return err
+	}
+
+	if err = bot.CanEditBot(cmd.AuthorUUID); err != nil {
+		return err
+	}
+
+	_, err = h.files.Save(ctx, file, cmd.Content)
+	return err
+}
@@ -19,11 +19,13 @@ type GetAnswersTableHandler decorator.QueryHandler[GetAnswersTable, types.Answer
 type answersHandler struct {
 	bots         bots.Repository
 	participants bots.ParticipantRepository
+	tickets      bots.TicketRepository
 }
 
 func NewGetAnswersTableHandler(
 	bots bots.Repository,
 	participants bots.ParticipantRepository,
+	tickets bots.TicketRepository,
 
 	logger *slog.Logger,
 	metricsClient decorator.MetricsClient,
@@ -36,8 +38,12 @@ func NewGetAnswersTableHandler(
 		panic("participants repository is nil")
 	}
 
+	if tickets == nil {
+		panic("tickets repository is nil")
+	}
+
 	return decorator.ApplyQueryDecorators[GetAnswersTable, types.AnswersTable](
-		answersHandler{bots: bots, participants: participants},
+		answersHandler{bots: bots, participants: participants, tickets: tickets},
 		logger,
 		metricsClient,
 	)
@@ -49,7 +55,7 @@ func (h answersHandler) Handle(ctx context.Context, query GetAnswersTable) (type
 		return types.AnswersTable{}, err
 	}
 
-	if err = bot.CanSeeBot(query.UserUUID); err != nil {
+	if err = bot.CanOperateBot(query.UserUUID); err != nil {
 		return types.AnswersTable{}, err
 	}
 
@@ -58,7 +64,15 @@ func (h answersHandler) Handle(ctx context.Context, query GetAnswersTable) (type
 		return types.AnswersTable{}, err
 	}
 
-	table := bots.NewAnswersTable(bot, prts)
+	var tickets []*bots.Ticket
+	if bot.HasTickets() {
+		tickets, err = h.tickets.Tickets(ctx, query.BotUUID)
+		if err != nil {
+			return types.AnswersTable{}, err
+		}
+	}
+
+	table := bots.NewAnswersTableWithTickets(bot, prts, tickets)
 
 	return types.MapAnswersTableFromDomain(table), nil
 }
@@ -0,0 +1,51 @@
+package query
+
+import (
+	"context"
+	"log/slog"
+
+	"github.com/bmstu-itstech/itsreg-bots/internal/app/types"
+	"github.com/bmstu-itstech/itsreg-bots/internal/common/decorator"
+	"github.com/bmstu-itstech/itsreg-bots/internal/domain/bots"
+)
+
+type GetBotMembers struct {
+	UserUUID string
+	BotUUID  string
+}
+
+type GetBotMembersHandler decorator.QueryHandler[GetBotMembers, []types.Member]
+
+type getBotMembersHandler struct {
+	bots bots.Repository
+}
+
+func NewGetBotMembersHandler(
+	bots bots.Repository,
+
+	logger *slog.Logger,
+	metricsClient decorator.MetricsClient,
+) GetBotMembersHandler {
+	if bots == nil {
+		panic("bots repository is nil")
+	}
+
+	return decorator.ApplyQueryDecorators[GetBotMembers, []types.Member](
+		getBotMembersHandler{bots: bots},
+		logger,
+		metricsClient,
+	)
+}
+
+func (h getBotMembersHandler) Handle(ctx context.Context, query GetBotMembers) ([]types.Member, error) {
+	bot, err := h.bots.Bot(ctx, query.BotUUID)
+	if err != nil {
+		return nil, err
+	}
+
+	if err = bot.CanSeeBot(query.UserUUID); err != nil {
+		return nil, err
+	}
+
+	return types.MapMembersFromDomain(bot.Members()), nil
+}
@@ -0,0 +1,65 @@
+package query
+
+import (
+	"context"
+	"log/slog"
+
+	"github.com/bmstu-itstech/itsreg-bots/internal/app/types"
+	"github.com/bmstu-itstech/itsreg-bots/internal/common/decorator"
+	"github.com/bmstu-itstech/itsreg-bots/internal/domain/bots"
+)
+
+type GetFile struct {
+	UserUUID string
+	BotUUID  string
+	FileID   string
+}
+
+type GetFileHandler decorator.QueryHandler[GetFile, types.File]
+
+type getFileHandler struct {
+	bots  bots.Repository
+	files bots.FileStorage
+}
+
+func NewGetFileHandler(
+	bots bots.Repository,
+	files bots.FileStorage,
+
+	logger *slog.Logger,
+	metricsClient decorator.MetricsClient,
+) GetFileHandler {
+	if bots == nil {
+		panic("bots repository is nil")
+	}
+
+	if files == nil {
+		panic("file storage is nil")
+	}
+
+	return decorator.ApplyQueryDecorators[GetFile, types.File](
+		getFileHandler{bots: bots, files: files},
+		logger,
+		metricsClient,
+	)
+}
+
+func (h getFileHandler) Handle(ctx context.Context, query GetFile) (types.File, error) {
+	bot, err := h.bots.Bot(ctx, query.BotUUID)
+	if err != nil {
+		return types.File{}, err
+	}
+
+	if query.UserUUID != "" {
+		if err = bot.CanOperateBot(query.UserUUID); err != nil {
+			return types.File{}, err
+		}
+	}
+
+	file, content, err := h.files.Open(ctx, query.BotUUID, query.FileID)
+	if err != nil {
+		return types.File{}, err
+	}
+
+	return types.MapFileFromDomain(file, content), nil
+}
@@ -0,0 +1,42 @@
+package query
+
+import (
+	"context"
+	"log/slog"
+
+	"github.com/bmstu-itstech/itsreg-bots/internal/app/types"
+	"github.com/bmstu-itstech/itsreg-bots/internal/common/decorator"
+	"github.com/bmstu-itstech/itsreg-bots/internal/domain/bots"
+)
+
+type GetLeases struct{}
+
+type GetLeasesHandler decorator.QueryHandler[GetLeases, []types.Lease]
+
+type getLeasesHandler struct {
+	leases bots.LeaseRepository
+}
+
+func NewGetLeasesHandler(
+	leases bots.LeaseRepository,
+	logger *slog.Logger,
+	metricsClient decorator.MetricsClient,
+) GetLeasesHandler {
+	if leases == nil {
+		panic("leases repository is nil")
+	}
+
+	return decorator.ApplyQueryDecorators(
+		getLeasesHandler{leases},
+		logger, metricsClient,
+	)
+}
+
+func (h getLeasesHandler) Handle(ctx context.Context, _ GetLeases) ([]types.Lease, error) {
+	leases, err := h.leases.Leases(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	return types.MapLeasesFromDomain(leases), nil
+}
@@ -0,0 +1,63 @@
+package query
+
+import (
+	"context"
+	"log/slog"
+
+	"github.com/bmstu-itstech/itsreg-bots/internal/app/types"
+	"github.com/bmstu-itstech/itsreg-bots/internal/common/decorator"
+	"github.com/bmstu-itstech/itsreg-bots/internal/domain/bots"
+)
+
+type GetParticipant struct {
+	UserUUID string
+	BotUUID  string
+	UserID   int64
+}
+
+type GetParticipantHandler decorator.QueryHandler[GetParticipant, types.Participant]
+
+type getParticipantHandler struct {
+	bots         bots.Repository
+	participants bots.ParticipantRepository
+}
+
+func NewGetParticipantHandler(
+	bots bots.Repository,
+	participants bots.ParticipantRepository,
+
+	logger *slog.Logger,
+	metricsClient decorator.MetricsClient,
+) GetParticipantHandler {
+	if bots == nil {
+		panic("bots repository is nil")
+	}
+
+	if participants == nil {
+		panic("participants repository is nil")
+	}
+
+	return decorator.ApplyQueryDecorators[GetParticipant, types.Participant](
+		getParticipantHandler{bots: bots, participants: participants},
+		logger,
+		metricsClient,
+	)
+}
+
+func (h getParticipantHandler) Handle(ctx context.Context, query GetParticipant) (types.Participant, error) {
+	bot, err := h.bots.Bot(ctx, query.BotUUID)
+	if err != nil {
+		return types.Participant{}, err
+	}
+
+	if err = bot.CanOperateBot(query.UserUUID); err != nil {
+		return types.Participant{}, err
+	}
+
+	prt, err := h.participants.Participant(ctx, query.BotUUID, query.UserID)
+	if err != nil {
+		return types.Participant{}, err
+	}
+
+	return types.MapParticipantFromDomain(prt), nil
+}
@@ -0,0 +1,87 @@
+package query
+
+import (
+	"context"
+	"log/slog"
+
+	"github.com/bmstu-itstech/itsreg-bots/internal/app/types"
+	"github.com/bmstu-itstech/itsreg-bots/internal/common/commonerrs"
+	"github.com/bmstu-itstech/itsreg-bots/internal/common/decorator"
+	"github.com/bmstu-itstech/itsreg-bots/internal/domain/bots"
+)
+
+const (
+	defaultParticipantsLimit = 50
+	maxParticipantsLimit     = 500
+)
+
+type GetParticipants struct {
+	UserUUID string
+	BotUUID  string
+	Offset   int
+	Limit    int
+}
+
+type GetParticipantsHandler decorator.QueryHandler[GetParticipants, types.ParticipantsPage]
+
+type getParticipantsHandler struct {
+	bots         bots.Repository
+	participants bots.ParticipantRepository
+}
+
+func NewGetParticipantsHandler(
+	bots bots.Repository,
+	participants bots.ParticipantRepository,
+
+	logger *slog.Logger,
+	metricsClient decorator.MetricsClient,
+) GetParticipantsHandler {
+	if bots == nil {
+		panic("bots repository is nil")
+	}
+
+	if participants == nil {
+		panic("participants repository is nil")
+	}
+
+	return decorator.ApplyQueryDecorators[GetParticipants, types.ParticipantsPage](
+		getParticipantsHandler{bots: bots, participants: participants},
+		logger,
+		metricsClient,
+	)
+}
+
+func (h getParticipantsHandler) Handle(ctx context.Context, query GetParticipants) (types.ParticipantsPage, error) {
+	if query.Offset < 0 {
+		return types.ParticipantsPage{}, commonerrs.NewInvalidInputError("expected non-negative offset")
+	}
+
+	if query.Limit < 0 || query.Limit > maxParticipantsLimit {
+		return types.ParticipantsPage{}, commonerrs.NewInvalidInputErrorf(
+			"expected limit between 0 and %d", maxParticipantsLimit,
+		)
+	}
+
+	if query.Limit == 0 {
+		query.Limit = defaultParticipantsLimit
+	}
+
+	bot, err := h.bots.Bot(ctx, query.BotUUID)
+	if err != nil {
+		return types.ParticipantsPage{}, err
+	}
+
+	if err = bot.CanOperateBot(query.UserUUID); err != nil {
+		return types.ParticipantsPage{}, err
+	}
+
+	prts, total, err := h.participants.ParticipantsPage(ctx, query.BotUUID, query.Offset, query.Limit)
+	if err != nil {
+		return types.ParticipantsPage{}, err
+	}
+
+	return types.ParticipantsPage{
+		Participants: types.MapParticipantsFromDomain(prts),
+		Total:        total,
+	}, nil
+}
@@ -0,0 +1,68 @@
+package query
+
+import (
+	"context"
+	"log/slog"
+
+	"github.com/bmstu-itstech/itsreg-bots/internal/app/types"
+	"github.com/bmstu-itstech/itsreg-bots/internal/common/decorator"
+	"github.com/bmstu-itstech/itsreg-bots/internal/domain/bots"
+)
+
+type GetSeats struct {
+	UserUUID string
+	BotUUID  string
+	EntryKey string
+}
+
+type GetSeatsHandler decorator.QueryHandler[GetSeats, types.Seats]
+
+type getSeatsHandler struct {
+	bots  bots.Repository
+	seats bots.SeatsRepository
+}
+
+func NewGetSeatsHandler(
+	bots bots.Repository,
+	seats bots.SeatsRepository,
+
+	logger *slog.Logger,
+	metricsClient decorator.MetricsClient,
+) GetSeatsHandler {
+	if bots == nil {
+		panic("bots repository is nil")
+	}
+
+	if seats == nil {
+		panic("seats repository is nil")
+	}
+
+	return decorator.ApplyQueryDecorators[GetSeats, types.Seats](
+		getSeatsHandler{bots: bots, seats: seats},
+		logger,
+		metricsClient,
+	)
+}
+
+func (h getSeatsHandler) Handle(ctx context.Context, query GetSeats) (types.Seats, error) {
+	bot, err := h.bots.Bot(ctx, query.BotUUID)
+	if err != nil {
+		return types.Seats{}, err
+	}
+
+	if err = bot.CanOperateBot(query.UserUUID); err != nil {
+		return types.Seats{}, err
+	}
+
+	entry, err := bot.EntryPoint(query.EntryKey)
+	if err != nil {
+		return types.Seats{}, err
+	}
+
+	seats, err := h.seats.Seats(ctx, query.BotUUID, query.EntryKey)
+	if err != nil {
+		return types.Seats{}, err
+	}
+
+	return types.MapSeatsFromDomain(seats, entry.Capacity), nil
+}
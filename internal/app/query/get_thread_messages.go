@@ -0,0 +1,73 @@
+package query
+
+import (
+	"context"
+	"log/slog"
+
+	"github.com/bmstu-itstech/itsreg-bots/internal/app/types"
+	"github.com/bmstu-itstech/itsreg-bots/internal/common/decorator"
+	"github.com/bmstu-itstech/itsreg-bots/internal/domain/bots"
+)
+
+type GetThreadMessages struct {
+	UserUUID string
+	BotUUID  string
+	UserID   int64
+}
+
+type GetThreadMessagesHandler decorator.QueryHandler[GetThreadMessages, []types.ThreadMessage]
+
+type getThreadMessagesHandler struct {
+	bots         bots.Repository
+	participants bots.ParticipantRepository
+	threads      bots.ThreadRepository
+}
+
+func NewGetThreadMessagesHandler(
+	bots bots.Repository,
+	participants bots.ParticipantRepository,
+	threads bots.ThreadRepository,
+
+	logger *slog.Logger,
+	metricsClient decorator.MetricsClient,
+) GetThreadMessagesHandler {
+	if bots == nil {
+		panic("bots repository is nil")
+	}
+
+	if participants == nil {
+		panic("participants repository is nil")
+	}
+
+	if threads == nil {
+		panic("threads repository is nil")
+	}
+
+	return decorator.ApplyQueryDecorators[GetThreadMessages, []types.ThreadMessage](
+		getThreadMessagesHandler{bots: bots, participants: participants, threads: threads},
+		logger,
+		metricsClient,
+	)
+}
+
+func (h getThreadMessagesHandler) Handle(ctx context.Context, query GetThreadMessages) ([]types.ThreadMessage, error) {
+	bot, err := h.bots.Bot(ctx, query.BotUUID)
+	if err != nil {
+		return nil, err
+	}
+
+	if err = bot.CanOperateBot(query.UserUUID); err != nil {
+		return nil, err
+	}
+
+	if _, err = h.participants.Participant(ctx, query.BotUUID, query.UserID); err != nil {
+		return nil, err
+	}
+
+	msgs, err := h.threads.Messages(ctx, query.BotUUID, query.UserID)
+	if err != nil {
+		return nil, err
+	}
+
+	return types.MapThreadMessagesFromDomain(msgs), nil
+}
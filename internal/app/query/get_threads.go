@@ -0,0 +1,62 @@
+package query
+
+import (
+	"context"
+	"log/slog"
+
+	"github.com/bmstu-itstech/itsreg-bots/internal/app/types"
+	"github.com/bmstu-itstech/itsreg-bots/internal/common/decorator"
+	"github.com/bmstu-itstech/itsreg-bots/internal/domain/bots"
+)
+
+type GetThreads struct {
+	UserUUID string
+	BotUUID  string
+}
+
+type GetThreadsHandler decorator.QueryHandler[GetThreads, []types.Thread]
+
+type getThreadsHandler struct {
+	bots    bots.Repository
+	threads bots.ThreadRepository
+}
+
+func NewGetThreadsHandler(
+	bots bots.Repository,
+	threads bots.ThreadRepository,
+
+	logger *slog.Logger,
+	metricsClient decorator.MetricsClient,
+) GetThreadsHandler {
+	if bots == nil {
+		panic("bots repository is nil")
+	}
+
+	if threads == nil {
+		panic("threads repository is nil")
+	}
+
+	return decorator.ApplyQueryDecorators[GetThreads, []types.Thread](
+		getThreadsHandler{bots: bots, threads: threads},
+		logger,
+		metricsClient,
+	)
+}
+
+func (h getThreadsHandler) Handle(ctx context.Context, query GetThreads) ([]types.Thread, error) {
+	bot, err := h.bots.Bot(ctx, query.BotUUID)
+	if err != nil {
+		return nil, err
+	}
+
+	if err = bot.CanOperateBot(query.UserUUID); err != nil {
+		return nil, err
+	}
+
+	threads, err := h.threads.Threads(ctx, query.BotUUID)
+	if err != nil {
+		return nil, err
+	}
+
+	return types.MapThreadsFromDomain(threads), nil
+}
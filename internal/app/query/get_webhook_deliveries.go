@@ -0,0 +1,66 @@
+package query
+
+import (
+	"context"
+	"log/slog"
+
+	"github.com/bmstu-itstech/itsreg-bots/internal/app/types"
+	"github.com/bmstu-itstech/itsreg-bots/internal/common/decorator"
+	"github.com/bmstu-itstech/itsreg-bots/internal/domain/bots"
+)
+
+type GetWebhookDeliveries struct {
+	UserUUID    string
+	BotUUID     string
+	WebhookUUID string
+}
+
+type GetWebhookDeliveriesHandler decorator.QueryHandler[GetWebhookDeliveries, []types.WebhookDelivery]
+
+type getWebhookDeliveriesHandler struct {
+	bots     bots.Repository
+	webhooks bots.WebhookRepository
+}
+
+func NewGetWebhookDeliveriesHandler(
+	bots bots.Repository,
+	webhooks bots.WebhookRepository,
+
+	logger *slog.Logger,
+	metricsClient decorator.MetricsClient,
+) GetWebhookDeliveriesHandler {
+	if bots == nil {
+		panic("bots repository is nil")
+	}
+
+	if webhooks == nil {
+		panic("webhooks repository is nil")
+	}
+
+	return decorator.ApplyQueryDecorators[GetWebhookDeliveries, []types.WebhookDelivery](
+		getWebhookDeliveriesHandler{bots: bots, webhooks: webhooks},
+		logger,
+		metricsClient,
+	)
+}
+
+func (h getWebhookDeliveriesHandler) Handle(
+	ctx context.Context,
+	query GetWebhookDeliveries,
+) ([]types.WebhookDelivery, error) {
+	bot, err := h.bots.Bot(ctx, query.BotUUID)
+	if err != nil {
+		return nil, err
+	}
+
+	if err = bot.CanEditBot(query.UserUUID); err != nil {
+		return nil, err
+	}
+
+	ds, err := h.webhooks.Deliveries(ctx, query.BotUUID, query.WebhookUUID)
+	if err != nil {
+		return nil, err
+	}
+
+	return types.MapWebhookDeliveriesFromDomain(ds), nil
+}
@@ -0,0 +1,62 @@
+package query
+
+import (
+	"context"
+	"log/slog"
+
+	"github.com/bmstu-itstech/itsreg-bots/internal/app/types"
+	"github.com/bmstu-itstech/itsreg-bots/internal/common/decorator"
+	"github.com/bmstu-itstech/itsreg-bots/internal/domain/bots"
+)
+
+type GetWebhooks struct {
+	UserUUID string
+	BotUUID  string
+}
+
+type GetWebhooksHandler decorator.QueryHandler[GetWebhooks, []types.Webhook]
+
+type getWebhooksHandler struct {
+	bots     bots.Repository
+	webhooks bots.WebhookRepository
+}
+
+func NewGetWebhooksHandler(
+	bots bots.Repository,
+	webhooks bots.WebhookRepository,
+
+	logger *slog.Logger,
+	metricsClient decorator.MetricsClient,
+) GetWebhooksHandler {
+	if bots == nil {
+		panic("bots repository is nil")
+	}
+
+	if webhooks == nil {
+		panic("webhooks repository is nil")
+	}
+
+	return decorator.ApplyQueryDecorators[GetWebhooks, []types.Webhook](
+		getWebhooksHandler{bots: bots, webhooks: webhooks},
+		logger,
+		metricsClient,
+	)
+}
+
+func (h getWebhooksHandler) Handle(ctx context.Context, query GetWebhooks) ([]types.Webhook, error) {
+	bot, err := h.bots.Bot(ctx, query.BotUUID)
+	if err != nil {
+		return nil, err
+	}
+
+	if err = bot.CanEditBot(query.UserUUID); err != nil {
+		return nil, err
+	}
+
+	ws, err := h.webhooks.BotWebhooks(ctx, query.BotUUID)
+	if err != nil {
+		return nil, err
+	}
+
+	return types.MapWebhooksFromDomain(ws), nil
+}
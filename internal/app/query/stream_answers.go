@@ -0,0 +1,78 @@
+package query
+
+import (
+	"context"
+	"log/slog"
+
+	"github.com/bmstu-itstech/itsreg-bots/internal/app/types"
+	"github.com/bmstu-itstech/itsreg-bots/internal/common/decorator"
+	"github.com/bmstu-itstech/itsreg-bots/internal/domain/bots"
+)
+
+type StreamAnswers struct {
+	UserUUID    string
+	BotUUID     string
+	LastEventID int64
+}
+
+type StreamAnswersHandler decorator.QueryHandler[StreamAnswers, <-chan types.AnswerEvent]
+
+type streamAnswersHandler struct {
+	bots   bots.Repository
+	events bots.EventSubscriber
+}
+
+func NewStreamAnswersHandler(
+	bots bots.Repository,
+	events bots.EventSubscriber,
+
+	logger *slog.Logger,
+	metricsClient decorator.MetricsClient,
+) StreamAnswersHandler {
+	if bots == nil {
+		panic("bots repository is nil")
+	}
+
+	if events == nil {
+		panic("event subscriber is nil")
+	}
+
+	return decorator.ApplyQueryDecorators[StreamAnswers, <-chan types.AnswerEvent](
+		streamAnswersHandler{bots: bots, events: events},
+		logger,
+		metricsClient,
+	)
+}
+
+func (h streamAnswersHandler) Handle(ctx context.Context, query StreamAnswers) (<-chan types.AnswerEvent, error) {
+	bot, err := h.bots.Bot(ctx, query.BotUUID)
+	if err != nil {
+		return nil, err
+	}
+
+	if err = bot.CanOperateBot(query.UserUUID); err != nil {
+		return nil, err
+	}
+
+	events, err := h.events.Subscribe(ctx, query.BotUUID, query.LastEventID)
+	if err != nil {
+		return nil, err
+	}
+
+	res := make(chan types.AnswerEvent)
+	go func() {
+		defer close(res)
+		for event := range events {
+			if !event.IsParticipantEvent() {
+				continue
+			}
+			select {
+			case res <- types.MapAnswerEventFromDomain(event):
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+
+	return res, nil
+}
@@ -1,28 +1,95 @@
 package types
 
 import (
+	"io"
+	"slices"
 	"time"
 
 	"github.com/bmstu-itstech/itsreg-bots/internal/domain/bots"
 )
 
 type Option struct {
-	Text string
-	Next int
+	Text         string
+	Value        string
+	ID           string
+	Next         int
+	Aliases      []string
+	Locale       string
+	Translations map[string]string
 }
 
 type Block struct {
-	Type      string
-	State     int
-	NextState int
-	Options   []Option
-	Title     string
-	Text      string
+	Type       string
+	State      int
+	NextState  int
+	Options    []Option
+	Title      string
+	Text       string
+	Unique     bool
+	UniqueText string
+	Attachment Attachment
+	Format     string
+
+	Fallback      string
+	FallbackState int
+	FallbackText  string
+
+	Translations map[string]Translation
+}
+
+type Translation struct {
+	Title string
+	Text  string
+}
+
+type Attachment struct {
+	Type   string
+	FileID string
+}
+
+type File struct {
+	ID       string
+	Name     string
+	MimeType string
+	Size     int64
+	Content  io.ReadCloser
+}
+
+type Capacity struct {
+	Limit         int
+	FullState     int
+	WaitlistState int
+	PromotedState int
+}
+
+type Window struct {
+	OpensAt     time.Time
+	ClosesAt    time.Time
+	Timezone    string
+	NotOpenText string
+	ClosedText  string
+}
+
+type Reminder struct {
+	After time.Duration
+	Limit int
+	Text  string
+}
+
+type Expiry struct {
+	TTL  time.Duration
+	Mode string
+	Text string
 }
 
 type EntryPoint struct {
-	Key   string
-	State int
+	Key         string
+	State       int
+	Capacity    Capacity
+	Window      Window
+	WindowState string
+	Reminder    Reminder
+	Expiry      Expiry
 }
 
 type Mailing struct {
@@ -32,15 +99,98 @@ type Mailing struct {
 }
 
 type Bot struct {
+	UUID        string
+	Entries     []EntryPoint
+	Mailings    []Mailing
+	Blocks      []Block
+	Name        string
+	TokenMask   string
+	SealedToken bots.SealedToken
+	TelegramID  int64
+	Username    string
+	Status      string
+	LastError   string
+	FailedAt    time.Time
+	CreatedAt   time.Time
+	UpdatedAt   time.Time
+}
+
+type Member struct {
+	UserUUID string
+	Role     string
+}
+
+type Webhook struct {
 	UUID      string
-	Entries   []EntryPoint
-	Mailings  []Mailing
-	Blocks    []Block
-	Name      string
-	Token     string
-	Status    string
+	URL       string
+	Events    []string
 	CreatedAt time.Time
-	UpdatedAt time.Time
+}
+
+type WebhookDelivery struct {
+	UUID         string
+	Event        string
+	Status       string
+	Attempts     int
+	ResponseCode int
+	LastError    string
+	CreatedAt    time.Time
+	UpdatedAt    time.Time
+}
+
+type Answer struct {
+	State int
+	Text  string
+}
+
+type Participant struct {
+	UserID  int64
+	State   int
+	Locale  string
+	Paused  bool
+	Blocked bool
+	Answers []Answer
+}
+
+type ParticipantsPage struct {
+	Participants []Participant
+	Total        int
+}
+
+type ThreadMessage struct {
+	Direction  string
+	Text       string
+	AuthorUUID string
+	CreatedAt  time.Time
+}
+
+type Thread struct {
+	UserID      int64
+	Paused      bool
+	LastMessage ThreadMessage
+}
+
+type AnswerEvent struct {
+	ID         int64
+	Type       string
+	UserID     int64
+	EntryKey   string
+	State      int
+	Answer     string
+	OccurredAt time.Time
+}
+
+type Seats struct {
+	EntryKey   string
+	Capacity   Capacity
+	Registered []int64
+	Waitlisted []int64
+}
+
+type Lease struct {
+	BotUUID    string
+	InstanceID string
+	ExpiresAt  time.Time
 }
 
 type AnswersTable struct {
@@ -50,13 +200,50 @@ type AnswersTable struct {
 
 func MapOptionFromDomain(option bots.Option) Option {
 	return Option{
-		Text: option.Text,
-		Next: option.Next,
+		Text:         option.Text,
+		Value:        option.Value,
+		ID:           option.ID,
+		Next:         option.Next,
+		Aliases:      option.Aliases,
+		Locale:       option.Locale,
+		Translations: option.Translations,
 	}
 }
 
 func MapOptionToDomain(option Option) (bots.Option, error) {
-	return bots.NewOption(option.Text, option.Next)
+	o, err := bots.NewOption(option.Text, option.Next)
+	if err != nil {
+		return bots.Option{}, err
+	}
+	if option.Value != "" {
+		o, err = o.WithValue(option.Value)
+		if err != nil {
+			return bots.Option{}, err
+		}
+	}
+	if option.ID != "" {
+		o, err = o.WithID(option.ID)
+		if err != nil {
+			return bots.Option{}, err
+		}
+	}
+	o, err = o.WithAliases(option.Aliases...)
+	if err != nil {
+		return bots.Option{}, err
+	}
+	if option.Locale != "" {
+		o, err = o.WithLocale(option.Locale)
+		if err != nil {
+			return bots.Option{}, err
+		}
+	}
+	for locale, text := range option.Translations {
+		o, err = o.WithTranslation(locale, text)
+		if err != nil {
+			return bots.Option{}, err
+		}
+	}
+	return o, nil
 }
 
 func MapOptionsFromDomain(options []bots.Option) []Option {
@@ -79,15 +266,96 @@ func MapOptionsToDomain(options []Option) ([]bots.Option, error) {
 	return res, nil
 }
 
+func MapCapacityFromDomain(capacity bots.Capacity) Capacity {
+	return Capacity{
+		Limit:         capacity.Limit,
+		FullState:     capacity.FullState,
+		WaitlistState: capacity.WaitlistState,
+		PromotedState: capacity.PromotedState,
+	}
+}
+
+func MapCapacityToDomain(capacity Capacity) (bots.Capacity, error) {
+	return bots.NewCapacity(capacity.Limit, capacity.FullState, capacity.WaitlistState, capacity.PromotedState)
+}
+
+func MapWindowFromDomain(window bots.Window) Window {
+	return Window{
+		OpensAt:     window.OpensAt,
+		ClosesAt:    window.ClosesAt,
+		Timezone:    window.Timezone,
+		NotOpenText: window.NotOpenText,
+		ClosedText:  window.ClosedText,
+	}
+}
+
+func MapWindowToDomain(window Window) (bots.Window, error) {
+	return bots.NewWindow(window.OpensAt, window.ClosesAt, window.Timezone, window.NotOpenText, window.ClosedText)
+}
+
 func MapEntryPointFromDomain(entry bots.EntryPoint) EntryPoint {
 	return EntryPoint{
-		Key:   entry.Key,
-		State: entry.State,
+		Key:         entry.Key,
+		State:       entry.State,
+		Capacity:    MapCapacityFromDomain(entry.Capacity),
+		Window:      MapWindowFromDomain(entry.Window),
+		WindowState: entry.Window.StateAt(time.Now()).String(),
+		Reminder:    MapReminderFromDomain(entry.Reminder),
+		Expiry:      MapExpiryFromDomain(entry.Expiry),
+	}
+}
+
+func MapReminderFromDomain(reminder bots.Reminder) Reminder {
+	return Reminder{
+		After: reminder.After,
+		Limit: reminder.Limit,
+		Text:  reminder.Text,
 	}
 }
 
+func MapReminderToDomain(reminder Reminder) (bots.Reminder, error) {
+	return bots.NewReminder(reminder.After, reminder.Limit, reminder.Text)
+}
+
+func MapExpiryFromDomain(expiry bots.Expiry) Expiry {
+	return Expiry{
+		TTL:  expiry.TTL,
+		Mode: expiry.Mode.String(),
+		Text: expiry.Text,
+	}
+}
+
+func MapExpiryToDomain(expiry Expiry) (bots.Expiry, error) {
+	return bots.NewExpiry(expiry.TTL, expiry.Mode, expiry.Text)
+}
+
 func MapEntryPointToDomain(entry EntryPoint) (bots.EntryPoint, error) {
-	return bots.NewEntryPoint(entry.Key, entry.State)
+	e, err := bots.NewEntryPoint(entry.Key, entry.State)
+	if err != nil {
+		return bots.EntryPoint{}, err
+	}
+
+	c, err := MapCapacityToDomain(entry.Capacity)
+	if err != nil {
+		return bots.EntryPoint{}, err
+	}
+
+	w, err := MapWindowToDomain(entry.Window)
+	if err != nil {
+		return bots.EntryPoint{}, err
+	}
+
+	r, err := MapReminderToDomain(entry.Reminder)
+	if err != nil {
+		return bots.EntryPoint{}, err
+	}
+
+	x, err := MapExpiryToDomain(entry.Expiry)
+	if err != nil {
+		return bots.EntryPoint{}, err
+	}
+
+	return e.WithCapacity(c).WithWindow(w).WithReminder(r).WithExpiry(x), nil
 }
 
 func MapEntriesFromDomain(entries []bots.EntryPoint) []EntryPoint {
@@ -148,12 +416,22 @@ func MapMailingsToDomain(mailings []Mailing) ([]bots.Mailing, error) {
 
 func MapBlockFromDomain(block bots.Block) Block {
 	return Block{
-		Type:      block.Type.String(),
-		State:     block.State,
-		NextState: block.NextState,
-		Options:   MapOptionsFromDomain(block.Options),
-		Title:     block.Title,
-		Text:      block.Text,
+		Type:       block.Type.String(),
+		State:      block.State,
+		NextState:  block.NextState,
+		Options:    MapOptionsFromDomain(block.Options),
+		Title:      block.Title,
+		Text:       block.Text,
+		Unique:     block.Unique,
+		UniqueText: block.UniqueText,
+		Attachment: MapAttachmentFromDomain(block.Attachment),
+		Format:     block.Format.String(),
+
+		Fallback:      block.Fallback.String(),
+		FallbackState: block.FallbackState,
+		FallbackText:  block.FallbackText,
+
+		Translations: MapTranslationsFromDomain(block.Translations),
 	}
 }
 
@@ -162,7 +440,7 @@ func MapBlockToDomain(block Block) (bots.Block, error) {
 	if err != nil {
 		return bots.Block{}, err
 	}
-	return bots.NewBlock(
+	b, err := bots.NewBlock(
 		block.Type,
 		block.State,
 		block.NextState,
@@ -170,6 +448,77 @@ func MapBlockToDomain(block Block) (bots.Block, error) {
 		block.Title,
 		block.Text,
 	)
+	if err != nil {
+		return bots.Block{}, err
+	}
+	if block.Attachment.Type != "" {
+		a, err := MapAttachmentToDomain(block.Attachment)
+		if err != nil {
+			return bots.Block{}, err
+		}
+		b = b.WithAttachment(a)
+	}
+	if block.Format != "" {
+		b, err = b.WithFormat(block.Format)
+		if err != nil {
+			return bots.Block{}, err
+		}
+	}
+	if block.Fallback != "" {
+		b, err = b.WithFallback(block.Fallback, block.FallbackState, block.FallbackText)
+		if err != nil {
+			return bots.Block{}, err
+		}
+	}
+	for locale, t := range block.Translations {
+		tr, err := bots.NewTranslation(t.Title, t.Text)
+		if err != nil {
+			return bots.Block{}, err
+		}
+		b, err = b.WithTranslation(locale, tr)
+		if err != nil {
+			return bots.Block{}, err
+		}
+	}
+	if block.Unique {
+		return b.WithUnique(block.UniqueText)
+	}
+	return b, nil
+}
+
+func MapTranslationsFromDomain(ts map[string]bots.Translation) map[string]Translation {
+	if len(ts) == 0 {
+		return nil
+	}
+	res := make(map[string]Translation, len(ts))
+	for locale, t := range ts {
+		res[locale] = Translation{
+			Title: t.Title,
+			Text:  t.Text,
+		}
+	}
+	return res
+}
+
+func MapAttachmentFromDomain(attachment bots.Attachment) Attachment {
+	return Attachment{
+		Type:   attachment.Type.String(),
+		FileID: attachment.FileID,
+	}
+}
+
+func MapAttachmentToDomain(attachment Attachment) (bots.Attachment, error) {
+	return bots.NewAttachment(attachment.Type, attachment.FileID)
+}
+
+func MapFileFromDomain(file bots.File, content io.ReadCloser) File {
+	return File{
+		ID:       file.ID,
+		Name:     file.Name,
+		MimeType: file.MimeType,
+		Size:     file.Size,
+		Content:  content,
+	}
 }
 
 func MapBlocksFromDomain(blocks []bots.Block) []Block {
@@ -194,15 +543,20 @@ func MapBlocksToDomain(blocks []Block) ([]bots.Block, error) {
 
 func MapBotFromDomain(bot *bots.Bot) Bot {
 	return Bot{
-		UUID:      bot.UUID,
-		Entries:   MapEntriesFromDomain(bot.Entries()),
-		Blocks:    MapBlocksFromDomain(bot.Blocks()),
-		Mailings:  MapMailingsFromDomain(bot.Mailings()),
-		Name:      bot.Name,
-		Token:     bot.Token,
-		Status:    bot.Status.String(),
-		CreatedAt: bot.CreatedAt,
-		UpdatedAt: bot.UpdatedAt,
+		UUID:        bot.UUID,
+		Entries:     MapEntriesFromDomain(bot.Entries()),
+		Blocks:      MapBlocksFromDomain(bot.Blocks()),
+		Mailings:    MapMailingsFromDomain(bot.Mailings()),
+		Name:        bot.Name,
+		TokenMask:   bot.TokenMask,
+		SealedToken: bot.SealedToken,
+		TelegramID:  bot.TelegramID,
+		Username:    bot.Username,
+		Status:      bot.Status.String(),
+		LastError:   bot.LastError,
+		FailedAt:    bot.FailedAt,
+		CreatedAt:   bot.CreatedAt,
+		UpdatedAt:   bot.UpdatedAt,
 	}
 }
 
@@ -214,9 +568,173 @@ func MapBotsFromDomain(bs []*bots.Bot) []Bot {
 	return res
 }
 
+func MapMemberFromDomain(member bots.Member) Member {
+	return Member{
+		UserUUID: member.UserUUID,
+		Role:     member.Role.String(),
+	}
+}
+
+func MapMemberToDomain(member Member) (bots.Member, error) {
+	return bots.NewMember(member.UserUUID, member.Role)
+}
+
+func MapMembersFromDomain(members []bots.Member) []Member {
+	res := make([]Member, len(members))
+	for i, member := range members {
+		res[i] = MapMemberFromDomain(member)
+	}
+	return res
+}
+
+func MapWebhookFromDomain(webhook bots.Webhook) Webhook {
+	events := make([]string, len(webhook.Events))
+	for i, e := range webhook.Events {
+		events[i] = e.String()
+	}
+	return Webhook{
+		UUID:      webhook.UUID,
+		URL:       webhook.URL,
+		Events:    events,
+		CreatedAt: webhook.CreatedAt,
+	}
+}
+
+func MapWebhooksFromDomain(webhooks []bots.Webhook) []Webhook {
+	res := make([]Webhook, len(webhooks))
+	for i, webhook := range webhooks {
+		res[i] = MapWebhookFromDomain(webhook)
+	}
+	return res
+}
+
+func MapWebhookDeliveryFromDomain(delivery bots.WebhookDelivery) WebhookDelivery {
+	return WebhookDelivery{
+		UUID:         delivery.UUID,
+		Event:        delivery.Event.String(),
+		Status:       delivery.Status.String(),
+		Attempts:     delivery.Attempts,
+		ResponseCode: delivery.ResponseCode,
+		LastError:    delivery.LastError,
+		CreatedAt:    delivery.CreatedAt,
+		UpdatedAt:    delivery.UpdatedAt,
+	}
+}
+
+func MapWebhookDeliveriesFromDomain(deliveries []bots.WebhookDelivery) []WebhookDelivery {
+	res := make([]WebhookDelivery, len(deliveries))
+	for i, delivery := range deliveries {
+		res[i] = MapWebhookDeliveryFromDomain(delivery)
+	}
+	return res
+}
+
+func MapAnswerFromDomain(answer bots.Answer) Answer {
+	return Answer{
+		State: answer.State,
+		Text:  answer.Text,
+	}
+}
+
+func MapAnswersFromDomain(answers []bots.Answer) []Answer {
+	res := make([]Answer, len(answers))
+	for i, answer := range answers {
+		res[i] = MapAnswerFromDomain(answer)
+	}
+	slices.SortFunc(res, func(a, b Answer) int {
+		return a.State - b.State
+	})
+	return res
+}
+
+func MapParticipantFromDomain(prt *bots.Participant) Participant {
+	return Participant{
+		UserID:  prt.UserID,
+		State:   prt.State,
+		Locale:  prt.Locale,
+		Paused:  prt.IsPaused(),
+		Blocked: prt.IsBlocked(),
+		Answers: MapAnswersFromDomain(prt.Answers()),
+	}
+}
+
+func MapParticipantsFromDomain(prts []*bots.Participant) []Participant {
+	res := make([]Participant, len(prts))
+	for i, prt := range prts {
+		res[i] = MapParticipantFromDomain(prt)
+	}
+	return res
+}
+
+func MapThreadMessageFromDomain(msg bots.ThreadMessage) ThreadMessage {
+	return ThreadMessage{
+		Direction:  msg.Direction.String(),
+		Text:       msg.Text,
+		AuthorUUID: msg.AuthorUUID,
+		CreatedAt:  msg.CreatedAt,
+	}
+}
+
+func MapThreadMessagesFromDomain(msgs []bots.ThreadMessage) []ThreadMessage {
+	res := make([]ThreadMessage, len(msgs))
+	for i, msg := range msgs {
+		res[i] = MapThreadMessageFromDomain(msg)
+	}
+	return res
+}
+
+func MapThreadFromDomain(thread bots.Thread) Thread {
+	return Thread{
+		UserID:      thread.UserID,
+		Paused:      thread.Paused,
+		LastMessage: MapThreadMessageFromDomain(thread.LastMessage),
+	}
+}
+
+func MapThreadsFromDomain(threads []bots.Thread) []Thread {
+	res := make([]Thread, len(threads))
+	for i, thread := range threads {
+		res[i] = MapThreadFromDomain(thread)
+	}
+	return res
+}
+
+func MapAnswerEventFromDomain(event bots.Event) AnswerEvent {
+	return AnswerEvent{
+		ID:         event.ID,
+		Type:       event.Type.String(),
+		UserID:     event.UserID,
+		EntryKey:   event.EntryKey,
+		State:      event.Answer.State,
+		Answer:     event.Answer.Text,
+		OccurredAt: event.OccurredAt,
+	}
+}
+
+func MapSeatsFromDomain(seats *bots.Seats, capacity bots.Capacity) Seats {
+	return Seats{
+		EntryKey:   seats.EntryKey,
+		Capacity:   MapCapacityFromDomain(capacity),
+		Registered: seats.Registered(),
+		Waitlisted: seats.Waitlisted(),
+	}
+}
+
 func MapAnswersTableFromDomain(table *bots.AnswersTable) AnswersTable {
 	return AnswersTable{
 		THead: table.Head,
 		TBody: table.Body,
 	}
 }
+
+func MapLeasesFromDomain(leases []bots.Lease) []Lease {
+	res := make([]Lease, len(leases))
+	for i, lease := range leases {
+		res[i] = Lease{
+			BotUUID:    lease.BotUUID,
+			InstanceID: lease.InstanceID,
+			ExpiresAt:  lease.ExpiresAt,
+		}
+	}
+	return res
+}
@@ -5,31 +5,173 @@ package bots
 
 import (
 	"time"
+
+	openapi_types "github.com/oapi-codegen/runtime/types"
 )
 
 const (
 	BearerAuthScopes = "bearerAuth.Scopes"
 )
 
+// Defines values for AnswerEventType.
+const (
+	AnswerEventTypeAnswerGiven        AnswerEventType = "answer.given"
+	AnswerEventTypeFlowFinished       AnswerEventType = "flow.finished"
+	AnswerEventTypeParticipantStarted AnswerEventType = "participant.started"
+)
+
+// Defines values for AttachmentType.
+const (
+	Document AttachmentType = "document"
+	Photo    AttachmentType = "photo"
+	Voice    AttachmentType = "voice"
+)
+
+// Defines values for BlockFallback.
+const (
+	Other  BlockFallback = "other"
+	Reject BlockFallback = "reject"
+	State  BlockFallback = "state"
+)
+
+// Defines values for BlockFormat.
+const (
+	Html       BlockFormat = "html"
+	MarkdownV2 BlockFormat = "markdown_v2"
+	Plain      BlockFormat = "plain"
+)
+
 // Defines values for BlockType.
 const (
-	Message   BlockType = "message"
-	Question  BlockType = "question"
-	Selection BlockType = "selection"
+	BlockTypeFile      BlockType = "file"
+	BlockTypeLanguage  BlockType = "language"
+	BlockTypeMessage   BlockType = "message"
+	BlockTypeQuestion  BlockType = "question"
+	BlockTypeSelection BlockType = "selection"
+	BlockTypeTicket    BlockType = "ticket"
 )
 
 // Defines values for BotStatus.
 const (
-	Failed  BotStatus = "failed"
-	Started BotStatus = "started"
-	Stopped BotStatus = "stopped"
+	BotStatusFailed  BotStatus = "failed"
+	BotStatusStarted BotStatus = "started"
+	BotStatusStopped BotStatus = "stopped"
+)
+
+// Defines values for EventType.
+const (
+	EventTypeAnswerGiven        EventType = "answer.given"
+	EventTypeBotFailed          EventType = "bot.failed"
+	EventTypeFlowFinished       EventType = "flow.finished"
+	EventTypeMailingFinished    EventType = "mailing.finished"
+	EventTypeParticipantStarted EventType = "participant.started"
+)
+
+// Defines values for ExpiryMode.
+const (
+	Notify  ExpiryMode = "notify"
+	Restart ExpiryMode = "restart"
+)
+
+// Defines values for MemberRole.
+const (
+	Editor   MemberRole = "editor"
+	Operator MemberRole = "operator"
+	Owner    MemberRole = "owner"
+	Viewer   MemberRole = "viewer"
+)
+
+// Defines values for ThreadMessageDirection.
+const (
+	Incoming ThreadMessageDirection = "incoming"
+	Outgoing ThreadMessageDirection = "outgoing"
+)
+
+// Defines values for WebhookDeliveryStatus.
+const (
+	WebhookDeliveryStatusDelivered WebhookDeliveryStatus = "delivered"
+	WebhookDeliveryStatusFailed    WebhookDeliveryStatus = "failed"
+	WebhookDeliveryStatusPending   WebhookDeliveryStatus = "pending"
+)
+
+// Defines values for WindowState.
+const (
+	Closed  WindowState = "closed"
+	NotOpen WindowState = "not_open"
+	Open    WindowState = "open"
 )
 
+// Answer Ответ участника на блок.
+type Answer struct {
+	// State Состояние блока.
+	State int `json:"state"`
+
+	// Text Текст ответа.
+	Text string `json:"text"`
+}
+
+// AnswerEvent Событие потока ответов: начало прохождения, ответ на блок или завершение сценария участником.
+type AnswerEvent struct {
+	// Answer Текст ответа.
+	Answer *string `json:"answer,omitempty"`
+
+	// EntryKey Ключ точки входа, если участник начал прохождение.
+	EntryKey *string `json:"entryKey,omitempty"`
+
+	// EventID Монотонно возрастающий идентификатор события.
+	EventID int64 `json:"eventID"`
+
+	// OccurredAt Время события.
+	OccurredAt time.Time `json:"occurredAt"`
+
+	// State Состояние блока, на который дан ответ.
+	State *int `json:"state,omitempty"`
+
+	// Type Тип события.
+	Type AnswerEventType `json:"type"`
+
+	// UserID Telegram ID участника.
+	UserID int64 `json:"userID"`
+}
+
+// AnswerEventType Тип события.
+type AnswerEventType string
+
+// Attachment Файл, отправляемый вместе с текстом блока. Текст отправляется подписью к файлу.
+type Attachment struct {
+	// FileId ID файла, загруженного через POST /bots/{uuid}/files.
+	FileId string `json:"fileId"`
+
+	// Type Способ отправки файла.
+	Type AttachmentType `json:"type"`
+}
+
+// AttachmentType Способ отправки файла.
+type AttachmentType string
+
 // Block Минимальная структурная единица сценария бота. Представляет из себя сообщение, которое отправляет бот пользователю, и в зависимости от типа блока обрабатывается по-разному:
 //   - Сообщение (message) - просто сообщение от бота. Не ждет ответа пользователя и сразу переключает пользователя на следующий блок с состоянием next.
 //   - Вопрос (question) - сообщение от бот, ожидается ответ пользователя. После ответа пользователя переключает пользователя на следующий блок с состоянием next
 //   - Выбор (selection) - сообщение от бота, после которого ожидается ответ пользователя кнопкой или произвольным текстом. Если пользователь отвечает кнопкой, бот переключает его на следующий блок с состоянием next у выбранной опции (Option). Если пользователь отвечает произвольным текстом, переключает пользователя на следующий блок с состоянием next.
 type Block struct {
+	// Attachment Файл, отправляемый вместе с текстом блока. Текст отправляется подписью к файлу.
+	Attachment *Attachment `json:"attachment,omitempty"`
+
+	// Fallback Поведение блока с выбором (selection, language), если ответ участника не совпал ни с одной опцией:
+	//  - other (по умолчанию) - ответ сохраняется как произвольный текст, участник переходит на блок с состоянием nextState.
+	//  - reject - ответ отклоняется, бот отправляет fallbackText и повторяет вопрос. nextState должен быть равен 0.
+	//  - state - ответ сохраняется, участник переходит на блок с состоянием fallbackState. nextState должен быть равен 0.
+	Fallback *BlockFallback `json:"fallback,omitempty"`
+
+	// FallbackState Состояние (state) блока, на который переходит участник при fallback = state.
+	FallbackState *int `json:"fallbackState,omitempty"`
+
+	// FallbackText Сообщение, отправляемое при fallback = reject перед повтором вопроса.
+	FallbackText *string `json:"fallbackText,omitempty"`
+
+	// Format Режим форматирования текста блока. По умолчанию plain - текст без вёрстки.
+	Format *BlockFormat `json:"format,omitempty"`
+
 	// NextState Состояние (state) другого блока. Конкретное значение определяется типом (type) блока.
 	NextState int `json:"nextState"`
 
@@ -39,23 +181,47 @@ type Block struct {
 	// State Уникальный идентификатор блока в рамках бота. Не может равняться нулю.
 	State int `json:"state"`
 
-	// Text Текст сообщения бота. Не допускается использование вёрстки.
+	// Text Текст сообщения бота. Вёрстка задаётся режимом форматирования (format) и проверяется при создании бота. Подстановка {{N}} заменяется ответом участника на блок с состоянием N, экранированным согласно режиму форматирования.
 	Text string `json:"text"`
 
 	// Title Название блока. Используется в заголовке таблицы с ответами участников.
 	Title string `json:"title"`
 
+	// Translations Переводы блока. Ключ - язык (IETF language tag, например en или pt-br). Если для языка участника нет перевода, используется перевод основного языка (en для en-us), иначе исходный текст блока.
+	Translations *map[string]Translation `json:"translations,omitempty"`
+
 	// Type Тип кнопки:
 	//  - Сообщение (message) - просто сообщение от бота. Не ждет ответа пользователя и сразу переключает пользователя на следующий блок с состоянием next.
 	//  - Вопрос (question) - сообщение от бот, ожидается ответ пользователя. После ответа пользователя переключает пользователя на следующий блок с состоянием next
 	//  - Выбор (selection) - сообщение от бота, после которого ожидается ответ пользователя кнопкой или произвольным текстом. Если пользователь отвечает кнопкой, бот переключает его на следующий блок с состоянием next у выбранной опции (Option). Если пользователь отвечает произвольным текстом, переключает пользователя на следующий блок с состоянием next.
+	//  - Билет (ticket) - бот генерирует уникальный код билета участника и отправляет его QR-кодом вместе с текстом блока. Не ждет ответа пользователя и сразу переключает пользователя на следующий блок с состоянием next.
+	//  - Файл (file) - сообщение от бота, после которого ожидается фото, документ или голосовое сообщение от пользователя. Файл сохраняется как ответ, после чего бот переключает пользователя на следующий блок с состоянием next. На текстовый ответ бот повторяет вопрос.
+	//  - Язык (language) - блок с выбором, каждая опция которого задаёт язык (locale). Выбранный язык используется для переводов всех последующих сообщений участнику.
 	Type BlockType `json:"type"`
+
+	// Unique Ответ на вопрос должен быть уникальным в рамках бота (например, email или номер студенческого билета). Ответы сравниваются без учёта регистра и пробелов по краям. Допускается только для блоков с вопросом.
+	Unique *bool `json:"unique,omitempty"`
+
+	// UniqueText Сообщение, отправляемое при совпадении ответа с ответом другого участника. После него вопрос задаётся повторно.
+	UniqueText *string `json:"uniqueText,omitempty"`
 }
 
+// BlockFallback Поведение блока с выбором (selection, language), если ответ участника не совпал ни с одной опцией:
+//   - other (по умолчанию) - ответ сохраняется как произвольный текст, участник переходит на блок с состоянием nextState.
+//   - reject - ответ отклоняется, бот отправляет fallbackText и повторяет вопрос. nextState должен быть равен 0.
+//   - state - ответ сохраняется, участник переходит на блок с состоянием fallbackState. nextState должен быть равен 0.
+type BlockFallback string
+
+// BlockFormat Режим форматирования текста блока. По умолчанию plain - текст без вёрстки.
+type BlockFormat string
+
 // BlockType Тип кнопки:
 //   - Сообщение (message) - просто сообщение от бота. Не ждет ответа пользователя и сразу переключает пользователя на следующий блок с состоянием next.
 //   - Вопрос (question) - сообщение от бот, ожидается ответ пользователя. После ответа пользователя переключает пользователя на следующий блок с состоянием next
 //   - Выбор (selection) - сообщение от бота, после которого ожидается ответ пользователя кнопкой или произвольным текстом. Если пользователь отвечает кнопкой, бот переключает его на следующий блок с состоянием next у выбранной опции (Option). Если пользователь отвечает произвольным текстом, переключает пользователя на следующий блок с состоянием next.
+//   - Билет (ticket) - бот генерирует уникальный код билета участника и отправляет его QR-кодом вместе с текстом блока. Не ждет ответа пользователя и сразу переключает пользователя на следующий блок с состоянием next.
+//   - Файл (file) - сообщение от бота, после которого ожидается фото, документ или голосовое сообщение от пользователя. Файл сохраняется как ответ, после чего бот переключает пользователя на следующий блок с состоянием next. На текстовый ответ бот повторяет вопрос.
+//   - Язык (language) - блок с выбором, каждая опция которого задаёт язык (locale). Выбранный язык используется для переводов всех последующих сообщений участнику.
 type BlockType string
 
 // Bot Информация о боте.
@@ -72,6 +238,12 @@ type Bot struct {
 	// Entries Все точки входа бота, см. EntryPoint. Гарантировано существует точка входа start
 	Entries []EntryPoint `json:"entries"`
 
+	// FailedAt Время последней ошибки бота.
+	FailedAt *time.Time `json:"failedAt,omitempty"`
+
+	// LastError Причина последней ошибки бота. Заполняется при переходе в статус failed.
+	LastError *string `json:"lastError,omitempty"`
+
 	// Mailings Все рассылки бота, см. Mailings.
 	Mailings *[]Mailing `json:"mailings,omitempty"`
 
@@ -81,16 +253,37 @@ type Bot struct {
 	// Status Статус бота: started (запущен), stopped (не запущен), failed (ошибка запуска).
 	Status BotStatus `json:"status"`
 
-	// Token Телеграм токен бота. Получить токен можно в телеграм-боте @BotFather.
+	// TelegramId Идентификатор бота в Telegram, полученный при проверке токена.
+	TelegramId *int64 `json:"telegramId,omitempty"`
+
+	// Token Маскированный телеграм токен бота: видны только идентификатор бота и последние 4 символа.
 	Token string `json:"token"`
 
 	// UpdatedAt Время последнего обновления бота.
 	UpdatedAt time.Time `json:"updatedAt"`
+
+	// Username Имя пользователя бота в Telegram, полученное при проверке токена.
+	Username *string `json:"username,omitempty"`
 }
 
 // BotStatus Статус бота: started (запущен), stopped (не запущен), failed (ошибка запуска).
 type BotStatus string
 
+// Capacity Ограничение числа участников, завершивших скрипт точки входа. При отсутствии свободных мест участник направляется в блок fullState, а при наличии листа ожидания - в блок waitlistState. При освобождении места первый участник из листа ожидания направляется в блок promotedState.
+type Capacity struct {
+	// FullState Состояние (state) блока, отправляемого при отсутствии свободных мест.
+	FullState int `json:"fullState"`
+
+	// Limit Максимальное число участников.
+	Limit int `json:"limit"`
+
+	// PromotedState Состояние (state) блока, отправляемого при переходе из листа ожидания.
+	PromotedState *int `json:"promotedState,omitempty"`
+
+	// WaitlistState Состояние (state) блока, отправляемого при попадании в лист ожидания.
+	WaitlistState *int `json:"waitlistState,omitempty"`
+}
+
 // CreateMailing Рассылка и связанные с ней точка входа и блоки.
 type CreateMailing struct {
 	// Blocks Список блоков для рассылки. Обычно содержит единственный блок типа message.
@@ -108,11 +301,26 @@ type CreateMailing struct {
 
 // EntryPoint Точка входа для бота. Бот должен иметь как минимум точку входа "start". Иные точки входа используются для создания рассылок. Точка входа начинает скрипт бота с отправки блока с состоянием state пользователю.
 type EntryPoint struct {
+	// Capacity Ограничение числа участников, завершивших скрипт точки входа. При отсутствии свободных мест участник направляется в блок fullState, а при наличии листа ожидания - в блок waitlistState. При освобождении места первый участник из листа ожидания направляется в блок promotedState.
+	Capacity *Capacity `json:"capacity,omitempty"`
+
+	// Expiry Ограничение времени на прохождение скрипта точки входа. Если участник не завершил скрипт за ttlMinutes минут с момента входа, сессия истекает. В режиме notify бот отправляет text и сбрасывает прогресс, в режиме restart — начинает скрипт заново.
+	Expiry *Expiry `json:"expiry,omitempty"`
+
 	// Key Уникальный ключ точки входа бота.
 	Key string `json:"key"`
 
+	// Reminder Напоминание участникам, которые начали скрипт точки входа, но не отвечают на вопрос. Если участник не отвечает afterMinutes минут, бот отправляет text и повторяет текущий блок, но не более limit раз.
+	Reminder *Reminder `json:"reminder,omitempty"`
+
 	// State Состояние (state) первого блока в скрипте.
 	State int `json:"state"`
+
+	// Window Окно регистрации точки входа. Вне окна бот отвечает сообщением notOpenText или closedText вместо запуска скрипта.
+	Window *Window `json:"window,omitempty"`
+
+	// WindowState Текущее состояние окна регистрации.
+	WindowState *WindowState `json:"windowState,omitempty"`
 }
 
 // Error Описание ошибки.
@@ -120,9 +328,57 @@ type Error struct {
 	Message string `json:"message"`
 }
 
+// EventType Тип события бота:
+//   - participant.started - участник активировал точку входа;
+//   - answer.given - участник ответил на вопрос;
+//   - flow.finished - участник завершил сценарий;
+//   - mailing.finished - рассылка отправлена всем получателям;
+//   - bot.failed - бот завершился с ошибкой.
+type EventType string
+
+// Expiry Ограничение времени на прохождение скрипта точки входа. Если участник не завершил скрипт за ttlMinutes минут с момента входа, сессия истекает. В режиме notify бот отправляет text и сбрасывает прогресс, в режиме restart — начинает скрипт заново.
+type Expiry struct {
+	// Mode Действие при истечении сессии. По умолчанию notify.
+	Mode *ExpiryMode `json:"mode,omitempty"`
+
+	// Text Текст, отправляемый при истечении сессии.
+	Text *string `json:"text,omitempty"`
+
+	// TtlMinutes Время жизни сессии в минутах.
+	TtlMinutes int `json:"ttlMinutes"`
+}
+
+// ExpiryMode Действие при истечении сессии. По умолчанию notify.
+type ExpiryMode string
+
+// File defines model for File.
+type File struct {
+	Id       string `json:"id"`
+	MimeType string `json:"mimeType"`
+	Name     string `json:"name"`
+
+	// Size Размер файла в байтах.
+	Size int64 `json:"size"`
+}
+
 // GetBots Список ботов.
 type GetBots = []Bot
 
+// GetMembers Список участников команды бота.
+type GetMembers = []Member
+
+// GetThreadMessages История переписки участника с операторами.
+type GetThreadMessages = []ThreadMessage
+
+// GetThreads Список диалогов участников с операторами.
+type GetThreads = []Thread
+
+// GetWebhookDeliveries Журнал доставок вебхука, начиная с последних.
+type GetWebhookDeliveries = []WebhookDelivery
+
+// GetWebhooks Список вебхуков бота.
+type GetWebhooks = []Webhook
+
 // Mailing Рассылка от бота. При старте рассылки активирует точку входа с ключом entryKey всем пользователям, прошедшим блок с состоянием requiredState.
 type Mailing struct {
 	// EntryKey Ключ точки входа (EntryPoint), которая активируется при старте рассылки.
@@ -135,13 +391,72 @@ type Mailing struct {
 	RequiredState int `json:"requiredState"`
 }
 
+// Member Участник команды бота и его роль:
+//   - Владелец (owner) - полный доступ к боту, в том числе удаление бота и управление командой.
+//   - Редактор (editor) - изменение сценария бота, создание рассылок, запуск и остановка бота.
+//   - Оператор (operator) - запуск рассылок и просмотр ответов участников.
+//   - Наблюдатель (viewer) - только просмотр информации о боте.
+type Member struct {
+	// Role Роль пользователя в команде бота.
+	Role MemberRole `json:"role"`
+
+	// UserUUID UUID пользователя.
+	UserUUID string `json:"userUUID"`
+}
+
+// MemberRole Роль пользователя в команде бота.
+type MemberRole string
+
 // Option Опция для блока с выбором ответа. Представлена в telegram как кнопка в клавиатуре (ReplyKeyboard).
 type Option struct {
+	// Aliases Альтернативные ответы, которые также выбирают эту опцию. Ответы сравниваются без учёта регистра и пробелов по краям.
+	Aliases *[]string `json:"aliases,omitempty"`
+
+	// Id Необязательный идентификатор опции, уникальный в рамках блока.
+	Id *string `json:"id,omitempty"`
+
+	// Locale Язык, который выбирает участник этой опцией. Обязателен для опций блока выбора языка (language).
+	Locale *string `json:"locale,omitempty"`
+
 	// Next Состояние (state) следующего блока, если пользователь выбрал данную опцию.
 	Next int `json:"next"`
 
 	// Text Текст на кнопке.
 	Text string `json:"text"`
+
+	// Translations Переводы текста на кнопке. Ключ - язык (IETF language tag), значение - текст. Ответ участника сохраняется исходным текстом опции.
+	Translations *map[string]string `json:"translations,omitempty"`
+
+	// Value Значение, сохраняемое как ответ участника при выборе опции. По умолчанию совпадает с текстом на кнопке. Позволяет менять текст кнопки, не ломая выгрузки ответов и фильтры рассылок.
+	Value *string `json:"value,omitempty"`
+}
+
+// Participant Участник бота.
+type Participant struct {
+	Answers []Answer `json:"answers"`
+
+	// Blocked Заблокирован ли участник.
+	Blocked bool `json:"blocked"`
+
+	// Locale Язык участника: выбранный в блоке выбора языка или язык клиента Telegram.
+	Locale *string `json:"locale,omitempty"`
+
+	// Paused Приостановлен ли бот для участника.
+	Paused bool `json:"paused"`
+
+	// State Текущее состояние участника. 0, если участник не проходит сценарий.
+	State int `json:"state"`
+
+	// UserID Telegram ID участника.
+	UserID int64 `json:"userID"`
+}
+
+// ParticipantsPage Страница списка участников бота.
+type ParticipantsPage struct {
+	Participants []Participant `json:"participants"`
+
+	// Total Общее количество участников бота.
+	Total int `json:"total"`
 }
 
 // PostBots Данные, необходимые для создания бота.
@@ -165,8 +480,213 @@ type PostBots struct {
 	Token string `json:"token"`
 }
 
+// PostCheckIn defines model for PostCheckIn.
+type PostCheckIn struct {
+	// Code Код билета участника.
+	Code string `json:"code"`
+}
+
+// PostOperatorMessage Сообщение оператора участнику.
+type PostOperatorMessage struct {
+	// Text Текст сообщения.
+	Text string `json:"text"`
+}
+
+// PostResetParticipant Точка входа, к которой возвращается участник.
+type PostResetParticipant struct {
+	// EntryKey Ключ точки входа.
+	EntryKey string `json:"entryKey"`
+}
+
+// PostWebhook Данные, необходимые для создания вебхука.
+type PostWebhook struct {
+	// Events Типы событий, на которые подписан вебхук.
+	Events []EventType `json:"events"`
+
+	// Secret Секрет для подписи запросов. Не менее 16 символов.
+	Secret string `json:"secret"`
+
+	// Url URL, на который отправляются события.
+	Url string `json:"url"`
+}
+
+// Reminder Напоминание участникам, которые начали скрипт точки входа, но не отвечают на вопрос. Если участник не отвечает afterMinutes минут, бот отправляет text и повторяет текущий блок, но не более limit раз.
+type Reminder struct {
+	// AfterMinutes Время бездействия участника в минутах перед каждым напоминанием.
+	AfterMinutes int `json:"afterMinutes"`
+
+	// Limit Максимальное количество напоминаний. По умолчанию 1.
+	Limit *int `json:"limit,omitempty"`
+
+	// Text Текст напоминания, отправляемый перед повтором блока.
+	Text *string `json:"text,omitempty"`
+}
+
+// Seats defines model for Seats.
+type Seats struct {
+	// Capacity Ограничение числа участников, завершивших скрипт точки входа. При отсутствии свободных мест участник направляется в блок fullState, а при наличии листа ожидания - в блок waitlistState. При освобождении места первый участник из листа ожидания направляется в блок promotedState.
+	Capacity *Capacity `json:"capacity,omitempty"`
+
+	// EntryKey Ключ точки входа.
+	EntryKey string `json:"entryKey"`
+
+	// Registered Telegram ID участников, занявших места, в порядке регистрации.
+	Registered []int64 `json:"registered"`
+
+	// Waitlisted Telegram ID участников в листе ожидания, в порядке очереди.
+	Waitlisted []int64 `json:"waitlisted"`
+}
+
+// Thread Диалог участника с операторами.
+type Thread struct {
+	// LastMessage Сообщение в диалоге участника с операторами.
+	LastMessage ThreadMessage `json:"lastMessage"`
+
+	// Paused Приостановлен ли бот для участника.
+	Paused bool `json:"paused"`
+
+	// UserID Telegram ID участника.
+	UserID int64 `json:"userID"`
+}
+
+// ThreadMessage Сообщение в диалоге участника с операторами.
+type ThreadMessage struct {
+	// AuthorUUID UUID оператора, отправившего сообщение.
+	AuthorUUID *string `json:"authorUUID,omitempty"`
+
+	// CreatedAt Время отправки сообщения.
+	CreatedAt time.Time `json:"createdAt"`
+
+	// Direction Направление сообщения: от участника (incoming) или от оператора (outgoing).
+	Direction ThreadMessageDirection `json:"direction"`
+
+	// Text Текст сообщения.
+	Text string `json:"text"`
+}
+
+// ThreadMessageDirection Направление сообщения: от участника (incoming) или от оператора (outgoing).
+type ThreadMessageDirection string
+
+// Translation Перевод блока на другой язык.
+type Translation struct {
+	// Text Переведённый текст сообщения бота.
+	Text string `json:"text"`
+
+	// Title Переведённое название блока.
+	Title *string `json:"title,omitempty"`
+}
+
+// Webhook Вебхук бота. Секрет не возвращается.
+type Webhook struct {
+	// CreatedAt Время создания вебхука.
+	CreatedAt time.Time `json:"createdAt"`
+
+	// Events Типы событий, на которые подписан вебхук.
+	Events []EventType `json:"events"`
+
+	// Url URL, на который отправляются события.
+	Url string `json:"url"`
+
+	// WebhookUUID Уникальный идентификатор вебхука.
+	WebhookUUID string `json:"webhookUUID"`
+}
+
+// WebhookDelivery Запись журнала доставки события на вебхук.
+type WebhookDelivery struct {
+	// Attempts Количество совершённых попыток доставки.
+	Attempts int `json:"attempts"`
+
+	// CreatedAt Время возникновения события.
+	CreatedAt time.Time `json:"createdAt"`
+
+	// DeliveryUUID Уникальный идентификатор доставки. Передаётся в заголовке X-Itsreg-Delivery.
+	DeliveryUUID string `json:"deliveryUUID"`
+
+	// Event Тип события бота:
+	//  - participant.started - участник активировал точку входа;
+	//  - answer.given - участник ответил на вопрос;
+	//  - flow.finished - участник завершил сценарий;
+	//  - mailing.finished - рассылка отправлена всем получателям;
+	//  - bot.failed - бот завершился с ошибкой.
+	Event EventType `json:"event"`
+
+	// LastError Ошибка последней попытки доставки.
+	LastError *string `json:"lastError,omitempty"`
+
+	// ResponseCode HTTP-код ответа на последнюю попытку.
+	ResponseCode *int `json:"responseCode,omitempty"`
+
+	// Status Статус доставки: pending (ожидает отправки), delivered (доставлено), failed (попытки исчерпаны).
+	Status WebhookDeliveryStatus `json:"status"`
+
+	// UpdatedAt Время последней попытки доставки.
+	UpdatedAt time.Time `json:"updatedAt"`
+}
+
+// WebhookDeliveryStatus Статус доставки: pending (ожидает отправки), delivered (доставлено), failed (попытки исчерпаны).
+type WebhookDeliveryStatus string
+
+// Window Окно регистрации точки входа. Вне окна бот отвечает сообщением notOpenText или closedText вместо запуска скрипта.
+type Window struct {
+	// ClosedText Сообщение, отправляемое после закрытия регистрации.
+	ClosedText *string `json:"closedText,omitempty"`
+
+	// ClosesAt Время закрытия регистрации.
+	ClosesAt *time.Time `json:"closesAt,omitempty"`
+
+	// NotOpenText Сообщение, отправляемое до открытия регистрации.
+	NotOpenText *string `json:"notOpenText,omitempty"`
+
+	// OpensAt Время открытия регистрации.
+	OpensAt *time.Time `json:"opensAt,omitempty"`
+
+	// Timezone Часовой пояс окна регистрации в формате IANA. По умолчанию UTC.
+	Timezone *string `json:"timezone,omitempty"`
+}
+
+// WindowState Текущее состояние окна регистрации.
+type WindowState string
+
+// StreamAnswersParams defines parameters for StreamAnswers.
+type StreamAnswersParams struct {
+	// LastEventID Идентификатор последнего полученного события для возобновления потока.
+	LastEventID *int64 `json:"Last-Event-ID,omitempty"`
+}
+
+// UploadFileMultipartBody defines parameters for UploadFile.
+type UploadFileMultipartBody struct {
+	File openapi_types.File `json:"file"`
+}
+
+// GetParticipantsParams defines parameters for GetParticipants.
+type GetParticipantsParams struct {
+	// Offset Количество пропускаемых участников.
+	Offset *int `form:"offset,omitempty" json:"offset,omitempty"`
+
+	// Limit Максимальное количество участников на странице. По умолчанию 50.
+	Limit *int `form:"limit,omitempty" json:"limit,omitempty"`
+}
+
 // CreateBotJSONRequestBody defines body for CreateBot for application/json ContentType.
 type CreateBotJSONRequestBody = PostBots
 
+// CheckInJSONRequestBody defines body for CheckIn for application/json ContentType.
+type CheckInJSONRequestBody = PostCheckIn
+
+// UploadFileMultipartRequestBody defines body for UploadFile for multipart/form-data ContentType.
+type UploadFileMultipartRequestBody UploadFileMultipartBody
+
 // CreateMailingJSONRequestBody defines body for CreateMailing for application/json ContentType.
 type CreateMailingJSONRequestBody = CreateMailing
+
+// SetBotMemberJSONRequestBody defines body for SetBotMember for application/json ContentType.
+type SetBotMemberJSONRequestBody = Member
+
+// SendOperatorMessageJSONRequestBody defines body for SendOperatorMessage for application/json ContentType.
+type SendOperatorMessageJSONRequestBody = PostOperatorMessage
+
+// ResetParticipantJSONRequestBody defines body for ResetParticipant for application/json ContentType.
+type ResetParticipantJSONRequestBody = PostResetParticipant
+
+// CreateWebhookJSONRequestBody defines body for CreateWebhook for application/json ContentType.
+type CreateWebhookJSONRequestBody = PostWebhook
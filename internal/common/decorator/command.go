@@ -12,12 +12,14 @@ func ApplyCommandDecorators[H any](
 	logger *slog.Logger,
 	metricsClient MetricsClient,
 ) CommandHandler[H] {
-	return commandLoggingDecorator[H]{
-		base: commandMetricsDecorator[H]{
-			base:   handler,
-			client: metricsClient,
+	return commandTracingDecorator[H]{
+		base: commandLoggingDecorator[H]{
+			base: commandMetricsDecorator[H]{
+				base:   handler,
+				client: metricsClient,
+			},
+			logger: logger,
 		},
-		logger: logger,
 	}
 }
 
@@ -16,7 +16,7 @@ func (d commandLoggingDecorator[C]) Handle(ctx context.Context, cmd C) (err erro
 
 	logger := d.logger.With(
 		slog.String("command", handlerType),
-		slog.String("command_body", fmt.Sprintf("%v", cmd)),
+		slog.Attr{Key: "command_body", Value: bodyLogValue(cmd)},
 	)
 
 	logger.Debug("Executing command")
@@ -39,7 +39,7 @@ type queryLoggingDecorator[C any, R any] struct {
 func (d queryLoggingDecorator[C, R]) Handle(ctx context.Context, cmd C) (result R, err error) {
 	logger := d.logger.With(
 		slog.String("query", generateActionName(cmd)),
-		slog.String("query_body", fmt.Sprintf("%v", cmd)),
+		slog.Attr{Key: "query_body", Value: bodyLogValue(cmd)},
 	)
 
 	logger.Debug("Executing query")
@@ -53,3 +53,10 @@ func (d queryLoggingDecorator[C, R]) Handle(ctx context.Context, cmd C) (result
 
 	return d.base.Handle(ctx, cmd)
 }
+
+func bodyLogValue(body any) slog.Value {
+	if v, ok := body.(slog.LogValuer); ok {
+		return v.LogValue()
+	}
+	return slog.StringValue(fmt.Sprintf("%v", body))
+}
@@ -0,0 +1,55 @@
+package decorator_test
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"log/slog"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/bmstu-itstech/itsreg-bots/internal/common/decorator"
+	"github.com/bmstu-itstech/itsreg-bots/internal/common/metrics"
+)
+
+type SetSecret struct {
+	Name   string
+	Secret string
+}
+
+func (c SetSecret) LogValue() slog.Value {
+	c.Secret = "<redacted>"
+	return slog.StringValue(fmt.Sprintf("%v", c))
+}
+
+type setSecretHandler struct{}
+
+func (setSecretHandler) Handle(_ context.Context, _ SetSecret) error {
+	return nil
+}
+
+func TestCommandLoggingDecorator(t *testing.T) {
+	t.Run("should log command body", func(t *testing.T) {
+		buf := &bytes.Buffer{}
+		logger := slog.New(slog.NewTextHandler(buf, nil))
+		handler := decorator.ApplyCommandDecorators[RenameBot](renameBotHandler{}, logger, metrics.NoOp{})
+
+		err := handler.Handle(context.Background(), RenameBot{})
+		require.NoError(t, err)
+		require.Contains(t, buf.String(), "command=RenameBot")
+		require.Contains(t, buf.String(), "command_body={}")
+	})
+
+	t.Run("should redact sensitive command fields", func(t *testing.T) {
+		buf := &bytes.Buffer{}
+		logger := slog.New(slog.NewTextHandler(buf, nil))
+		handler := decorator.ApplyCommandDecorators[SetSecret](setSecretHandler{}, logger, metrics.NoOp{})
+
+		err := handler.Handle(context.Background(), SetSecret{Name: "hook", Secret: "0123456789abcdef"})
+		require.NoError(t, err)
+		require.Contains(t, buf.String(), "hook")
+		require.Contains(t, buf.String(), "<redacted>")
+		require.NotContains(t, buf.String(), "0123456789abcdef")
+	})
+}
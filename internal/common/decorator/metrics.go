@@ -2,13 +2,12 @@ package decorator
 
 import (
 	"context"
-	"fmt"
 	"strings"
 	"time"
 )
 
 type MetricsClient interface {
-	Inc(key string, value int)
+	ObserveHandler(kind string, action string, duration time.Duration, err error)
 }
 
 type commandMetricsDecorator[C any] struct {
@@ -22,15 +21,7 @@ func (d commandMetricsDecorator[C]) Handle(ctx context.Context, cmd C) (err erro
 	actionName := strings.ToLower(generateActionName(cmd))
 
 	defer func() {
-		end := time.Since(start)
-
-		d.client.Inc(fmt.Sprintf("commands.%s.duration", actionName), int(end.Seconds()))
-
-		if err == nil {
-			d.client.Inc(fmt.Sprintf("commands.%s.success", actionName), 1)
-		} else {
-			d.client.Inc(fmt.Sprintf("commands.%s.failure", actionName), 1)
-		}
+		d.client.ObserveHandler("command", actionName, time.Since(start), err)
 	}()
 
 	return d.base.Handle(ctx, cmd)
@@ -47,15 +38,7 @@ func (d queryMetricsDecorator[C, R]) Handle(ctx context.Context, query C) (resul
 	actionName := strings.ToLower(generateActionName(query))
 
 	defer func() {
-		end := time.Since(start)
-
-		d.client.Inc(fmt.Sprintf("querys.%s.duration", actionName), int(end.Seconds()))
-
-		if err == nil {
-			d.client.Inc(fmt.Sprintf("querys.%s.success", actionName), 1)
-		} else {
-			d.client.Inc(fmt.Sprintf("querys.%s.failure", actionName), 1)
-		}
+		d.client.ObserveHandler("query", actionName, time.Since(start), err)
 	}()
 
 	return d.base.Handle(ctx, query)
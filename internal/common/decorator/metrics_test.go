@@ -0,0 +1,129 @@
+package decorator_test
+
+import (
+	"context"
+	"errors"
+	"io"
+	"log/slog"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/bmstu-itstech/itsreg-bots/internal/common/decorator"
+)
+
+const handlerDelay = 5 * time.Millisecond
+
+type observation struct {
+	kind     string
+	action   string
+	duration time.Duration
+	err      error
+}
+
+type recordingMetricsClient struct {
+	observations []observation
+}
+
+func (c *recordingMetricsClient) ObserveHandler(kind string, action string, duration time.Duration, err error) {
+	c.observations = append(c.observations, observation{
+		kind:     kind,
+		action:   action,
+		duration: duration,
+		err:      err,
+	})
+}
+
+type RenameBot struct{}
+
+type renameBotHandler struct {
+	err error
+}
+
+func (h renameBotHandler) Handle(_ context.Context, _ RenameBot) error {
+	time.Sleep(handlerDelay)
+	return h.err
+}
+
+type GetBotName struct{}
+
+type getBotNameHandler struct {
+	err error
+}
+
+func (h getBotNameHandler) Handle(_ context.Context, _ GetBotName) (string, error) {
+	time.Sleep(handlerDelay)
+	return "name", h.err
+}
+
+func TestCommandMetricsDecorator(t *testing.T) {
+	logger := slog.New(slog.NewTextHandler(io.Discard, nil))
+
+	t.Run("should observe successful command", func(t *testing.T) {
+		client := &recordingMetricsClient{}
+		handler := decorator.ApplyCommandDecorators[RenameBot](renameBotHandler{}, logger, client)
+
+		err := handler.Handle(context.Background(), RenameBot{})
+		require.NoError(t, err)
+
+		require.Len(t, client.observations, 1)
+		obs := client.observations[0]
+		require.Equal(t, "command", obs.kind)
+		require.Equal(t, "renamebot", obs.action)
+		require.GreaterOrEqual(t, obs.duration, handlerDelay)
+		require.NoError(t, obs.err)
+	})
+
+	t.Run("should observe failed command", func(t *testing.T) {
+		handlerErr := errors.New("rename failed")
+		client := &recordingMetricsClient{}
+		handler := decorator.ApplyCommandDecorators[RenameBot](renameBotHandler{err: handlerErr}, logger, client)
+
+		err := handler.Handle(context.Background(), RenameBot{})
+		require.ErrorIs(t, err, handlerErr)
+
+		require.Len(t, client.observations, 1)
+		obs := client.observations[0]
+		require.Equal(t, "command", obs.kind)
+		require.Equal(t, "renamebot", obs.action)
+		require.GreaterOrEqual(t, obs.duration, handlerDelay)
+		require.ErrorIs(t, obs.err, handlerErr)
+	})
+}
+
+func TestQueryMetricsDecorator(t *testing.T) {
+	logger := slog.New(slog.NewTextHandler(io.Discard, nil))
+
+	t.Run("should observe successful query", func(t *testing.T) {
+		client := &recordingMetricsClient{}
+		handler := decorator.ApplyQueryDecorators[GetBotName, string](getBotNameHandler{}, logger, client)
+
+		name, err := handler.Handle(context.Background(), GetBotName{})
+		require.NoError(t, err)
+		require.Equal(t, "name", name)
+
+		require.Len(t, client.observations, 1)
+		obs := client.observations[0]
+		require.Equal(t, "query", obs.kind)
+		require.Equal(t, "getbotname", obs.action)
+		require.GreaterOrEqual(t, obs.duration, handlerDelay)
+		require.NoError(t, obs.err)
+	})
+
+	t.Run("should observe failed query", func(t *testing.T) {
+		handlerErr := errors.New("query failed")
+		client := &recordingMetricsClient{}
+		handler := decorator.ApplyQueryDecorators[GetBotName, string](getBotNameHandler{err: handlerErr}, logger, client)
+
+		_, err := handler.Handle(context.Background(), GetBotName{})
+		require.ErrorIs(t, err, handlerErr)
+
+		require.Len(t, client.observations, 1)
+		obs := client.observations[0]
+		require.Equal(t, "query", obs.kind)
+		require.Equal(t, "getbotname", obs.action)
+		require.GreaterOrEqual(t, obs.duration, handlerDelay)
+		require.ErrorIs(t, obs.err, handlerErr)
+	})
+}
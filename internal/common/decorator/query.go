@@ -10,12 +10,14 @@ func ApplyQueryDecorators[H any, R any](
 	logger *slog.Logger,
 	metricsClient MetricsClient,
 ) QueryHandler[H, R] {
-	return queryLoggingDecorator[H, R]{
-		base: queryMetricsDecorator[H, R]{
-			base:   handler,
-			client: metricsClient,
+	return queryTracingDecorator[H, R]{
+		base: queryLoggingDecorator[H, R]{
+			base: queryMetricsDecorator[H, R]{
+				base:   handler,
+				client: metricsClient,
+			},
+			logger: logger,
 		},
-		logger: logger,
 	}
 }
 
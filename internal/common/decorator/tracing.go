@@ -0,0 +1,33 @@
+package decorator
+
+import (
+	"context"
+
+	"github.com/bmstu-itstech/itsreg-bots/internal/common/tracing"
+)
+
+type commandTracingDecorator[C any] struct {
+	base CommandHandler[C]
+}
+
+func (d commandTracingDecorator[C]) Handle(ctx context.Context, cmd C) (err error) {
+	ctx, span := tracing.Start(ctx, "command."+generateActionName(cmd))
+	defer func() {
+		tracing.End(span, err)
+	}()
+
+	return d.base.Handle(ctx, cmd)
+}
+
+type queryTracingDecorator[C any, R any] struct {
+	base QueryHandler[C, R]
+}
+
+func (d queryTracingDecorator[C, R]) Handle(ctx context.Context, query C) (result R, err error) {
+	ctx, span := tracing.Start(ctx, "query."+generateActionName(query))
+	defer func() {
+		tracing.End(span, err)
+	}()
+
+	return d.base.Handle(ctx, query)
+}
@@ -0,0 +1,71 @@
+package health
+
+import (
+	"context"
+	"net/http"
+	"time"
+
+	"github.com/go-chi/render"
+)
+
+const checkTimeout = 3 * time.Second
+
+type Check func(ctx context.Context) error
+
+type namedCheck struct {
+	name  string
+	check Check
+}
+
+type Checker struct {
+	checks []namedCheck
+}
+
+func NewChecker() *Checker {
+	return &Checker{}
+}
+
+func (c *Checker) Add(name string, check Check) {
+	c.checks = append(c.checks, namedCheck{name: name, check: check})
+}
+
+func (c *Checker) Check(ctx context.Context) map[string]error {
+	ctx, cancel := context.WithTimeout(ctx, checkTimeout)
+	defer cancel()
+
+	res := make(map[string]error, len(c.checks))
+	for _, nc := range c.checks {
+		res[nc.name] = nc.check(ctx)
+	}
+	return res
+}
+
+type response struct {
+	Status string            `json:"status"`
+	Checks map[string]string `json:"checks,omitempty"`
+}
+
+func (c *Checker) LivenessHandler(w http.ResponseWriter, r *http.Request) {
+	render.JSON(w, r, response{Status: "ok"})
+}
+
+func (c *Checker) ReadinessHandler(w http.ResponseWriter, r *http.Request) {
+	resp := response{
+		Status: "ok",
+		Checks: make(map[string]string),
+	}
+
+	for name, err := range c.Check(r.Context()) {
+		if err != nil {
+			resp.Status = "unavailable"
+			resp.Checks[name] = err.Error()
+		} else {
+			resp.Checks[name] = "ok"
+		}
+	}
+
+	if resp.Status != "ok" {
+		render.Status(r, http.StatusServiceUnavailable)
+	}
+	render.JSON(w, r, resp)
+}
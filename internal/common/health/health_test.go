@@ -0,0 +1,84 @@
+package health_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/bmstu-itstech/itsreg-bots/internal/common/health"
+)
+
+type readinessResponse struct {
+	Status string            `json:"status"`
+	Checks map[string]string `json:"checks"`
+}
+
+func okCheck(context.Context) error {
+	return nil
+}
+
+func failingCheck(context.Context) error {
+	return errors.New("connection refused")
+}
+
+func serveReadiness(t *testing.T, checker *health.Checker) (int, readinessResponse) {
+	rec := httptest.NewRecorder()
+	checker.ReadinessHandler(rec, httptest.NewRequest(http.MethodGet, "/readyz", nil))
+
+	var resp readinessResponse
+	require.NoError(t, json.NewDecoder(rec.Body).Decode(&resp))
+	return rec.Code, resp
+}
+
+func TestChecker_ReadinessHandler(t *testing.T) {
+	t.Run("should be ready if all checks pass", func(t *testing.T) {
+		checker := health.NewChecker()
+		checker.Add("postgres", okCheck)
+		checker.Add("nats", okCheck)
+
+		code, resp := serveReadiness(t, checker)
+		require.Equal(t, http.StatusOK, code)
+		require.Equal(t, "ok", resp.Status)
+		require.Equal(t, map[string]string{"postgres": "ok", "nats": "ok"}, resp.Checks)
+	})
+
+	t.Run("should return 503 if dependency fails", func(t *testing.T) {
+		checker := health.NewChecker()
+		checker.Add("postgres", okCheck)
+		checker.Add("nats", failingCheck)
+
+		code, resp := serveReadiness(t, checker)
+		require.Equal(t, http.StatusServiceUnavailable, code)
+		require.Equal(t, "unavailable", resp.Status)
+		require.Equal(t, "ok", resp.Checks["postgres"])
+		require.Equal(t, "connection refused", resp.Checks["nats"])
+	})
+
+	t.Run("should pass deadline to checks", func(t *testing.T) {
+		checker := health.NewChecker()
+		checker.Add("postgres", func(ctx context.Context) error {
+			_, ok := ctx.Deadline()
+			if !ok {
+				return errors.New("expected deadline")
+			}
+			return nil
+		})
+
+		code, _ := serveReadiness(t, checker)
+		require.Equal(t, http.StatusOK, code)
+	})
+}
+
+func TestChecker_LivenessHandler(t *testing.T) {
+	checker := health.NewChecker()
+	checker.Add("nats", failingCheck)
+
+	rec := httptest.NewRecorder()
+	checker.LivenessHandler(rec, httptest.NewRequest(http.MethodGet, "/healthz", nil))
+	require.Equal(t, http.StatusOK, rec.Code)
+}
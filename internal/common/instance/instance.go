@@ -0,0 +1,24 @@
+package instance
+
+import (
+	"os"
+	"sync"
+
+	"github.com/ThreeDotsLabs/watermill"
+)
+
+var id = sync.OnceValue(func() string {
+	if id := os.Getenv("INSTANCE_ID"); id != "" {
+		return id
+	}
+
+	hostname, err := os.Hostname()
+	if err != nil || hostname == "" {
+		hostname = "instance"
+	}
+	return hostname + "-" + watermill.NewShortUUID()
+})
+
+func ID() string {
+	return id()
+}
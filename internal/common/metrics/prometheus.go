@@ -0,0 +1,81 @@
+package metrics
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+)
+
+const namespace = "itsreg_bots"
+
+const PublishedAtMetadata = "published_at"
+
+const (
+	MessagePublished = "published"
+	MessageSent      = "sent"
+	MessageFailed    = "failed"
+)
+
+var (
+	handlerDuration = promauto.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: namespace,
+		Name:      "handler_duration_seconds",
+		Help:      "Duration of command and query handlers.",
+		Buckets:   prometheus.DefBuckets,
+	}, []string{"kind", "action"})
+
+	handlerTotal = promauto.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "handler_total",
+		Help:      "Number of handled commands and queries by result.",
+	}, []string{"kind", "action", "result"})
+
+	runningBots = promauto.NewGauge(prometheus.GaugeOpts{
+		Namespace: namespace,
+		Name:      "running_bots",
+		Help:      "Number of bots running on this instance.",
+	})
+
+	botMessages = promauto.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "bot_messages_total",
+		Help:      "Number of bot messages by status.",
+	}, []string{"bot_uuid", "status"})
+
+	consumerLag = promauto.NewGaugeVec(prometheus.GaugeOpts{
+		Namespace: namespace,
+		Name:      "nats_consumer_lag_seconds",
+		Help:      "Delay between publishing and consuming the last NATS message.",
+	}, []string{"topic"})
+)
+
+type Prometheus struct{}
+
+func (p Prometheus) ObserveHandler(kind string, action string, duration time.Duration, err error) {
+	handlerDuration.WithLabelValues(kind, action).Observe(duration.Seconds())
+
+	result := "success"
+	if err != nil {
+		result = "failure"
+	}
+	handlerTotal.WithLabelValues(kind, action, result).Inc()
+}
+
+func SetRunningBots(n int) {
+	runningBots.Set(float64(n))
+}
+
+func IncBotMessages(botUUID string, status string) {
+	botMessages.WithLabelValues(botUUID, status).Inc()
+}
+
+func ObserveConsumerLag(topic string, lag time.Duration) {
+	consumerLag.WithLabelValues(topic).Set(lag.Seconds())
+}
+
+func Handler() http.Handler {
+	return promhttp.Handler()
+}
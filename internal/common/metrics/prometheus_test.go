@@ -0,0 +1,75 @@
+package metrics_test
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/stretchr/testify/require"
+
+	"github.com/bmstu-itstech/itsreg-bots/internal/common/metrics"
+)
+
+type handlerSamples struct {
+	successes float64
+	failures  float64
+	count     uint64
+	sum       float64
+}
+
+func gatherHandlerSamples(t *testing.T, kind string, action string) handlerSamples {
+	families, err := prometheus.DefaultGatherer.Gather()
+	require.NoError(t, err)
+
+	var res handlerSamples
+	for _, family := range families {
+		for _, m := range family.GetMetric() {
+			labels := make(map[string]string)
+			for _, l := range m.GetLabel() {
+				labels[l.GetName()] = l.GetValue()
+			}
+			if labels["kind"] != kind || labels["action"] != action {
+				continue
+			}
+
+			switch family.GetName() {
+			case "itsreg_bots_handler_total":
+				switch labels["result"] {
+				case "success":
+					res.successes = m.GetCounter().GetValue()
+				case "failure":
+					res.failures = m.GetCounter().GetValue()
+				}
+			case "itsreg_bots_handler_duration_seconds":
+				res.count = m.GetHistogram().GetSampleCount()
+				res.sum = m.GetHistogram().GetSampleSum()
+			}
+		}
+	}
+	return res
+}
+
+func TestPrometheus_ObserveHandler(t *testing.T) {
+	client := metrics.Prometheus{}
+
+	t.Run("should count success and observe duration", func(t *testing.T) {
+		client.ObserveHandler("command", "startbot", 250*time.Millisecond, nil)
+
+		samples := gatherHandlerSamples(t, "command", "startbot")
+		require.Equal(t, float64(1), samples.successes)
+		require.Zero(t, samples.failures)
+		require.Equal(t, uint64(1), samples.count)
+		require.InDelta(t, 0.25, samples.sum, 1e-9)
+	})
+
+	t.Run("should count failure and observe duration", func(t *testing.T) {
+		client.ObserveHandler("query", "getbot", 2*time.Second, errors.New("not found"))
+
+		samples := gatherHandlerSamples(t, "query", "getbot")
+		require.Zero(t, samples.successes)
+		require.Equal(t, float64(1), samples.failures)
+		require.Equal(t, uint64(1), samples.count)
+		require.InDelta(t, 2.0, samples.sum, 1e-9)
+	})
+}
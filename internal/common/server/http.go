@@ -10,16 +10,19 @@ import (
 	"github.com/go-chi/chi/v5/middleware"
 	"github.com/go-chi/cors"
 
+	"github.com/bmstu-itstech/itsreg-bots/internal/common/health"
 	"github.com/bmstu-itstech/itsreg-bots/internal/common/jwtauth"
 	"github.com/bmstu-itstech/itsreg-bots/internal/common/logs"
 	"github.com/bmstu-itstech/itsreg-bots/internal/common/logs/sl"
+	"github.com/bmstu-itstech/itsreg-bots/internal/common/metrics"
+	"github.com/bmstu-itstech/itsreg-bots/internal/common/tracing"
 )
 
-func RunHTTPServer(createHandler func(router chi.Router) http.Handler) {
-	RunHTTPServerOnAddr(":"+os.Getenv("PORT"), createHandler)
+func RunHTTPServer(checker *health.Checker, createHandler func(router chi.Router) http.Handler) {
+	RunHTTPServerOnAddr(":"+os.Getenv("PORT"), checker, createHandler)
 }
 
-func RunHTTPServerOnAddr(addr string, createHandler func(router chi.Router) http.Handler) {
+func RunHTTPServerOnAddr(addr string, checker *health.Checker, createHandler func(router chi.Router) http.Handler) {
 	log := logs.DefaultLogger()
 
 	apiRouter := chi.NewRouter()
@@ -27,6 +30,7 @@ func RunHTTPServerOnAddr(addr string, createHandler func(router chi.Router) http
 
 	rootRouter := chi.NewRouter()
 	rootRouter.Mount("/api", createHandler(apiRouter))
+	setServiceRoutes(rootRouter, checker)
 
 	log.Info("Starting: HTTP server", "addr", addr)
 
@@ -37,8 +41,38 @@ func RunHTTPServerOnAddr(addr string, createHandler func(router chi.Router) http
 	}
 }
 
+func RunAdminServer(checker *health.Checker, routes func(router chi.Router)) {
+	port := os.Getenv("ADMIN_PORT")
+	if port == "" {
+		return
+	}
+
+	log := logs.DefaultLogger()
+
+	router := chi.NewRouter()
+	router.Use(middleware.Recoverer)
+	setServiceRoutes(router, checker)
+	routes(router)
+
+	addr := ":" + port
+	log.Info("Starting: admin server", "addr", addr)
+
+	err := http.ListenAndServe(addr, router)
+	if err != nil {
+		log.Error("Unable to start admin server")
+		panic(err)
+	}
+}
+
+func setServiceRoutes(router chi.Router, checker *health.Checker) {
+	router.Handle("/metrics", metrics.Handler())
+	router.Get("/healthz", checker.LivenessHandler)
+	router.Get("/readyz", checker.ReadinessHandler)
+}
+
 func setMiddlewares(router *chi.Mux, log *slog.Logger) {
 	router.Use(middleware.RequestID)
+	router.Use(tracing.HTTPMiddleware)
 	router.Use(middleware.RealIP)
 	router.Use(sl.NewLoggerMiddleware(log))
 	router.Use(middleware.Recoverer)
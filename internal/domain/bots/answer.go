@@ -2,11 +2,13 @@ package bots
 
 import (
 	"errors"
+	"fmt"
 )
 
 type Answer struct {
-	State int
-	Text  string
+	State  int
+	Text   string
+	Upload Upload
 }
 
 func NewAnswer(state int, text string) (Answer, error) {
@@ -31,3 +33,48 @@ func MustNewAnswer(state int, text string) Answer {
 	}
 	return a
 }
+
+func NewUploadAnswer(state int, upload Upload) (Answer, error) {
+	if upload.IsZero() {
+		return Answer{}, errors.New("missing upload")
+	}
+
+	a, err := NewAnswer(state, upload.Name)
+	if err != nil {
+		return Answer{}, err
+	}
+	a.Upload = upload
+
+	return a, nil
+}
+
+func (a Answer) IsUpload() bool {
+	return !a.Upload.IsZero()
+}
+
+type AnswerNotUniqueError struct {
+	State int
+	Text  string
+}
+
+func (e AnswerNotUniqueError) Error() string {
+	return fmt.Sprintf("answer '%s' for state %d is already taken", e.Text, e.State)
+}
+
+func (b *Bot) RejectAnswer(prt *Participant, state int) ([]Message, error) {
+	block, ok := b.blocks[state]
+	if !ok || !block.Unique {
+		return nil, fmt.Errorf("block %d is not unique", state)
+	}
+
+	if prt.State != state {
+		return []Message{}, nil
+	}
+
+	msg, err := b.blockMessage(prt, block)
+	if err != nil {
+		return nil, err
+	}
+
+	return []Message{MustNewPlainMessage(block.UniqueText), msg}, nil
+}
@@ -3,10 +3,12 @@ package bots
 import (
 	"slices"
 	"strconv"
+	"time"
 )
 
 const (
-	userIDColumnName = "UserID"
+	userIDColumnName   = "UserID"
+	attendedColumnName = "Attended"
 )
 
 type AnswersTable struct {
@@ -15,14 +17,37 @@ type AnswersTable struct {
 }
 
 func NewAnswersTable(bot *Bot, prts []*Participant) *AnswersTable {
+	return NewAnswersTableWithTickets(bot, prts, nil)
+}
+
+func NewAnswersTableWithTickets(bot *Bot, prts []*Participant, tickets []*Ticket) *AnswersTable {
 	head, m := thead(bot)
 	body := tbody(prts, m)
+
+	if bot.HasTickets() {
+		head = append(head, attendedColumnName)
+		attended := attendance(tickets)
+		for i, prt := range prts {
+			body[i] = append(body[i], attended[prt.UserID])
+		}
+	}
+
 	return &AnswersTable{
 		Head: head,
 		Body: body,
 	}
 }
 
+func attendance(tickets []*Ticket) map[int64]string {
+	m := make(map[int64]string, len(tickets))
+	for _, t := range tickets {
+		if t.IsAttended() {
+			m[t.UserID] = t.AttendedAt.Format(time.DateTime)
+		}
+	}
+	return m
+}
+
 type mapStateToIndex map[int]int
 
 func thead(bot *Bot) ([]string, mapStateToIndex) {
@@ -54,12 +79,19 @@ func trow(prt *Participant, m mapStateToIndex) []string {
 	for _, ans := range prt.Answers() {
 		i, ok := m[ans.State]
 		if ok {
-			row[i] = ans.Text
+			row[i] = answerCell(prt, ans)
 		}
 	}
 	return row
 }
 
+func answerCell(prt *Participant, ans Answer) string {
+	if ans.IsUpload() && ans.Upload.FileID != "" {
+		return FileLink(prt.BotUUID, ans.Upload.FileID)
+	}
+	return ans.Text
+}
+
 func tbody(prts []*Participant, m mapStateToIndex) [][]string {
 	body := make([][]string, len(prts))
 	for i, prt := range prts {
@@ -0,0 +1,135 @@
+package bots
+
+import (
+	"fmt"
+
+	"github.com/bmstu-itstech/itsreg-bots/internal/common/commonerrs"
+)
+
+type AttachmentType struct {
+	s string
+}
+
+var (
+	PhotoAttachment    = AttachmentType{s: "photo"}
+	DocumentAttachment = AttachmentType{s: "document"}
+	VoiceAttachment    = AttachmentType{s: "voice"}
+)
+
+func (t AttachmentType) String() string {
+	return t.s
+}
+
+func (t AttachmentType) IsZero() bool {
+	return t == AttachmentType{}
+}
+
+func NewAttachmentTypeFromString(s string) (AttachmentType, error) {
+	switch s {
+	case "photo":
+		return PhotoAttachment, nil
+	case "document":
+		return DocumentAttachment, nil
+	case "voice":
+		return VoiceAttachment, nil
+	}
+	return AttachmentType{}, commonerrs.NewInvalidInputError(
+		fmt.Sprintf("invalid attachment type %s, expected one of ['photo', 'document', 'voice']", s),
+	)
+}
+
+type Attachment struct {
+	Type   AttachmentType
+	FileID string
+}
+
+func (a Attachment) IsZero() bool {
+	return a.Type.IsZero()
+}
+
+func NewAttachment(attachmentType string, fileID string) (Attachment, error) {
+	t, err := NewAttachmentTypeFromString(attachmentType)
+	if err != nil {
+		return Attachment{}, err
+	}
+
+	if fileID == "" {
+		return Attachment{}, commonerrs.NewInvalidInputError("expected not empty attachment file id")
+	}
+
+	return Attachment{
+		Type:   t,
+		FileID: fileID,
+	}, nil
+}
+
+func MustNewAttachment(attachmentType string, fileID string) Attachment {
+	a, err := NewAttachment(attachmentType, fileID)
+	if err != nil {
+		panic(err)
+	}
+	return a
+}
+
+type Upload struct {
+	Type           AttachmentType
+	TelegramFileID string
+	FileID         string
+	Name           string
+	MimeType       string
+	Size           int64
+}
+
+func (u Upload) IsZero() bool {
+	return u.Type.IsZero()
+}
+
+func NewUpload(
+	uploadType string,
+	telegramFileID string,
+	fileID string,
+	name string,
+	mimeType string,
+	size int64,
+) (Upload, error) {
+	t, err := NewAttachmentTypeFromString(uploadType)
+	if err != nil {
+		return Upload{}, err
+	}
+
+	if telegramFileID == "" {
+		return Upload{}, commonerrs.NewInvalidInputError("expected not empty telegram file id")
+	}
+
+	if name == "" {
+		name = t.String()
+	}
+
+	return Upload{
+		Type:           t,
+		TelegramFileID: telegramFileID,
+		FileID:         fileID,
+		Name:           name,
+		MimeType:       mimeType,
+		Size:           size,
+	}, nil
+}
+
+func MustNewUpload(
+	uploadType string,
+	telegramFileID string,
+	fileID string,
+	name string,
+	mimeType string,
+	size int64,
+) Upload {
+	u, err := NewUpload(uploadType, telegramFileID, fileID, name, mimeType, size)
+	if err != nil {
+		panic(err)
+	}
+	return u
+}
+
+func FileLink(botUUID string, fileID string) string {
+	return fmt.Sprintf("/bots/%s/files/%s", botUUID, fileID)
+}
@@ -0,0 +1,113 @@
+package bots_test
+
+import (
+	"math/rand/v2"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+
+	"github.com/bmstu-itstech/itsreg-bots/internal/common/commonerrs"
+	"github.com/bmstu-itstech/itsreg-bots/internal/domain/bots"
+)
+
+func TestNewAttachment(t *testing.T) {
+	t.Run("should create attachment", func(t *testing.T) {
+		a, err := bots.NewAttachment("photo", "file-id")
+		require.NoError(t, err)
+		require.Equal(t, bots.PhotoAttachment, a.Type)
+		require.Equal(t, "file-id", a.FileID)
+	})
+
+	t.Run("should return error if type is invalid", func(t *testing.T) {
+		_, err := bots.NewAttachment("video", "file-id")
+		require.ErrorAs(t, err, &commonerrs.InvalidInputError{})
+	})
+
+	t.Run("should return error if file id is empty", func(t *testing.T) {
+		_, err := bots.NewAttachment("document", "")
+		require.ErrorAs(t, err, &commonerrs.InvalidInputError{})
+	})
+}
+
+func TestBot_ProcessUpload(t *testing.T) {
+	poster := bots.MustNewAttachment("photo", uuid.NewString())
+	greetingBlock := bots.MustNewMessageBlock(1, 2, "Greeting", "Hello!").WithAttachment(poster)
+	cvBlock := bots.MustNewFileBlock(2, 3, "CV", "Send your CV")
+	endBlock := bots.MustNewMessageBlock(3, 0, "End", "Thanks!")
+
+	blocks := []bots.Block{greetingBlock, cvBlock, endBlock}
+	entries := []bots.EntryPoint{
+		bots.MustNewEntryPoint("start", 1),
+	}
+	botUUID := uuid.NewString()
+	userUUID := uuid.NewString()
+	bot := bots.MustNewBot(botUUID, userUUID, entries, nil, blocks, "Test bot", "12345678:XXXXXXXXXXXXXXXXXXXXXXXXXXXXXXXXXXX")
+
+	t.Run("should send attachment with block message", func(t *testing.T) {
+		prt := bots.MustNewParticipant(botUUID, rand.Int64())
+
+		resp, err := bot.Entry(prt, "start")
+		require.NoError(t, err)
+		requireMessages(t, []bots.Message{
+			bots.MustNewPlainMessage(greetingBlock.Text).WithAttachment(poster),
+			bots.MustNewPlainMessage(cvBlock.Text),
+		}, resp)
+	})
+
+	t.Run("should re-ask on text answer", func(t *testing.T) {
+		prt := bots.MustNewParticipant(botUUID, rand.Int64())
+		prt.SwitchTo(2)
+
+		resp, err := bot.Process(prt, "no file")
+		require.NoError(t, err)
+		requireMessages(t, []bots.Message{
+			bots.MustNewPlainMessage(cvBlock.Text),
+		}, resp)
+		require.Equal(t, 2, prt.State)
+		require.False(t, prt.HasAnswer(2))
+	})
+
+	t.Run("should store upload and advance", func(t *testing.T) {
+		prt := bots.MustNewParticipant(botUUID, rand.Int64())
+		prt.SwitchTo(2)
+
+		upload := bots.MustNewUpload("document", "tg-file-id", uuid.NewString(), "cv.pdf", "application/pdf", 1024)
+		resp, err := bot.ProcessUpload(prt, upload)
+		require.NoError(t, err)
+		requireMessages(t, []bots.Message{
+			bots.MustNewPlainMessage(endBlock.Text),
+		}, resp)
+
+		ans, ok := prt.Answer(2)
+		require.True(t, ok)
+		require.Equal(t, upload, ans.Upload)
+		require.Equal(t, "cv.pdf", ans.Text)
+	})
+
+	t.Run("should ignore upload outside file block", func(t *testing.T) {
+		prt := bots.MustNewParticipant(botUUID, rand.Int64())
+		prt.SwitchTo(3)
+
+		upload := bots.MustNewUpload("photo", "tg-file-id", "", "", "", 0)
+		resp, err := bot.ProcessUpload(prt, upload)
+		require.NoError(t, err)
+		require.Empty(t, resp)
+		require.False(t, prt.HasAnswer(3))
+	})
+
+	t.Run("should link upload from answers table", func(t *testing.T) {
+		prt := bots.MustNewParticipant(botUUID, 10)
+		prt.SwitchTo(2)
+
+		fileID := uuid.NewString()
+		_, err := bot.ProcessUpload(prt, bots.MustNewUpload("photo", "tg-file-id", fileID, "", "", 0))
+		require.NoError(t, err)
+
+		table := bots.NewAnswersTable(bot, []*bots.Participant{prt})
+		require.Equal(t, []string{"UserID", "CV"}, table.Head)
+		require.Equal(t, [][]string{
+			{"10", bots.FileLink(botUUID, fileID)},
+		}, table.Body)
+	})
+}
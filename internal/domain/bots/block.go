@@ -1,6 +1,12 @@
 package bots
 
-import "errors"
+import (
+	"errors"
+
+	"github.com/bmstu-itstech/itsreg-bots/internal/common/commonerrs"
+)
+
+const DefaultUniqueText = "Такой ответ уже был дан другим участником. Попробуйте ещё раз."
 
 type Block struct {
 	Type      BlockType
@@ -10,6 +16,18 @@ type Block struct {
 
 	Title string
 	Text  string
+
+	Unique     bool
+	UniqueText string
+
+	Attachment Attachment
+	Format     FormatMode
+
+	Fallback      FallbackMode
+	FallbackState int
+	FallbackText  string
+
+	Translations map[string]Translation
 }
 
 func (b Block) IsZero() bool {
@@ -36,6 +54,12 @@ func NewBlock(
 		return NewQuestionBlock(state, nextState, title, text)
 	case SelectionBlock:
 		return NewSelectionBlock(state, nextState, options, title, text)
+	case TicketBlock:
+		return NewTicketBlock(state, nextState, title, text)
+	case FileBlock:
+		return NewFileBlock(state, nextState, title, text)
+	case LanguageBlock:
+		return NewLanguageBlock(state, nextState, options, title, text)
 	}
 	return Block{}, errors.New("unknown type")
 }
@@ -137,6 +161,10 @@ func NewSelectionBlock(
 		return Block{}, errors.New("missing options")
 	}
 
+	if err := validateOptionIDs(options); err != nil {
+		return Block{}, err
+	}
+
 	if title == "" {
 		return Block{}, errors.New("missing title")
 	}
@@ -169,6 +197,124 @@ func MustNewSelectionBlock(
 	return b
 }
 
+func NewTicketBlock(
+	state int,
+	next int,
+	title string,
+	text string,
+) (Block, error) {
+	if state == 0 {
+		return Block{}, errors.New("missing state")
+	}
+
+	if title == "" {
+		return Block{}, errors.New("missing title")
+	}
+
+	if text == "" {
+		return Block{}, errors.New("missing text")
+	}
+
+	return Block{
+		Type:      TicketBlock,
+		State:     state,
+		NextState: next,
+		Options:   nil,
+		Title:     title,
+		Text:      text,
+	}, nil
+}
+
+func MustNewTicketBlock(
+	state int,
+	next int,
+	title string,
+	text string,
+) Block {
+	b, err := NewTicketBlock(state, next, title, text)
+	if err != nil {
+		panic(err)
+	}
+	return b
+}
+
+func NewFileBlock(
+	state int,
+	next int,
+	title string,
+	text string,
+) (Block, error) {
+	if state == 0 {
+		return Block{}, errors.New("missing state")
+	}
+
+	if title == "" {
+		return Block{}, errors.New("missing title")
+	}
+
+	if text == "" {
+		return Block{}, errors.New("missing text")
+	}
+
+	return Block{
+		Type:      FileBlock,
+		State:     state,
+		NextState: next,
+		Options:   nil,
+		Title:     title,
+		Text:      text,
+	}, nil
+}
+
+func MustNewFileBlock(
+	state int,
+	next int,
+	title string,
+	text string,
+) Block {
+	b, err := NewFileBlock(state, next, title, text)
+	if err != nil {
+		panic(err)
+	}
+	return b
+}
+
+func NewLanguageBlock(
+	state int,
+	next int,
+	options []Option,
+	title string,
+	text string,
+) (Block, error) {
+	b, err := NewSelectionBlock(state, next, options, title, text)
+	if err != nil {
+		return Block{}, err
+	}
+
+	for _, opt := range options {
+		if opt.Locale == "" {
+			return Block{}, commonerrs.NewInvalidInputErrorf("expected option %q of language block to have locale", opt.Text)
+		}
+	}
+
+	b.Type = LanguageBlock
+	return b, nil
+}
+
+func MustNewLanguageBlock(
+	state int,
+	next int,
+	options []Option,
+	title string,
+	text string,
+) Block {
+	b, err := NewLanguageBlock(state, next, options, title, text)
+	if err != nil {
+		panic(err)
+	}
+	return b
+}
+
 func UnmarshallBlockFromDB(
 	blockType string,
 	state int,
@@ -208,14 +354,70 @@ func UnmarshallBlockFromDB(
 	}, nil
 }
 
+func (b Block) WithUnique(text string) (Block, error) {
+	if b.Type != QuestionBlock {
+		return Block{}, commonerrs.NewInvalidInputError("expected question block to be unique")
+	}
+
+	if text == "" {
+		text = DefaultUniqueText
+	}
+
+	b.Unique = true
+	b.UniqueText = text
+	return b, nil
+}
+
+func (b Block) WithAttachment(a Attachment) Block {
+	b.Attachment = a
+	return b
+}
+
+func (b Block) WithFormat(format string) (Block, error) {
+	f, err := NewFormatModeFromString(format)
+	if err != nil {
+		return Block{}, err
+	}
+	b.Format = f
+	return b, nil
+}
+
 func (b Block) Message() (Message, error) {
+	var msg Message
+	var err error
 	switch b.Type {
-	case MessageBlock, QuestionBlock:
-		return NewPlainMessage(b.Text)
-	case SelectionBlock:
-		return NewMessageWithButtons(b.Text, b.Options)
+	case MessageBlock, QuestionBlock, FileBlock:
+		msg, err = NewPlainMessage(b.Text)
+	case SelectionBlock, LanguageBlock:
+		msg, err = NewMessageWithButtons(b.Text, b.Options)
+	default:
+		return Message{}, errors.New("unknown type")
+	}
+	if err != nil {
+		return Message{}, err
+	}
+	msg.Format = b.Format
+	return msg.WithAttachment(b.Attachment), nil
+}
+
+func (b Block) ExpectsAnswer() bool {
+	return b.Type == QuestionBlock || b.Type == SelectionBlock || b.Type == FileBlock || b.Type == LanguageBlock
+}
+
+func (b Block) Option(text string) (Option, bool) {
+	for _, opt := range b.Options {
+		if opt.Match(text) {
+			return opt, true
+		}
 	}
-	return Message{}, errors.New("unknown type")
+	return Option{}, false
+}
+
+func (b Block) Canonical(text string) string {
+	if opt, ok := b.Option(text); ok {
+		return opt.Value
+	}
+	return text
 }
 
 func (b Block) IsFinish() bool {
@@ -232,5 +434,23 @@ func (b Block) ChildrenStates() []int {
 		states = append(states, opt.Next)
 	}
 
+	if b.Fallback == StateFallback {
+		states = append(states, b.FallbackState)
+	}
+
 	return states
 }
+
+func validateOptionIDs(options []Option) error {
+	ids := make(map[string]struct{}, len(options))
+	for _, opt := range options {
+		if opt.ID == "" {
+			continue
+		}
+		if _, ok := ids[opt.ID]; ok {
+			return commonerrs.NewInvalidInputErrorf("duplicate option id %q", opt.ID)
+		}
+		ids[opt.ID] = struct{}{}
+	}
+	return nil
+}
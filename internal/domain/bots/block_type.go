@@ -14,6 +14,9 @@ var (
 	MessageBlock   = BlockType{s: "message"}
 	QuestionBlock  = BlockType{s: "question"}
 	SelectionBlock = BlockType{s: "selection"}
+	TicketBlock    = BlockType{s: "ticket"}
+	FileBlock      = BlockType{s: "file"}
+	LanguageBlock  = BlockType{s: "language"}
 )
 
 func (b BlockType) String() string {
@@ -32,8 +35,14 @@ func NewBlockTypeFromString(s string) (BlockType, error) {
 		return QuestionBlock, nil
 	case "selection":
 		return SelectionBlock, nil
+	case "ticket":
+		return TicketBlock, nil
+	case "file":
+		return FileBlock, nil
+	case "language":
+		return LanguageBlock, nil
 	}
 	return BlockType{}, commonerrs.NewInvalidInputError(
-		fmt.Sprintf("invalid block type %s, expected one of ['message', 'question', 'selection']", s),
+		fmt.Sprintf("invalid block type %s, expected one of ['message', 'question', 'selection', 'ticket', 'file', 'language']", s),
 	)
 }
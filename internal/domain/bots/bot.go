@@ -1,9 +1,7 @@
 package bots
 
 import (
-	"errors"
 	"fmt"
-	"regexp"
 	"time"
 
 	"github.com/bmstu-itstech/itsreg-bots/internal/common/commonerrs"
@@ -24,11 +22,21 @@ type Bot struct {
 	entryPoints map[string]EntryPoint
 	blocks      map[int]Block
 	mailings    map[string]Mailing
+	members     map[string]Member
 
 	Name   string
-	Token  string
 	Status Status
 
+	Token       string
+	SealedToken SealedToken
+	TokenMask   string
+
+	TelegramID int64
+	Username   string
+
+	LastError string
+	FailedAt  time.Time
+
 	CreatedAt time.Time
 	UpdatedAt time.Time
 }
@@ -70,8 +78,8 @@ func NewBot(
 		return nil, commonerrs.NewInvalidInputError("expected not empty token")
 	}
 
-	if !regexp.MustCompile("[0-9]{8,10}:[a-zA-Z0-9_-]{35}").MatchString(token) {
-		return nil, commonerrs.NewInvalidInputError("invalid token")
+	if !tokenRegexp.MatchString(token) {
+		return nil, ErrInvalidToken
 	}
 
 	for _, entry := range entries {
@@ -100,11 +108,19 @@ func NewBot(
 		return nil, err
 	}
 
+	for _, block := range bs {
+		if err = validateBlockFormat(block, bs); err != nil {
+			return nil, err
+		}
+	}
+
 	vs := vertices(bs)
 	for _, entry := range entries {
-		err := colorizeVertices(vs, entry.State)
-		if err != nil {
-			return nil, err
+		for _, state := range entry.States() {
+			err := colorizeVertices(vs, state)
+			if err != nil {
+				return nil, err
+			}
 		}
 	}
 
@@ -126,14 +142,21 @@ func NewBot(
 		}
 	}
 
+	mbs, err := mapMembers(ownerUUID, nil)
+	if err != nil {
+		return nil, err
+	}
+
 	return &Bot{
 		UUID:        uuid,
 		OwnerUUID:   ownerUUID,
 		entryPoints: es,
 		blocks:      bs,
 		mailings:    ms,
+		members:     mbs,
 		Name:        name,
 		Token:       token,
+		TokenMask:   MaskToken(token),
 		Status:      Stopped,
 		CreatedAt:   time.Now(),
 		UpdatedAt:   time.Now(),
@@ -162,9 +185,15 @@ func UnmarshallBotFromDB(
 	entries []EntryPoint,
 	mailings []Mailing,
 	blocks []Block,
+	members []Member,
 	name string,
-	token string,
+	sealedToken SealedToken,
+	tokenMask string,
+	telegramID int64,
+	username string,
 	status string,
+	lastError string,
+	failedAt time.Time,
 	createdAt time.Time,
 	updatedAt time.Time,
 ) (*Bot, error) {
@@ -180,8 +209,8 @@ func UnmarshallBotFromDB(
 		return nil, commonerrs.NewInvalidInputError("expected not empty name")
 	}
 
-	if token == "" {
-		return nil, commonerrs.NewInvalidInputError("expected not empty token")
+	if sealedToken.IsZero() {
+		return nil, commonerrs.NewInvalidInputError("expected not empty sealed token")
 	}
 
 	if status == "" {
@@ -229,6 +258,11 @@ func UnmarshallBotFromDB(
 		}
 	}
 
+	mbs, err := mapMembers(ownerUUID, members)
+	if err != nil {
+		return nil, err
+	}
+
 	st, err := NewStatusFromString(status)
 	if err != nil {
 		return nil, err
@@ -240,9 +274,15 @@ func UnmarshallBotFromDB(
 		entryPoints: es,
 		blocks:      bs,
 		mailings:    ms,
+		members:     mbs,
 		Name:        name,
-		Token:       token,
+		SealedToken: sealedToken,
+		TokenMask:   tokenMask,
+		TelegramID:  telegramID,
+		Username:    username,
 		Status:      st,
+		LastError:   lastError,
+		FailedAt:    failedAt,
 		CreatedAt:   createdAt,
 		UpdatedAt:   updatedAt,
 	}, nil
@@ -263,6 +303,12 @@ func (b *Bot) Children(block Block) []Block {
 		children = append(children, b.Children(next)...)
 	}
 
+	if block.Fallback == StateFallback {
+		next := b.blocks[block.FallbackState]
+		children = append(children, next)
+		children = append(children, b.Children(next)...)
+	}
+
 	return children
 }
 
@@ -274,6 +320,14 @@ func (b *Bot) Blocks() []Block {
 	return blocks
 }
 
+func (b *Bot) EntryPoint(key string) (EntryPoint, error) {
+	e, ok := b.entryPoints[key]
+	if !ok {
+		return EntryPoint{}, EntryNotFoundError{Key: key}
+	}
+	return e, nil
+}
+
 func (b *Bot) Entries() []EntryPoint {
 	entries := make([]EntryPoint, 0, len(b.entryPoints))
 	for _, entry := range b.entryPoints {
@@ -336,9 +390,11 @@ func (b *Bot) AddMailing(name string, requireState int, entry EntryPoint, blocks
 		return err
 	}
 	for _, entry := range b.entryPoints {
-		err = colorizeVertices(vs, entry.State)
-		if err != nil {
-			return err
+		for _, state := range entry.States() {
+			err = colorizeVertices(vs, state)
+			if err != nil {
+				return err
+			}
 		}
 	}
 
@@ -366,11 +422,15 @@ func (b *Bot) SetStatus(status Status) {
 	b.Status = status
 }
 
-var ErrPermissionDenied = errors.New("permission denied")
+func (b *Bot) Fail(reason string, at time.Time) {
+	b.Status = Failed
+	b.LastError = reason
+	b.FailedAt = at
+}
 
-func (b *Bot) CanSeeBot(userUUID string) error {
-	if b.OwnerUUID != userUUID {
-		return ErrPermissionDenied
+func (b *Bot) CanRestart() error {
+	if b.Status != Failed {
+		return commonerrs.NewInvalidInputErrorf("expected failed bot, got %s", b.Status.String())
 	}
 	return nil
 }
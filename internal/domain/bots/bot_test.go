@@ -1,10 +1,14 @@
 package bots_test
 
 import (
+	"errors"
+	"net/url"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/require"
 
+	"github.com/bmstu-itstech/itsreg-bots/internal/common/commonerrs"
 	"github.com/bmstu-itstech/itsreg-bots/internal/domain/bots"
 )
 
@@ -33,3 +37,145 @@ func TestBot_AddMailing(t *testing.T) {
 	)
 	require.NoError(t, err)
 }
+
+func TestBot_Members(t *testing.T) {
+	newBot := func() *bots.Bot {
+		return bots.MustNewBot(
+			"1234",
+			"owner",
+			[]bots.EntryPoint{
+				bots.MustNewEntryPoint("start", 1),
+			},
+			nil,
+			[]bots.Block{
+				bots.MustNewMessageBlock(1, 0, "Title", "Test text"),
+			},
+			"Test bot",
+			"12345678:XXXXXXXXXXXXXXXXXXXXXXXXXXXXXXXXXXX",
+		)
+	}
+
+	t.Run("should grant owner all permissions", func(t *testing.T) {
+		bot := newBot()
+		require.NoError(t, bot.CanSeeBot("owner"))
+		require.NoError(t, bot.CanOperateBot("owner"))
+		require.NoError(t, bot.CanEditBot("owner"))
+		require.NoError(t, bot.CanManageBot("owner"))
+	})
+
+	t.Run("should deny access to stranger", func(t *testing.T) {
+		bot := newBot()
+		require.ErrorIs(t, bot.CanSeeBot("stranger"), bots.ErrPermissionDenied)
+	})
+
+	t.Run("should check permissions by role", func(t *testing.T) {
+		bot := newBot()
+		require.NoError(t, bot.SetMember(bots.MustNewMember("editor", "editor")))
+		require.NoError(t, bot.SetMember(bots.MustNewMember("operator", "operator")))
+		require.NoError(t, bot.SetMember(bots.MustNewMember("viewer", "viewer")))
+
+		require.NoError(t, bot.CanEditBot("editor"))
+		require.ErrorIs(t, bot.CanManageBot("editor"), bots.ErrPermissionDenied)
+
+		require.NoError(t, bot.CanOperateBot("operator"))
+		require.ErrorIs(t, bot.CanEditBot("operator"), bots.ErrPermissionDenied)
+
+		require.NoError(t, bot.CanSeeBot("viewer"))
+		require.ErrorIs(t, bot.CanOperateBot("viewer"), bots.ErrPermissionDenied)
+	})
+
+	t.Run("should remove member", func(t *testing.T) {
+		bot := newBot()
+		require.NoError(t, bot.SetMember(bots.MustNewMember("viewer", "viewer")))
+		require.NoError(t, bot.RemoveMember("viewer"))
+		require.ErrorIs(t, bot.CanSeeBot("viewer"), bots.ErrPermissionDenied)
+		require.ErrorAs(t, bot.RemoveMember("viewer"), &bots.MemberNotFoundError{})
+	})
+
+	t.Run("should not change creator's role", func(t *testing.T) {
+		bot := newBot()
+		require.Error(t, bot.SetMember(bots.MustNewMember("owner", "viewer")))
+		require.Error(t, bot.RemoveMember("owner"))
+		require.NoError(t, bot.CanManageBot("owner"))
+	})
+}
+
+func TestBot_Fail(t *testing.T) {
+	bot := bots.MustNewBot(
+		"1234",
+		"owner",
+		[]bots.EntryPoint{
+			bots.MustNewEntryPoint("start", 1),
+		},
+		nil,
+		[]bots.Block{
+			bots.MustNewMessageBlock(1, 0, "Title", "Test text"),
+		},
+		"Test bot",
+		"12345678:XXXXXXXXXXXXXXXXXXXXXXXXXXXXXXXXXXX",
+	)
+	require.ErrorAs(t, bot.CanRestart(), &commonerrs.InvalidInputError{})
+
+	failedAt := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+	bot.Fail("Unauthorized", failedAt)
+	require.Equal(t, bots.Failed, bot.Status)
+	require.Equal(t, "Unauthorized", bot.LastError)
+	require.Equal(t, failedAt, bot.FailedAt)
+	require.NoError(t, bot.CanRestart())
+}
+
+func TestNewBot_Token(t *testing.T) {
+	newBot := func(token string) error {
+		_, err := bots.NewBot(
+			"1234",
+			"1234",
+			[]bots.EntryPoint{bots.MustNewEntryPoint("start", 1)},
+			nil,
+			[]bots.Block{bots.MustNewMessageBlock(1, 0, "Title", "Test text")},
+			"Test bot",
+			token,
+		)
+		return err
+	}
+
+	require.NoError(t, newBot("12345678:XXXXXXXXXXXXXXXXXXXXXXXXXXXXXXXXXXX"))
+	require.ErrorIs(t, newBot("12345678:XXXXXXXXXXXXXXXXXXXXXXXXXXXXXXXXXXX extra"), bots.ErrInvalidToken)
+	require.ErrorIs(t, newBot("prefix 12345678:XXXXXXXXXXXXXXXXXXXXXXXXXXXXXXXXXXX"), bots.ErrInvalidToken)
+	require.ErrorIs(t, newBot("12345678:short"), bots.ErrInvalidToken)
+}
+
+func TestMaskToken(t *testing.T) {
+	require.Equal(t,
+		"12345678:*******************************XXXX",
+		bots.MaskToken("12345678:XXXXXXXXXXXXXXXXXXXXXXXXXXXXXXXXXXX"),
+	)
+	require.Equal(t, "*****", bots.MaskToken("token"))
+}
+
+func TestRedactToken(t *testing.T) {
+	token := "12345678:XXXXXXXXXXXXXXXXXXXXXXXXXXXXXXXXXXX"
+
+	t.Run("should remove token from error text", func(t *testing.T) {
+		cause := &url.Error{
+			Op:  "Post",
+			URL: "https://api.telegram.org/bot" + token + "/getUpdates",
+			Err: errors.New("connection reset by peer"),
+		}
+
+		err := bots.RedactToken(cause, token)
+		require.NotContains(t, err.Error(), token)
+		require.Contains(t, err.Error(), "https://api.telegram.org/bot<token>/getUpdates")
+
+		var urlErr *url.Error
+		require.ErrorAs(t, err, &urlErr)
+	})
+
+	t.Run("should keep nil error", func(t *testing.T) {
+		require.NoError(t, bots.RedactToken(nil, token))
+	})
+
+	t.Run("should keep error without token", func(t *testing.T) {
+		cause := errors.New("bad gateway")
+		require.Equal(t, cause, bots.RedactToken(cause, ""))
+	})
+}
@@ -0,0 +1,308 @@
+package bots
+
+import (
+	"fmt"
+	"slices"
+
+	"github.com/bmstu-itstech/itsreg-bots/internal/common/commonerrs"
+)
+
+type Capacity struct {
+	Limit         int
+	FullState     int
+	WaitlistState int
+	PromotedState int
+}
+
+func (c Capacity) IsZero() bool {
+	return c == Capacity{}
+}
+
+func (c Capacity) IsLimited() bool {
+	return c.Limit > 0
+}
+
+func (c Capacity) HasWaitlist() bool {
+	return c.WaitlistState != 0
+}
+
+func (c Capacity) States() []int {
+	states := make([]int, 0, 3)
+	for _, s := range []int{c.FullState, c.WaitlistState, c.PromotedState} {
+		if s != 0 {
+			states = append(states, s)
+		}
+	}
+	return states
+}
+
+func NewCapacity(limit int, fullState int, waitlistState int, promotedState int) (Capacity, error) {
+	if limit < 0 {
+		return Capacity{}, commonerrs.NewInvalidInputError("expected non-negative capacity limit")
+	}
+
+	if limit == 0 {
+		if fullState != 0 || waitlistState != 0 || promotedState != 0 {
+			return Capacity{}, commonerrs.NewInvalidInputError("expected capacity limit for capacity states")
+		}
+		return Capacity{}, nil
+	}
+
+	if fullState == 0 {
+		return Capacity{}, commonerrs.NewInvalidInputError("expected not empty capacity full state")
+	}
+
+	if waitlistState != 0 && promotedState == 0 {
+		return Capacity{}, commonerrs.NewInvalidInputError("expected not empty capacity promoted state for waitlist")
+	}
+
+	if waitlistState == 0 && promotedState != 0 {
+		return Capacity{}, commonerrs.NewInvalidInputError("expected not empty capacity waitlist state for promoted state")
+	}
+
+	return Capacity{
+		Limit:         limit,
+		FullState:     fullState,
+		WaitlistState: waitlistState,
+		PromotedState: promotedState,
+	}, nil
+}
+
+func MustNewCapacity(limit int, fullState int, waitlistState int, promotedState int) Capacity {
+	c, err := NewCapacity(limit, fullState, waitlistState, promotedState)
+	if err != nil {
+		panic(err)
+	}
+	return c
+}
+
+type SeatStatus struct {
+	s string
+}
+
+var (
+	SeatRegistered = SeatStatus{s: "registered"}
+	SeatWaitlisted = SeatStatus{s: "waitlisted"}
+	SeatRejected   = SeatStatus{s: "rejected"}
+)
+
+func (s SeatStatus) String() string {
+	return s.s
+}
+
+func (s SeatStatus) IsZero() bool {
+	return s == SeatStatus{}
+}
+
+func NewSeatStatusFromString(s string) (SeatStatus, error) {
+	switch s {
+	case "registered":
+		return SeatRegistered, nil
+	case "waitlisted":
+		return SeatWaitlisted, nil
+	}
+	return SeatStatus{}, commonerrs.NewInvalidInputError(
+		fmt.Sprintf("invalid seat status %s, expected one of ['registered', 'waitlisted']", s),
+	)
+}
+
+type SeatNotFoundError struct {
+	EntryKey string
+	UserID   int64
+}
+
+func (e SeatNotFoundError) Error() string {
+	return fmt.Sprintf("seat of participant %d for entry '%s' not found", e.UserID, e.EntryKey)
+}
+
+type Seats struct {
+	BotUUID  string
+	EntryKey string
+
+	registered []int64
+	waitlisted []int64
+}
+
+func NewSeats(botUUID string, entryKey string) (*Seats, error) {
+	return UnmarshallSeatsFromDB(botUUID, entryKey, nil, nil)
+}
+
+func UnmarshallSeatsFromDB(
+	botUUID string,
+	entryKey string,
+	registered []int64,
+	waitlisted []int64,
+) (*Seats, error) {
+	if botUUID == "" {
+		return nil, commonerrs.NewInvalidInputError("expected not empty botUUID")
+	}
+
+	if entryKey == "" {
+		return nil, commonerrs.NewInvalidInputError("expected not empty entry key")
+	}
+
+	if registered == nil {
+		registered = make([]int64, 0)
+	}
+
+	if waitlisted == nil {
+		waitlisted = make([]int64, 0)
+	}
+
+	return &Seats{
+		BotUUID:    botUUID,
+		EntryKey:   entryKey,
+		registered: registered,
+		waitlisted: waitlisted,
+	}, nil
+}
+
+func (s *Seats) Registered() []int64 {
+	return slices.Clone(s.registered)
+}
+
+func (s *Seats) Waitlisted() []int64 {
+	return slices.Clone(s.waitlisted)
+}
+
+func (s *Seats) Status(userID int64) (SeatStatus, bool) {
+	if slices.Contains(s.registered, userID) {
+		return SeatRegistered, true
+	}
+	if slices.Contains(s.waitlisted, userID) {
+		return SeatWaitlisted, true
+	}
+	return SeatStatus{}, false
+}
+
+func (s *Seats) IsFull(c Capacity) bool {
+	return c.IsLimited() && len(s.registered) >= c.Limit
+}
+
+func (s *Seats) Accepts(c Capacity, userID int64) bool {
+	if _, ok := s.Status(userID); ok {
+		return true
+	}
+	return !s.IsFull(c) || c.HasWaitlist()
+}
+
+func (s *Seats) Take(c Capacity, userID int64) SeatStatus {
+	if status, ok := s.Status(userID); ok {
+		return status
+	}
+
+	if !s.IsFull(c) {
+		s.registered = append(s.registered, userID)
+		return SeatRegistered
+	}
+
+	if c.HasWaitlist() {
+		s.waitlisted = append(s.waitlisted, userID)
+		return SeatWaitlisted
+	}
+
+	return SeatRejected
+}
+
+func (s *Seats) Release(c Capacity, userID int64) ([]int64, error) {
+	if i := slices.Index(s.waitlisted, userID); i >= 0 {
+		s.waitlisted = slices.Delete(s.waitlisted, i, i+1)
+		return []int64{}, nil
+	}
+
+	i := slices.Index(s.registered, userID)
+	if i < 0 {
+		return nil, SeatNotFoundError{EntryKey: s.EntryKey, UserID: userID}
+	}
+	s.registered = slices.Delete(s.registered, i, i+1)
+
+	promoted := make([]int64, 0, 1)
+	for len(s.waitlisted) > 0 && !s.IsFull(c) {
+		next := s.waitlisted[0]
+		s.waitlisted = s.waitlisted[1:]
+		s.registered = append(s.registered, next)
+		promoted = append(promoted, next)
+	}
+
+	return promoted, nil
+}
+
+func (b *Bot) IsEntryFull(prt *Participant, key string, seats *Seats) bool {
+	e, ok := b.entryPoints[key]
+	if !ok || !e.Capacity.IsLimited() {
+		return false
+	}
+	return !seats.Accepts(e.Capacity, prt.UserID)
+}
+
+func (b *Bot) RejectEntry(prt *Participant, key string) ([]Message, error) {
+	e, ok := b.entryPoints[key]
+	if !ok {
+		return nil, EntryNotFoundError{Key: key}
+	}
+
+	if prt.IsPaused() || prt.IsBlocked() {
+		return []Message{}, nil
+	}
+
+	prt.EntryKey = ""
+	return b.routeTo(prt, e.Capacity.FullState)
+}
+
+func (b *Bot) RequiresSeat(prt *Participant) bool {
+	if prt.EntryKey == "" || prt.IsProcessing() {
+		return false
+	}
+	e, ok := b.entryPoints[prt.EntryKey]
+	return ok && e.Capacity.IsLimited()
+}
+
+func (b *Bot) TakeSeat(prt *Participant, seats *Seats) ([]Message, error) {
+	if !b.RequiresSeat(prt) || prt.EntryKey != seats.EntryKey {
+		return []Message{}, nil
+	}
+
+	e := b.entryPoints[prt.EntryKey]
+	prt.EntryKey = ""
+
+	switch seats.Take(e.Capacity, prt.UserID) {
+	case SeatWaitlisted:
+		return b.routeTo(prt, e.Capacity.WaitlistState)
+	case SeatRejected:
+		return b.routeTo(prt, e.Capacity.FullState)
+	}
+
+	return []Message{}, nil
+}
+
+func (b *Bot) ReleaseSeat(userID int64, seats *Seats) ([]int64, error) {
+	e, ok := b.entryPoints[seats.EntryKey]
+	if !ok {
+		return nil, EntryNotFoundError{Key: seats.EntryKey}
+	}
+	return seats.Release(e.Capacity, userID)
+}
+
+func (b *Bot) Promote(prt *Participant, key string) ([]Message, error) {
+	e, ok := b.entryPoints[key]
+	if !ok {
+		return nil, EntryNotFoundError{Key: key}
+	}
+
+	if prt.IsBlocked() || e.Capacity.PromotedState == 0 {
+		return []Message{}, nil
+	}
+
+	return b.routeTo(prt, e.Capacity.PromotedState)
+}
+
+func (b *Bot) routeTo(prt *Participant, state int) ([]Message, error) {
+	if state == 0 {
+		return []Message{}, nil
+	}
+
+	b.cleanAllAnswersFrom(state, prt)
+	prt.SwitchTo(state)
+
+	return b.processStart(prt)
+}
@@ -0,0 +1,147 @@
+package bots_test
+
+import (
+	"math/rand/v2"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+
+	"github.com/bmstu-itstech/itsreg-bots/internal/common/commonerrs"
+	"github.com/bmstu-itstech/itsreg-bots/internal/domain/bots"
+)
+
+func TestNewCapacity(t *testing.T) {
+	t.Run("should create unlimited capacity", func(t *testing.T) {
+		c, err := bots.NewCapacity(0, 0, 0, 0)
+		require.NoError(t, err)
+		require.True(t, c.IsZero())
+		require.False(t, c.IsLimited())
+	})
+
+	t.Run("should create capacity with waitlist", func(t *testing.T) {
+		c, err := bots.NewCapacity(10, 2, 3, 4)
+		require.NoError(t, err)
+		require.True(t, c.IsLimited())
+		require.True(t, c.HasWaitlist())
+		require.Equal(t, []int{2, 3, 4}, c.States())
+	})
+
+	t.Run("should return error if full state is empty", func(t *testing.T) {
+		_, err := bots.NewCapacity(10, 0, 0, 0)
+		require.ErrorAs(t, err, &commonerrs.InvalidInputError{})
+	})
+
+	t.Run("should return error if promoted state is empty for waitlist", func(t *testing.T) {
+		_, err := bots.NewCapacity(10, 2, 3, 0)
+		require.ErrorAs(t, err, &commonerrs.InvalidInputError{})
+	})
+
+	t.Run("should return error if states are set without limit", func(t *testing.T) {
+		_, err := bots.NewCapacity(0, 2, 0, 0)
+		require.ErrorAs(t, err, &commonerrs.InvalidInputError{})
+	})
+}
+
+func TestBot_Seats(t *testing.T) {
+	/*
+		TEST BOT SCHEME
+		1 --> 2 --> 0
+		3 --> 0 (full)
+		4 --> 0 (waitlist)
+		5 --> 0 (promoted)
+	*/
+	questionBlock := bots.MustNewQuestionBlock(1, 2, "Name", "What's your name?")
+	doneBlock := bots.MustNewMessageBlock(2, 0, "Done", "You are registered!")
+	fullBlock := bots.MustNewMessageBlock(3, 0, "Full", "No seats left")
+	waitlistBlock := bots.MustNewMessageBlock(4, 0, "Waitlist", "You are on the waitlist")
+	promotedBlock := bots.MustNewMessageBlock(5, 0, "Promoted", "A seat is available for you")
+
+	blocks := []bots.Block{questionBlock, doneBlock, fullBlock, waitlistBlock, promotedBlock}
+	entries := []bots.EntryPoint{
+		bots.MustNewEntryPoint("start", 1).WithCapacity(bots.MustNewCapacity(1, 3, 4, 5)),
+	}
+	botUUID := uuid.NewString()
+	bot := bots.MustNewBot(botUUID, uuid.NewString(), entries, nil, blocks, "Test bot", "12345678:XXXXXXXXXXXXXXXXXXXXXXXXXXXXXXXXXXX")
+
+	register := func(t *testing.T, seats *bots.Seats) (*bots.Participant, []bots.Message) {
+		prt := bots.MustNewParticipant(botUUID, rand.Int64())
+		_, err := bot.Entry(prt, "start")
+		require.NoError(t, err)
+		require.False(t, bot.RequiresSeat(prt))
+
+		_, err = bot.Process(prt, "Ivan")
+		require.NoError(t, err)
+		require.True(t, bot.RequiresSeat(prt))
+
+		resp, err := bot.TakeSeat(prt, seats)
+		require.NoError(t, err)
+		require.False(t, bot.RequiresSeat(prt))
+		return prt, resp
+	}
+
+	t.Run("should register and waitlist participants", func(t *testing.T) {
+		seats, err := bots.NewSeats(botUUID, "start")
+		require.NoError(t, err)
+
+		first, resp := register(t, seats)
+		requireMessages(t, []bots.Message{}, resp)
+
+		second, resp := register(t, seats)
+		requireMessages(t, []bots.Message{bots.MustNewPlainMessage(waitlistBlock.Text)}, resp)
+
+		require.Equal(t, []int64{first.UserID}, seats.Registered())
+		require.Equal(t, []int64{second.UserID}, seats.Waitlisted())
+	})
+
+	t.Run("should promote waitlisted participant on release", func(t *testing.T) {
+		seats, err := bots.NewSeats(botUUID, "start")
+		require.NoError(t, err)
+
+		first, _ := register(t, seats)
+		second, _ := register(t, seats)
+
+		promoted, err := bot.ReleaseSeat(first.UserID, seats)
+		require.NoError(t, err)
+		require.Equal(t, []int64{second.UserID}, promoted)
+		require.Equal(t, []int64{second.UserID}, seats.Registered())
+		require.Empty(t, seats.Waitlisted())
+
+		resp, err := bot.Promote(second, "start")
+		require.NoError(t, err)
+		requireMessages(t, []bots.Message{bots.MustNewPlainMessage(promotedBlock.Text)}, resp)
+	})
+
+	t.Run("should accept registered participant on repeated entry", func(t *testing.T) {
+		seats, err := bots.NewSeats(botUUID, "start")
+		require.NoError(t, err)
+
+		prt, _ := register(t, seats)
+		require.False(t, bot.IsEntryFull(prt, "start", seats))
+	})
+
+	t.Run("should return error if seat not found", func(t *testing.T) {
+		seats, err := bots.NewSeats(botUUID, "start")
+		require.NoError(t, err)
+
+		_, err = bot.ReleaseSeat(rand.Int64(), seats)
+		require.ErrorAs(t, err, &bots.SeatNotFoundError{})
+	})
+
+	t.Run("should reject entry if no seats and no waitlist", func(t *testing.T) {
+		entries := []bots.EntryPoint{
+			bots.MustNewEntryPoint("start", 1).WithCapacity(bots.MustNewCapacity(1, 3, 0, 0)),
+		}
+		bot := bots.MustNewBot(botUUID, uuid.NewString(), entries, nil, blocks[:3], "Test bot", "12345678:XXXXXXXXXXXXXXXXXXXXXXXXXXXXXXXXXXX")
+
+		seats, err := bots.UnmarshallSeatsFromDB(botUUID, "start", []int64{rand.Int64()}, nil)
+		require.NoError(t, err)
+
+		prt := bots.MustNewParticipant(botUUID, rand.Int64())
+		require.True(t, bot.IsEntryFull(prt, "start", seats))
+
+		resp, err := bot.RejectEntry(prt, "start")
+		require.NoError(t, err)
+		requireMessages(t, []bots.Message{bots.MustNewPlainMessage(fullBlock.Text)}, resp)
+	})
+}
@@ -1,6 +1,9 @@
 package bots
 
-import "fmt"
+import (
+	"fmt"
+	"time"
+)
 
 type EntryNotFoundError struct {
 	Key string
@@ -11,6 +14,31 @@ func (e EntryNotFoundError) Error() string {
 }
 
 func (b *Bot) Entry(prt *Participant, key string) ([]Message, error) {
+	return b.EntryAt(prt, key, time.Now())
+}
+
+func (b *Bot) EntryAt(prt *Participant, key string, t time.Time) ([]Message, error) {
+	e, ok := b.entryPoints[key]
+	if !ok {
+		return nil, EntryNotFoundError{Key: key}
+	}
+
+	if prt.IsPaused() || prt.IsBlocked() {
+		return []Message{}, nil
+	}
+
+	if msg, ok := e.Window.messageAt(t); ok {
+		return []Message{msg}, nil
+	}
+
+	return b.resetAt(prt, key, t)
+}
+
+func (b *Bot) Reset(prt *Participant, key string) ([]Message, error) {
+	return b.resetAt(prt, key, time.Now())
+}
+
+func (b *Bot) resetAt(prt *Participant, key string, t time.Time) ([]Message, error) {
 	e, ok := b.entryPoints[key]
 	if !ok {
 		return nil, EntryNotFoundError{Key: key}
@@ -18,9 +46,18 @@ func (b *Bot) Entry(prt *Participant, key string) ([]Message, error) {
 
 	b.cleanAllAnswersFrom(e.State, prt)
 	prt.SwitchTo(e.State)
+	prt.FlowEntry = key
+	prt.FlowStartedAt = t
+
+	prt.EntryKey = ""
+	if e.Capacity.IsLimited() {
+		prt.EntryKey = key
+	}
 
 	response := make([]Message, 0, 1)
-	response = append(response)
+	if prt.IsPaused() || prt.IsBlocked() {
+		return response, nil
+	}
 
 	ms, err := b.processStart(prt)
 	if err != nil {
@@ -3,8 +3,12 @@ package bots
 import "github.com/bmstu-itstech/itsreg-bots/internal/common/commonerrs"
 
 type EntryPoint struct {
-	Key   string
-	State int
+	Key      string
+	State    int
+	Capacity Capacity
+	Window   Window
+	Reminder Reminder
+	Expiry   Expiry
 }
 
 func (e EntryPoint) IsZero() bool {
@@ -33,3 +37,17 @@ func MustNewEntryPoint(key string, state int) EntryPoint {
 	}
 	return e
 }
+
+func (e EntryPoint) WithCapacity(c Capacity) EntryPoint {
+	e.Capacity = c
+	return e
+}
+
+func (e EntryPoint) States() []int {
+	return append([]int{e.State}, e.Capacity.States()...)
+}
+
+func (e EntryPoint) WithWindow(w Window) EntryPoint {
+	e.Window = w
+	return e
+}
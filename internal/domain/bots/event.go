@@ -0,0 +1,146 @@
+package bots
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/bmstu-itstech/itsreg-bots/internal/common/commonerrs"
+)
+
+type EventType struct {
+	s string
+}
+
+var (
+	ParticipantStartedEvent = EventType{s: "participant.started"}
+	AnswerGivenEvent        = EventType{s: "answer.given"}
+	FlowFinishedEvent       = EventType{s: "flow.finished"}
+	MailingFinishedEvent    = EventType{s: "mailing.finished"}
+	BotFailedEvent          = EventType{s: "bot.failed"}
+)
+
+func (t EventType) String() string {
+	return t.s
+}
+
+func (t EventType) IsZero() bool {
+	return t == EventType{}
+}
+
+func NewEventTypeFromString(s string) (EventType, error) {
+	switch s {
+	case "participant.started":
+		return ParticipantStartedEvent, nil
+	case "answer.given":
+		return AnswerGivenEvent, nil
+	case "flow.finished":
+		return FlowFinishedEvent, nil
+	case "mailing.finished":
+		return MailingFinishedEvent, nil
+	case "bot.failed":
+		return BotFailedEvent, nil
+	}
+	return EventType{}, commonerrs.NewInvalidInputError(
+		fmt.Sprintf(
+			"invalid event type %s, expected one of "+
+				"['participant.started', 'answer.given', 'flow.finished', 'mailing.finished', 'bot.failed']",
+			s,
+		),
+	)
+}
+
+type Event struct {
+	ID      int64
+	Type    EventType
+	BotUUID string
+
+	UserID     int64
+	EntryKey   string
+	Answer     Answer
+	Recipients int
+	Reason     string
+
+	OccurredAt time.Time
+}
+
+func (e Event) IsParticipantEvent() bool {
+	return e.Type == ParticipantStartedEvent || e.Type == AnswerGivenEvent || e.Type == FlowFinishedEvent
+}
+
+func NewParticipantStartedEvent(botUUID string, userID int64, entryKey string) Event {
+	return Event{
+		Type:       ParticipantStartedEvent,
+		BotUUID:    botUUID,
+		UserID:     userID,
+		EntryKey:   entryKey,
+		OccurredAt: time.Now(),
+	}
+}
+
+func NewAnswerGivenEvent(botUUID string, userID int64, answer Answer) Event {
+	return Event{
+		Type:       AnswerGivenEvent,
+		BotUUID:    botUUID,
+		UserID:     userID,
+		Answer:     answer,
+		OccurredAt: time.Now(),
+	}
+}
+
+func NewFlowFinishedEvent(botUUID string, userID int64) Event {
+	return Event{
+		Type:       FlowFinishedEvent,
+		BotUUID:    botUUID,
+		UserID:     userID,
+		OccurredAt: time.Now(),
+	}
+}
+
+func NewMailingFinishedEvent(botUUID string, entryKey string, recipients int) Event {
+	return Event{
+		Type:       MailingFinishedEvent,
+		BotUUID:    botUUID,
+		EntryKey:   entryKey,
+		Recipients: recipients,
+		OccurredAt: time.Now(),
+	}
+}
+
+func NewBotFailedEvent(botUUID string, reason string) Event {
+	return Event{
+		Type:       BotFailedEvent,
+		BotUUID:    botUUID,
+		Reason:     reason,
+		OccurredAt: time.Now(),
+	}
+}
+
+func (b *Bot) EntryEvents(prt *Participant, key string) []Event {
+	if prt.IsPaused() || prt.IsBlocked() {
+		return []Event{}
+	}
+	events := []Event{NewParticipantStartedEvent(b.UUID, prt.UserID, key)}
+	if !prt.IsProcessing() {
+		events = append(events, NewFlowFinishedEvent(b.UUID, prt.UserID))
+	}
+	return events
+}
+
+func (b *Bot) ProcessEvents(prt *Participant, prevState int) []Event {
+	events := make([]Event, 0)
+	if prevState == 0 {
+		return events
+	}
+
+	if block, ok := b.blocks[prevState]; ok && block.Type != MessageBlock && prt.State != prevState {
+		if ans, ok := prt.Answer(prevState); ok {
+			events = append(events, NewAnswerGivenEvent(b.UUID, prt.UserID, ans))
+		}
+	}
+
+	if !prt.IsProcessing() {
+		events = append(events, NewFlowFinishedEvent(b.UUID, prt.UserID))
+	}
+
+	return events
+}
@@ -0,0 +1,11 @@
+package bots
+
+import "context"
+
+type EventPublisher interface {
+	Publish(ctx context.Context, event Event) error
+}
+
+type EventSubscriber interface {
+	Subscribe(ctx context.Context, botUUID string, lastEventID int64) (<-chan Event, error)
+}
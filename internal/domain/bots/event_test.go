@@ -0,0 +1,124 @@
+package bots_test
+
+import (
+	"math/rand/v2"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+
+	"github.com/bmstu-itstech/itsreg-bots/internal/domain/bots"
+)
+
+func TestBot_Events(t *testing.T) {
+	botUUID := uuid.NewString()
+	bot := bots.MustNewBot(
+		botUUID,
+		uuid.NewString(),
+		[]bots.EntryPoint{
+			bots.MustNewEntryPoint("start", 1),
+		},
+		nil,
+		[]bots.Block{
+			bots.MustNewMessageBlock(1, 2, "Greeting", "Hello, user!"),
+			bots.MustNewQuestionBlock(2, 0, "Name", "What's your name?"),
+		},
+		"Test bot",
+		"12345678:XXXXXXXXXXXXXXXXXXXXXXXXXXXXXXXXXXX",
+	)
+
+	t.Run("should emit participant started on entry", func(t *testing.T) {
+		prt := bots.MustNewParticipant(botUUID, rand.Int64())
+
+		_, err := bot.Entry(prt, "start")
+		require.NoError(t, err)
+
+		events := bot.EntryEvents(prt, "start")
+		require.Len(t, events, 1)
+		require.Equal(t, bots.ParticipantStartedEvent, events[0].Type)
+		require.Equal(t, "start", events[0].EntryKey)
+		require.Equal(t, prt.UserID, events[0].UserID)
+	})
+
+	t.Run("should emit answer given and flow finished on last answer", func(t *testing.T) {
+		prt := bots.MustNewParticipant(botUUID, rand.Int64())
+		prt.SwitchTo(2)
+
+		prevState := prt.State
+		_, err := bot.Process(prt, "Ivan")
+		require.NoError(t, err)
+
+		events := bot.ProcessEvents(prt, prevState)
+		require.Len(t, events, 2)
+		require.Equal(t, bots.AnswerGivenEvent, events[0].Type)
+		require.Equal(t, "Ivan", events[0].Answer.Text)
+		require.Equal(t, bots.FlowFinishedEvent, events[1].Type)
+	})
+
+	t.Run("should not emit events for idle participant", func(t *testing.T) {
+		prt := bots.MustNewParticipant(botUUID, rand.Int64())
+
+		events := bot.ProcessEvents(prt, prt.State)
+		require.Empty(t, events)
+	})
+}
+
+func TestNewWebhook(t *testing.T) {
+	t.Run("should create webhook", func(t *testing.T) {
+		webhook, err := bots.NewWebhook(
+			uuid.NewString(), uuid.NewString(),
+			"https://example.com/hook", "0123456789abcdef",
+			[]string{"answer.given", "flow.finished"},
+		)
+		require.NoError(t, err)
+		require.True(t, webhook.Subscribed(bots.AnswerGivenEvent))
+		require.False(t, webhook.Subscribed(bots.BotFailedEvent))
+	})
+
+	t.Run("should reject invalid url", func(t *testing.T) {
+		_, err := bots.NewWebhook(
+			uuid.NewString(), uuid.NewString(),
+			"ftp://example.com", "0123456789abcdef",
+			[]string{"answer.given"},
+		)
+		require.Error(t, err)
+	})
+
+	t.Run("should reject internal address", func(t *testing.T) {
+		for _, rawURL := range []string{
+			"http://localhost:8080/hook",
+			"http://127.0.0.1/hook",
+			"http://10.0.0.5/hook",
+			"http://192.168.1.1/hook",
+			"http://169.254.169.254/latest/meta-data",
+			"http://[::1]/hook",
+			"http://[fe80::1]/hook",
+			"http://0.0.0.0/hook",
+		} {
+			_, err := bots.NewWebhook(
+				uuid.NewString(), uuid.NewString(),
+				rawURL, "0123456789abcdef",
+				[]string{"answer.given"},
+			)
+			require.Error(t, err, rawURL)
+		}
+	})
+
+	t.Run("should reject short secret", func(t *testing.T) {
+		_, err := bots.NewWebhook(
+			uuid.NewString(), uuid.NewString(),
+			"https://example.com/hook", "short",
+			[]string{"answer.given"},
+		)
+		require.Error(t, err)
+	})
+
+	t.Run("should reject unknown event", func(t *testing.T) {
+		_, err := bots.NewWebhook(
+			uuid.NewString(), uuid.NewString(),
+			"https://example.com/hook", "0123456789abcdef",
+			[]string{"unknown"},
+		)
+		require.Error(t, err)
+	})
+}
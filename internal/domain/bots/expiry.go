@@ -0,0 +1,148 @@
+package bots
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/bmstu-itstech/itsreg-bots/internal/common/commonerrs"
+)
+
+const (
+	DefaultExpiryText = "Ваша сессия истекла. Отправьте /start, чтобы начать заново."
+	MinExpiryTTL      = time.Minute
+)
+
+type ExpiryMode struct {
+	s string
+}
+
+var (
+	NotifyExpiry  = ExpiryMode{s: "notify"}
+	RestartExpiry = ExpiryMode{s: "restart"}
+)
+
+func (m ExpiryMode) String() string {
+	return m.s
+}
+
+func (m ExpiryMode) IsZero() bool {
+	return m == ExpiryMode{}
+}
+
+func NewExpiryModeFromString(s string) (ExpiryMode, error) {
+	switch s {
+	case "", "notify":
+		return NotifyExpiry, nil
+	case "restart":
+		return RestartExpiry, nil
+	}
+	return ExpiryMode{}, commonerrs.NewInvalidInputError(
+		fmt.Sprintf("invalid expiry mode %s, expected one of ['notify', 'restart']", s),
+	)
+}
+
+type Expiry struct {
+	TTL  time.Duration
+	Mode ExpiryMode
+	Text string
+}
+
+func (e Expiry) IsZero() bool {
+	return e.TTL == 0
+}
+
+func NewExpiry(ttl time.Duration, mode string, text string) (Expiry, error) {
+	if ttl == 0 {
+		if mode != "" || text != "" {
+			return Expiry{}, commonerrs.NewInvalidInputError("expected expiry ttl")
+		}
+		return Expiry{}, nil
+	}
+
+	if ttl < MinExpiryTTL {
+		return Expiry{}, commonerrs.NewInvalidInputErrorf("expected expiry ttl of at least %s", MinExpiryTTL)
+	}
+
+	m, err := NewExpiryModeFromString(mode)
+	if err != nil {
+		return Expiry{}, err
+	}
+
+	if text == "" && m == NotifyExpiry {
+		text = DefaultExpiryText
+	}
+
+	return Expiry{
+		TTL:  ttl,
+		Mode: m,
+		Text: text,
+	}, nil
+}
+
+func MustNewExpiry(ttl time.Duration, mode string, text string) Expiry {
+	e, err := NewExpiry(ttl, mode, text)
+	if err != nil {
+		panic(err)
+	}
+	return e
+}
+
+func (e EntryPoint) WithExpiry(expiry Expiry) EntryPoint {
+	e.Expiry = expiry
+	return e
+}
+
+func (b *Bot) ExpiryTTL() time.Duration {
+	var ttl time.Duration
+	for _, e := range b.entryPoints {
+		if e.Expiry.IsZero() {
+			continue
+		}
+		if ttl == 0 || e.Expiry.TTL < ttl {
+			ttl = e.Expiry.TTL
+		}
+	}
+	return ttl
+}
+
+func (b *Bot) IsExpired(prt *Participant, now time.Time) bool {
+	if !prt.IsProcessing() || prt.IsBlocked() || prt.IsPaused() || prt.FlowStartedAt.IsZero() {
+		return false
+	}
+
+	entry, ok := b.entryPoints[prt.FlowEntry]
+	if !ok || entry.Expiry.IsZero() {
+		return false
+	}
+
+	return !now.Before(prt.FlowStartedAt.Add(entry.Expiry.TTL))
+}
+
+func (b *Bot) Expire(prt *Participant, now time.Time) ([]Message, error) {
+	if !b.IsExpired(prt, now) {
+		return make([]Message, 0), nil
+	}
+
+	entry := b.entryPoints[prt.FlowEntry]
+
+	messages := make([]Message, 0, 1)
+	if entry.Expiry.Text != "" {
+		messages = append(messages, MustNewPlainMessage(entry.Expiry.Text))
+	}
+
+	if entry.Expiry.Mode == RestartExpiry {
+		ms, err := b.resetAt(prt, entry.Key, now)
+		if err != nil {
+			return nil, err
+		}
+		return append(messages, ms...), nil
+	}
+
+	b.cleanAllAnswersFrom(entry.State, prt)
+	prt.SwitchTo(0)
+	prt.EntryKey = ""
+	prt.FlowEntry = ""
+	prt.FlowStartedAt = time.Time{}
+
+	return messages, nil
+}
@@ -0,0 +1,129 @@
+package bots_test
+
+import (
+	"math/rand/v2"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+
+	"github.com/bmstu-itstech/itsreg-bots/internal/common/commonerrs"
+	"github.com/bmstu-itstech/itsreg-bots/internal/domain/bots"
+)
+
+func TestNewExpiry(t *testing.T) {
+	t.Run("should set defaults", func(t *testing.T) {
+		e, err := bots.NewExpiry(time.Hour, "", "")
+		require.NoError(t, err)
+		require.Equal(t, bots.NotifyExpiry, e.Mode)
+		require.Equal(t, bots.DefaultExpiryText, e.Text)
+	})
+
+	t.Run("should not set default text for restart", func(t *testing.T) {
+		e, err := bots.NewExpiry(time.Hour, "restart", "")
+		require.NoError(t, err)
+		require.Empty(t, e.Text)
+	})
+
+	t.Run("should reject too short ttl", func(t *testing.T) {
+		_, err := bots.NewExpiry(time.Second, "", "")
+		require.ErrorAs(t, err, &commonerrs.InvalidInputError{})
+	})
+
+	t.Run("should reject mode without ttl", func(t *testing.T) {
+		_, err := bots.NewExpiry(0, "restart", "")
+		require.ErrorAs(t, err, &commonerrs.InvalidInputError{})
+	})
+
+	t.Run("should reject unknown mode", func(t *testing.T) {
+		_, err := bots.NewExpiry(time.Hour, "drop", "")
+		require.ErrorAs(t, err, &commonerrs.InvalidInputError{})
+	})
+}
+
+func TestBot_Expire(t *testing.T) {
+	entries := []bots.EntryPoint{
+		bots.MustNewEntryPoint("start", 1).WithExpiry(bots.MustNewExpiry(time.Hour, "notify", "Expired")),
+		bots.MustNewEntryPoint("again", 1).
+			WithExpiry(bots.MustNewExpiry(2*time.Hour, "restart", "")).
+			WithReminder(bots.MustNewReminder(time.Hour, 1, "Still there?")),
+		bots.MustNewEntryPoint("quiet", 1),
+	}
+	botUUID := uuid.NewString()
+	bot := bots.MustNewBot(
+		botUUID, uuid.NewString(), entries, nil, []bots.Block{
+			bots.MustNewQuestionBlock(1, 2, "Name", "What's your name?"),
+			bots.MustNewQuestionBlock(2, 3, "Age", "How old are you?"),
+			bots.MustNewMessageBlock(3, 0, "Finish", "Bye"),
+		},
+		"Test bot", "12345678:XXXXXXXXXXXXXXXXXXXXXXXXXXXXXXXXXXX",
+	)
+	require.Equal(t, time.Hour, bot.ExpiryTTL())
+
+	start := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+	enter := func(t *testing.T, key string) *bots.Participant {
+		prt := bots.MustNewParticipant(botUUID, rand.Int64())
+		_, err := bot.EntryAt(prt, key, start)
+		require.NoError(t, err)
+		_, err = bot.Process(prt, "John")
+		require.NoError(t, err)
+		return prt
+	}
+
+	t.Run("should expire after ttl", func(t *testing.T) {
+		prt := enter(t, "start")
+		require.False(t, bot.IsExpired(prt, start.Add(30*time.Minute)))
+		require.True(t, bot.IsExpired(prt, start.Add(time.Hour)))
+	})
+
+	t.Run("should reset progress in notify mode", func(t *testing.T) {
+		prt := enter(t, "start")
+
+		resp, err := bot.Expire(prt, start.Add(time.Hour))
+		require.NoError(t, err)
+		requireMessages(t, []bots.Message{bots.MustNewPlainMessage("Expired")}, resp)
+		require.Equal(t, 0, prt.State)
+		require.Empty(t, prt.FlowEntry)
+		require.True(t, prt.FlowStartedAt.IsZero())
+
+		_, ok := prt.Answer(1)
+		require.False(t, ok)
+		require.False(t, bot.IsExpired(prt, start.Add(24*time.Hour)))
+	})
+
+	t.Run("should restart flow in restart mode", func(t *testing.T) {
+		prt := enter(t, "again")
+		now := start.Add(2 * time.Hour)
+
+		resp, err := bot.Expire(prt, now)
+		require.NoError(t, err)
+		requireMessages(t, []bots.Message{bots.MustNewPlainMessage("What's your name?")}, resp)
+		require.Equal(t, 1, prt.State)
+		require.Equal(t, "again", prt.FlowEntry)
+		require.Equal(t, now, prt.FlowStartedAt)
+	})
+
+	t.Run("should not expire without expiry on entry", func(t *testing.T) {
+		prt := enter(t, "quiet")
+		require.False(t, bot.IsExpired(prt, start.Add(24*time.Hour)))
+
+		resp, err := bot.Expire(prt, start.Add(24*time.Hour))
+		require.NoError(t, err)
+		require.Empty(t, resp)
+	})
+
+	t.Run("should not remind expired participant", func(t *testing.T) {
+		prt := enter(t, "again")
+		prt.Touch(start)
+
+		resp, err := bot.Remind(prt, start.Add(time.Hour))
+		require.NoError(t, err)
+		require.Len(t, resp, 2)
+
+		prt.Touch(start)
+		resp, err = bot.Remind(prt, start.Add(3*time.Hour))
+		require.NoError(t, err)
+		require.Empty(t, resp)
+	})
+}
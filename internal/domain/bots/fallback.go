@@ -0,0 +1,96 @@
+package bots
+
+import (
+	"fmt"
+
+	"github.com/bmstu-itstech/itsreg-bots/internal/common/commonerrs"
+)
+
+const DefaultFallbackText = "Пожалуйста, выберите один из предложенных вариантов."
+
+type FallbackMode struct {
+	s string
+}
+
+var (
+	OtherFallback  = FallbackMode{s: "other"}
+	RejectFallback = FallbackMode{s: "reject"}
+	StateFallback  = FallbackMode{s: "state"}
+)
+
+func (f FallbackMode) String() string {
+	return f.s
+}
+
+func (f FallbackMode) IsZero() bool {
+	return f == FallbackMode{}
+}
+
+func NewFallbackModeFromString(s string) (FallbackMode, error) {
+	switch s {
+	case "", "other":
+		return OtherFallback, nil
+	case "reject":
+		return RejectFallback, nil
+	case "state":
+		return StateFallback, nil
+	}
+	return FallbackMode{}, commonerrs.NewInvalidInputError(
+		fmt.Sprintf("invalid fallback mode %s, expected one of ['other', 'reject', 'state']", s),
+	)
+}
+
+func (b Block) WithFallback(mode string, state int, text string) (Block, error) {
+	f, err := NewFallbackModeFromString(mode)
+	if err != nil {
+		return Block{}, err
+	}
+
+	if b.Type != SelectionBlock && b.Type != LanguageBlock {
+		return Block{}, commonerrs.NewInvalidInputErrorf("expected block %d with fallback to be selection block", b.State)
+	}
+
+	if f != OtherFallback && b.NextState != 0 {
+		return Block{}, commonerrs.NewInvalidInputErrorf(
+			"next state %d of block %d is unreachable with %s fallback", b.NextState, b.State, f,
+		)
+	}
+
+	switch f {
+	case RejectFallback:
+		if text == "" {
+			text = DefaultFallbackText
+		}
+		state = 0
+	case StateFallback:
+		if state == 0 {
+			return Block{}, commonerrs.NewInvalidInputErrorf("expected fallback state of block %d", b.State)
+		}
+		text = ""
+	default:
+		state = 0
+		text = ""
+	}
+
+	b.Fallback = f
+	b.FallbackState = state
+	b.FallbackText = text
+	return b, nil
+}
+
+func (b Block) Rejects(text string) bool {
+	if b.Fallback != RejectFallback {
+		return false
+	}
+	_, ok := b.Option(text)
+	return !ok
+}
+
+func (b *Bot) rejectFallback(prt *Participant, current Block) ([]Message, error) {
+	msg, err := b.blockMessage(prt, current)
+	if err != nil {
+		return nil, err
+	}
+
+	return []Message{MustNewPlainMessage(current.FallbackText), msg}, nil
+}
@@ -0,0 +1,137 @@
+package bots_test
+
+import (
+	"math/rand/v2"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+
+	"github.com/bmstu-itstech/itsreg-bots/internal/common/commonerrs"
+	"github.com/bmstu-itstech/itsreg-bots/internal/domain/bots"
+)
+
+func TestBlock_WithFallback(t *testing.T) {
+	options := []bots.Option{bots.MustNewOption("A", 2)}
+
+	t.Run("should reject fallback for question block", func(t *testing.T) {
+		_, err := bots.MustNewQuestionBlock(1, 2, "Name", "What's your name?").WithFallback("reject", 0, "")
+		require.ErrorAs(t, err, &commonerrs.InvalidInputError{})
+	})
+
+	t.Run("should reject unreachable next state", func(t *testing.T) {
+		_, err := bots.MustNewSelectionBlock(1, 2, options, "Choice", "Choose").WithFallback("reject", 0, "")
+		require.ErrorAs(t, err, &commonerrs.InvalidInputError{})
+	})
+
+	t.Run("should require fallback state", func(t *testing.T) {
+		_, err := bots.MustNewSelectionBlock(1, 0, options, "Choice", "Choose").WithFallback("state", 0, "")
+		require.ErrorAs(t, err, &commonerrs.InvalidInputError{})
+	})
+
+	t.Run("should use default fallback text", func(t *testing.T) {
+		block, err := bots.MustNewSelectionBlock(1, 0, options, "Choice", "Choose").WithFallback("reject", 0, "")
+		require.NoError(t, err)
+		require.Equal(t, bots.DefaultFallbackText, block.FallbackText)
+	})
+}
+
+func TestNewBot_Fallback(t *testing.T) {
+	entries := []bots.EntryPoint{
+		bots.MustNewEntryPoint("start", 1),
+	}
+	token := "12345678:XXXXXXXXXXXXXXXXXXXXXXXXXXXXXXXXXXX"
+	options := []bots.Option{bots.MustNewOption("A", 2)}
+
+	t.Run("should reject non-existent fallback state", func(t *testing.T) {
+		block, err := bots.MustNewSelectionBlock(1, 0, options, "Choice", "Choose").WithFallback("state", 3, "")
+		require.NoError(t, err)
+
+		_, err = bots.NewBot(uuid.NewString(), uuid.NewString(), entries, nil, []bots.Block{
+			block,
+			bots.MustNewMessageBlock(2, 0, "Finish", "Bye"),
+		}, "Test bot", token)
+		require.ErrorAs(t, err, &commonerrs.InvalidInputError{})
+	})
+
+	t.Run("should treat fallback state as reachable", func(t *testing.T) {
+		block, err := bots.MustNewSelectionBlock(1, 0, options, "Choice", "Choose").WithFallback("state", 3, "")
+		require.NoError(t, err)
+
+		_, err = bots.NewBot(uuid.NewString(), uuid.NewString(), entries, nil, []bots.Block{
+			block,
+			bots.MustNewMessageBlock(2, 0, "Finish", "Bye"),
+			bots.MustNewMessageBlock(3, 0, "Other", "Other"),
+		}, "Test bot", token)
+		require.NoError(t, err)
+	})
+}
+
+func TestBot_ProcessFallback(t *testing.T) {
+	entries := []bots.EntryPoint{
+		bots.MustNewEntryPoint("start", 1),
+	}
+	options := []bots.Option{bots.MustNewOption("A", 2)}
+	newBot := func(block bots.Block) *bots.Bot {
+		return bots.MustNewBot(
+			uuid.NewString(), uuid.NewString(), entries, nil, []bots.Block{
+				block,
+				bots.MustNewMessageBlock(2, 0, "Finish", "Bye"),
+				bots.MustNewMessageBlock(3, 0, "Other", "Tell us more later"),
+			}, "Test bot", "12345678:XXXXXXXXXXXXXXXXXXXXXXXXXXXXXXXXXXX",
+		)
+	}
+
+	t.Run("should reject and re-ask", func(t *testing.T) {
+		block, err := bots.MustNewSelectionBlock(1, 0, options, "Choice", "Choose").WithFallback("reject", 0, "Use buttons")
+		require.NoError(t, err)
+		bot := bots.MustNewBot(
+			uuid.NewString(), uuid.NewString(), entries, nil, []bots.Block{
+				block,
+				bots.MustNewMessageBlock(2, 0, "Finish", "Bye"),
+			}, "Test bot", "12345678:XXXXXXXXXXXXXXXXXXXXXXXXXXXXXXXXXXX",
+		)
+		prt := bots.MustNewParticipant(bot.UUID, rand.Int64())
+		prt.SwitchTo(1)
+
+		resp, err := bot.Process(prt, "B")
+		require.NoError(t, err)
+		requireMessages(t, []bots.Message{
+			bots.MustNewPlainMessage("Use buttons"),
+			bots.MustNewMessageWithButtons("Choose", options),
+		}, resp)
+		require.Equal(t, 1, prt.State)
+
+		_, ok := prt.Answer(1)
+		require.False(t, ok)
+		require.Empty(t, bot.ProcessEvents(prt, 1))
+	})
+
+	t.Run("should route to fallback state", func(t *testing.T) {
+		block, err := bots.MustNewSelectionBlock(1, 0, options, "Choice", "Choose").WithFallback("state", 3, "")
+		require.NoError(t, err)
+		bot := newBot(block)
+		prt := bots.MustNewParticipant(bot.UUID, rand.Int64())
+		prt.SwitchTo(1)
+
+		resp, err := bot.Process(prt, "B")
+		require.NoError(t, err)
+		requireMessages(t, []bots.Message{bots.MustNewPlainMessage("Tell us more later")}, resp)
+
+		answer, ok := prt.Answer(1)
+		require.True(t, ok)
+		require.Equal(t, "B", answer.Text)
+	})
+
+	t.Run("should accept other answer", func(t *testing.T) {
+		block, err := bots.MustNewSelectionBlock(1, 3, options, "Choice", "Choose").WithFallback("other", 0, "")
+		require.NoError(t, err)
+		bot := newBot(block)
+		prt := bots.MustNewParticipant(bot.UUID, rand.Int64())
+		prt.SwitchTo(1)
+
+		resp, err := bot.Process(prt, "B")
+		require.NoError(t, err)
+		requireMessages(t, []bots.Message{bots.MustNewPlainMessage("Tell us more later")}, resp)
+	})
+}
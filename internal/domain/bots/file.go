@@ -0,0 +1,74 @@
+package bots
+
+import (
+	"fmt"
+
+	"github.com/bmstu-itstech/itsreg-bots/internal/common/commonerrs"
+)
+
+type File struct {
+	ID       string
+	BotUUID  string
+	Name     string
+	MimeType string
+	Size     int64
+}
+
+func NewFile(
+	id string,
+	botUUID string,
+	name string,
+	mimeType string,
+	size int64,
+) (File, error) {
+	if id == "" {
+		return File{}, commonerrs.NewInvalidInputError("expected not empty file id")
+	}
+
+	if botUUID == "" {
+		return File{}, commonerrs.NewInvalidInputError("expected not empty botUUID")
+	}
+
+	if name == "" {
+		return File{}, commonerrs.NewInvalidInputError("expected not empty file name")
+	}
+
+	if size < 0 {
+		return File{}, commonerrs.NewInvalidInputError("expected non-negative file size")
+	}
+
+	if mimeType == "" {
+		mimeType = "application/octet-stream"
+	}
+
+	return File{
+		ID:       id,
+		BotUUID:  botUUID,
+		Name:     name,
+		MimeType: mimeType,
+		Size:     size,
+	}, nil
+}
+
+func MustNewFile(
+	id string,
+	botUUID string,
+	name string,
+	mimeType string,
+	size int64,
+) File {
+	f, err := NewFile(id, botUUID, name, mimeType, size)
+	if err != nil {
+		panic(err)
+	}
+	return f
+}
+
+type FileNotFoundError struct {
+	BotUUID string
+	ID      string
+}
+
+func (e FileNotFoundError) Error() string {
+	return fmt.Sprintf("file '%s' of bot '%s' not found", e.ID, e.BotUUID)
+}
@@ -0,0 +1,12 @@
+package bots
+
+import (
+	"context"
+	"io"
+)
+
+type FileStorage interface {
+	Save(ctx context.Context, file File, content io.Reader) (File, error)
+	Open(ctx context.Context, botUUID string, id string) (File, io.ReadCloser, error)
+	Delete(ctx context.Context, botUUID string, id string) error
+}
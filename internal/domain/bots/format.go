@@ -0,0 +1,295 @@
+package bots
+
+import (
+	"fmt"
+	"html"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/bmstu-itstech/itsreg-bots/internal/common/commonerrs"
+)
+
+type FormatMode struct {
+	s string
+}
+
+var (
+	PlainFormat      = FormatMode{s: "plain"}
+	MarkdownV2Format = FormatMode{s: "markdown_v2"}
+	HTMLFormat       = FormatMode{s: "html"}
+)
+
+func (f FormatMode) String() string {
+	return f.s
+}
+
+func (f FormatMode) IsZero() bool {
+	return f == FormatMode{}
+}
+
+func NewFormatModeFromString(s string) (FormatMode, error) {
+	switch s {
+	case "", "plain":
+		return PlainFormat, nil
+	case "markdown_v2":
+		return MarkdownV2Format, nil
+	case "html":
+		return HTMLFormat, nil
+	}
+	return FormatMode{}, commonerrs.NewInvalidInputError(
+		fmt.Sprintf("invalid format mode %s, expected one of ['plain', 'markdown_v2', 'html']", s),
+	)
+}
+
+const markdownV2Special = "_*[]()~`>#+-=|{}.!\\"
+
+func (f FormatMode) Escape(s string) string {
+	switch f {
+	case MarkdownV2Format:
+		var sb strings.Builder
+		for _, r := range s {
+			if strings.ContainsRune(markdownV2Special, r) {
+				sb.WriteRune('\\')
+			}
+			sb.WriteRune(r)
+		}
+		return sb.String()
+	case HTMLFormat:
+		return html.EscapeString(s)
+	}
+	return s
+}
+
+func (f FormatMode) Validate(text string) error {
+	text = placeholderRe.ReplaceAllString(text, "")
+	switch f {
+	case MarkdownV2Format:
+		return validateMarkdownV2(text)
+	case HTMLFormat:
+		return validateHTML(text)
+	}
+	return nil
+}
+
+var placeholderRe = regexp.MustCompile(`\{\{\s*(\d+)\s*\}\}`)
+
+func placeholderStates(text string) []int {
+	states := make([]int, 0)
+	for _, m := range placeholderRe.FindAllStringSubmatch(text, -1) {
+		state, err := strconv.Atoi(m[1])
+		if err == nil {
+			states = append(states, state)
+		}
+	}
+	return states
+}
+
+func (f FormatMode) Interpolate(text string, prt *Participant) string {
+	return placeholderRe.ReplaceAllStringFunc(text, func(m string) string {
+		state, err := strconv.Atoi(placeholderRe.FindStringSubmatch(m)[1])
+		if err != nil {
+			return ""
+		}
+		ans, ok := prt.Answer(state)
+		if !ok {
+			return ""
+		}
+		return f.Escape(ans.Text)
+	})
+}
+
+func validateMarkdownV2(text string) error {
+	invalid := func(format string, args ...any) error {
+		return fmt.Errorf("invalid markdown_v2 markup: "+format, args...)
+	}
+
+	rs := []rune(text)
+	hasPrefix := func(i int, p string) bool {
+		return strings.HasPrefix(string(rs[i:min(len(rs), i+len(p))]), p)
+	}
+
+	stack := make([]string, 0)
+	top := func() string {
+		if len(stack) == 0 {
+			return ""
+		}
+		return stack[len(stack)-1]
+	}
+	toggle := func(d string) error {
+		if top() == d {
+			stack = stack[:len(stack)-1]
+			return nil
+		}
+		if slices.Contains(stack, d) {
+			return invalid("entity %q is not properly nested", d)
+		}
+		stack = append(stack, d)
+		return nil
+	}
+
+	for i := 0; i < len(rs); i++ {
+		c := rs[i]
+
+		if c == '\\' {
+			if i+1 >= len(rs) {
+				return invalid("trailing backslash")
+			}
+			i++
+			continue
+		}
+
+		switch top() {
+		case "```":
+			if hasPrefix(i, "```") {
+				stack = stack[:len(stack)-1]
+				i += 2
+			}
+			continue
+		case "`":
+			if c == '`' {
+				stack = stack[:len(stack)-1]
+			}
+			continue
+		}
+
+		switch {
+		case hasPrefix(i, "```"):
+			stack = append(stack, "```")
+			i += 2
+		case c == '`':
+			stack = append(stack, "`")
+		case hasPrefix(i, "||"), hasPrefix(i, "__"):
+			if err := toggle(string(rs[i : i+2])); err != nil {
+				return err
+			}
+			i++
+		case c == '*' || c == '_' || c == '~':
+			if err := toggle(string(c)); err != nil {
+				return err
+			}
+		case c == '[':
+			stack = append(stack, "[")
+		case c == ']':
+			if top() != "[" {
+				return invalid("unexpected ']' at position %d", i)
+			}
+			stack = stack[:len(stack)-1]
+			if i+1 >= len(rs) || rs[i+1] != '(' {
+				return invalid("expected link URL after ']' at position %d", i)
+			}
+			j := i + 2
+			for ; j < len(rs) && rs[j] != ')'; j++ {
+				if rs[j] == '\\' {
+					j++
+				}
+			}
+			if j >= len(rs) {
+				return invalid("unclosed link URL at position %d", i+1)
+			}
+			i = j
+		case c == '>' && (i == 0 || rs[i-1] == '\n'):
+		case strings.ContainsRune(markdownV2Special, c):
+			return invalid("character %q at position %d must be escaped", c, i)
+		}
+	}
+
+	if len(stack) > 0 {
+		return invalid("unclosed entity %q", top())
+	}
+
+	return nil
+}
+
+var htmlTags = map[string]bool{
+	"b": true, "strong": true, "i": true, "em": true, "u": true, "ins": true,
+	"s": true, "strike": true, "del": true, "span": true, "tg-spoiler": true,
+	"a": true, "code": true, "pre": true, "blockquote": true, "tg-emoji": true,
+}
+
+var htmlEntityRe = regexp.MustCompile(`^&(lt|gt|amp|quot|#[0-9]+|#x[0-9a-fA-F]+);`)
+
+func validateHTML(text string) error {
+	invalid := func(format string, args ...any) error {
+		return fmt.Errorf("invalid html markup: "+format, args...)
+	}
+
+	stack := make([]string, 0)
+	for i := 0; i < len(text); {
+		switch text[i] {
+		case '<':
+			j := strings.IndexByte(text[i:], '>')
+			if j < 0 {
+				return invalid("unclosed tag at position %d", i)
+			}
+			tag := text[i+1 : i+j]
+			if strings.HasPrefix(tag, "/") {
+				name := strings.ToLower(strings.TrimSpace(tag[1:]))
+				if len(stack) == 0 || stack[len(stack)-1] != name {
+					return invalid("unexpected closing tag </%s>", name)
+				}
+				stack = stack[:len(stack)-1]
+			} else {
+				fields := strings.Fields(tag)
+				if len(fields) == 0 {
+					return invalid("empty tag at position %d", i)
+				}
+				name := strings.ToLower(fields[0])
+				if !htmlTags[name] {
+					return invalid("unsupported tag <%s>", name)
+				}
+				stack = append(stack, name)
+			}
+			i += j + 1
+		case '>':
+			return invalid("character '>' at position %d must be escaped as &gt;", i)
+		case '&':
+			m := htmlEntityRe.FindString(text[i:])
+			if m == "" {
+				return invalid("character '&' at position %d must be escaped as &amp;", i)
+			}
+			i += len(m)
+		default:
+			i++
+		}
+	}
+
+	if len(stack) > 0 {
+		return invalid("unclosed tag <%s>", stack[len(stack)-1])
+	}
+
+	return nil
+}
+
+func (b *Bot) blockMessage(prt *Participant, block Block) (Message, error) {
+	block = block.Localize(prt.Locale)
+	block.Text = block.Format.Interpolate(block.Text, prt)
+	if block.Type == TicketBlock {
+		return b.ticketMessage(prt, block)
+	}
+	return block.Message()
+}
+
+func validateBlockFormat(block Block, bs map[int]Block) error {
+	texts := []string{block.Text}
+	for _, t := range block.Translations {
+		texts = append(texts, t.Text)
+	}
+
+	for _, text := range texts {
+		if err := block.Format.Validate(text); err != nil {
+			return commonerrs.NewInvalidInputErrorf("block %d: %s", block.State, err.Error())
+		}
+
+		for _, state := range placeholderStates(text) {
+			if _, ok := bs[state]; !ok {
+				return commonerrs.NewInvalidInputErrorf(
+					"block %d refers to answer of non-existent block %d", block.State, state,
+				)
+			}
+		}
+	}
+
+	return nil
+}
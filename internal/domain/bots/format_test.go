@@ -0,0 +1,109 @@
+package bots_test
+
+import (
+	"math/rand/v2"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+
+	"github.com/bmstu-itstech/itsreg-bots/internal/common/commonerrs"
+	"github.com/bmstu-itstech/itsreg-bots/internal/domain/bots"
+)
+
+func TestFormatMode_Validate(t *testing.T) {
+	tests := []struct {
+		name   string
+		format bots.FormatMode
+		text   string
+		valid  bool
+	}{
+		{"plain accepts anything", bots.PlainFormat, "<b>*_[", true},
+		{"markdown bold and link", bots.MarkdownV2Format, "*Hi* [site](https://example.com/a_b) \\!", true},
+		{"markdown nested entities", bots.MarkdownV2Format, "*bold _italic_ bold*", true},
+		{"markdown code may contain specials", bots.MarkdownV2Format, "`a.b-c!`", true},
+		{"markdown list with escaped dash", bots.MarkdownV2Format, "\\- one\n\\- two", true},
+		{"markdown unescaped dot", bots.MarkdownV2Format, "Hello.", false},
+		{"markdown unclosed bold", bots.MarkdownV2Format, "*Hello", false},
+		{"markdown improper nesting", bots.MarkdownV2Format, "*a _b* c_", false},
+		{"markdown link without url", bots.MarkdownV2Format, "[site]", false},
+		{"markdown placeholder is ignored", bots.MarkdownV2Format, "Hi, *{{1}}*", true},
+		{"html tags and entities", bots.HTMLFormat, "<b>Hi</b> &amp; <a href=\"https://example.com\">site</a>", true},
+		{"html unsupported tag", bots.HTMLFormat, "<div>Hi</div>", false},
+		{"html unclosed tag", bots.HTMLFormat, "<b>Hi", false},
+		{"html mismatched tag", bots.HTMLFormat, "<b><i>Hi</b></i>", false},
+		{"html bare ampersand", bots.HTMLFormat, "Tom & Jerry", false},
+		{"html bare greater than", bots.HTMLFormat, "a > b", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.format.Validate(tt.text)
+			if tt.valid {
+				require.NoError(t, err)
+			} else {
+				require.Error(t, err)
+			}
+		})
+	}
+}
+
+func TestFormatMode_Escape(t *testing.T) {
+	require.Equal(t, "a\\_b\\*c\\.", bots.MarkdownV2Format.Escape("a_b*c."))
+	require.Equal(t, "&lt;b&gt;Tom &amp; Jerry&lt;/b&gt;", bots.HTMLFormat.Escape("<b>Tom & Jerry</b>"))
+	require.Equal(t, "<b>", bots.PlainFormat.Escape("<b>"))
+}
+
+func TestNewBot_Format(t *testing.T) {
+	entries := []bots.EntryPoint{
+		bots.MustNewEntryPoint("start", 1),
+	}
+	token := "12345678:XXXXXXXXXXXXXXXXXXXXXXXXXXXXXXXXXXX"
+
+	t.Run("should reject invalid markup", func(t *testing.T) {
+		block, err := bots.MustNewMessageBlock(1, 0, "Greeting", "<b>Hello").WithFormat("html")
+		require.NoError(t, err)
+
+		_, err = bots.NewBot(uuid.NewString(), uuid.NewString(), entries, nil, []bots.Block{block}, "Test bot", token)
+		require.ErrorAs(t, err, &commonerrs.InvalidInputError{})
+	})
+
+	t.Run("should reject placeholder of non-existent block", func(t *testing.T) {
+		block := bots.MustNewMessageBlock(1, 0, "Greeting", "Hello, {{5}}")
+
+		_, err := bots.NewBot(uuid.NewString(), uuid.NewString(), entries, nil, []bots.Block{block}, "Test bot", token)
+		require.ErrorAs(t, err, &commonerrs.InvalidInputError{})
+	})
+
+	t.Run("should reject unknown format", func(t *testing.T) {
+		_, err := bots.MustNewMessageBlock(1, 0, "Greeting", "Hello").WithFormat("markdown")
+		require.ErrorAs(t, err, &commonerrs.InvalidInputError{})
+	})
+}
+
+func TestBot_ProcessFormat(t *testing.T) {
+	nameBlock := bots.MustNewQuestionBlock(1, 2, "Name", "What's your name?")
+	greetingBlock, err := bots.MustNewMessageBlock(2, 0, "Greeting", "<b>Hello, {{1}}!</b>").WithFormat("html")
+	require.NoError(t, err)
+
+	entries := []bots.EntryPoint{
+		bots.MustNewEntryPoint("start", 1),
+	}
+	botUUID := uuid.NewString()
+	bot := bots.MustNewBot(
+		botUUID, uuid.NewString(), entries, nil, []bots.Block{nameBlock, greetingBlock},
+		"Test bot", "12345678:XXXXXXXXXXXXXXXXXXXXXXXXXXXXXXXXXXX",
+	)
+
+	t.Run("should interpolate escaped answer", func(t *testing.T) {
+		prt := bots.MustNewParticipant(botUUID, rand.Int64())
+		prt.SwitchTo(1)
+
+		resp, err := bot.Process(prt, "<i>Ivan</i> & co")
+		require.NoError(t, err)
+
+		expected := bots.MustNewPlainMessage("<b>Hello, &lt;i&gt;Ivan&lt;/i&gt; &amp; co!</b>")
+		expected.Format = bots.HTMLFormat
+		requireMessages(t, []bots.Message{expected}, resp)
+	})
+}
@@ -0,0 +1,42 @@
+package bots
+
+import (
+	"errors"
+	"time"
+
+	"github.com/bmstu-itstech/itsreg-bots/internal/common/commonerrs"
+)
+
+const LeaseTTL = 30 * time.Second
+
+var ErrLeaseHeld = errors.New("bot is owned by another instance")
+
+type Lease struct {
+	BotUUID    string
+	InstanceID string
+	ExpiresAt  time.Time
+}
+
+func NewLease(botUUID string, instanceID string, expiresAt time.Time) (Lease, error) {
+	if botUUID == "" {
+		return Lease{}, commonerrs.NewInvalidInputError("expected not empty bot uuid")
+	}
+
+	if instanceID == "" {
+		return Lease{}, commonerrs.NewInvalidInputError("expected not empty instance id")
+	}
+
+	if expiresAt.IsZero() {
+		return Lease{}, commonerrs.NewInvalidInputError("expected not empty expires at timestamp")
+	}
+
+	return Lease{
+		BotUUID:    botUUID,
+		InstanceID: instanceID,
+		ExpiresAt:  expiresAt,
+	}, nil
+}
+
+func (l Lease) IsExpired(now time.Time) bool {
+	return !now.Before(l.ExpiresAt)
+}
@@ -0,0 +1,14 @@
+package bots
+
+import (
+	"context"
+	"time"
+)
+
+type LeaseRepository interface {
+	Acquire(ctx context.Context, lease Lease) error
+	Renew(ctx context.Context, instanceID string, expiresAt time.Time) error
+	Release(ctx context.Context, botUUID string, instanceID string) error
+	Leases(ctx context.Context) ([]Lease, error)
+	Owner(ctx context.Context, botUUID string) (string, error)
+}
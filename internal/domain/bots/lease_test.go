@@ -0,0 +1,29 @@
+package bots_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/bmstu-itstech/itsreg-bots/internal/common/commonerrs"
+	"github.com/bmstu-itstech/itsreg-bots/internal/domain/bots"
+)
+
+func TestNewLease(t *testing.T) {
+	now := time.Now()
+
+	lease, err := bots.NewLease("1234", "instance-1", now.Add(bots.LeaseTTL))
+	require.NoError(t, err)
+	require.False(t, lease.IsExpired(now))
+	require.True(t, lease.IsExpired(now.Add(bots.LeaseTTL)))
+
+	_, err = bots.NewLease("", "instance-1", now)
+	require.ErrorAs(t, err, &commonerrs.InvalidInputError{})
+
+	_, err = bots.NewLease("1234", "", now)
+	require.ErrorAs(t, err, &commonerrs.InvalidInputError{})
+
+	_, err = bots.NewLease("1234", "instance-1", time.Time{})
+	require.ErrorAs(t, err, &commonerrs.InvalidInputError{})
+}
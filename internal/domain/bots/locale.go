@@ -0,0 +1,125 @@
+package bots
+
+import (
+	"errors"
+	"maps"
+	"regexp"
+	"strings"
+
+	"github.com/bmstu-itstech/itsreg-bots/internal/common/commonerrs"
+)
+
+var localeRe = regexp.MustCompile(`^[a-z]{2,3}(-[a-z0-9]{2,8})*$`)
+
+func NormalizeLocale(s string) string {
+	s = strings.ToLower(strings.ReplaceAll(strings.TrimSpace(s), "_", "-"))
+	if !localeRe.MatchString(s) {
+		return ""
+	}
+	return s
+}
+
+func NewLocale(s string) (string, error) {
+	l := NormalizeLocale(s)
+	if l == "" {
+		return "", commonerrs.NewInvalidInputErrorf("invalid locale %q, expected IETF language tag like 'en' or 'pt-br'", s)
+	}
+	return l, nil
+}
+
+func lookupLocale[T any](m map[string]T, locale string) (T, bool) {
+	var zero T
+	if locale == "" || len(m) == 0 {
+		return zero, false
+	}
+
+	if v, ok := m[locale]; ok {
+		return v, true
+	}
+
+	if base, _, ok := strings.Cut(locale, "-"); ok {
+		if v, ok := m[base]; ok {
+			return v, true
+		}
+	}
+
+	return zero, false
+}
+
+type Translation struct {
+	Title string
+	Text  string
+}
+
+func NewTranslation(title string, text string) (Translation, error) {
+	if text == "" {
+		return Translation{}, errors.New("missing translation text")
+	}
+
+	return Translation{
+		Title: title,
+		Text:  text,
+	}, nil
+}
+
+func MustNewTranslation(title string, text string) Translation {
+	t, err := NewTranslation(title, text)
+	if err != nil {
+		panic(err)
+	}
+	return t
+}
+
+func (b Block) WithTranslation(locale string, t Translation) (Block, error) {
+	l, err := NewLocale(locale)
+	if err != nil {
+		return Block{}, err
+	}
+
+	if t.Text == "" {
+		return Block{}, errors.New("missing translation text")
+	}
+
+	ts := maps.Clone(b.Translations)
+	if ts == nil {
+		ts = make(map[string]Translation)
+	}
+	ts[l] = t
+	b.Translations = ts
+
+	return b, nil
+}
+
+func (b Block) Localize(locale string) Block {
+	if t, ok := lookupLocale(b.Translations, locale); ok {
+		b.Text = t.Text
+		if t.Title != "" {
+			b.Title = t.Title
+		}
+	}
+
+	if len(b.Options) > 0 {
+		options := make([]Option, len(b.Options))
+		for i, opt := range b.Options {
+			options[i] = opt.Localize(locale)
+		}
+		b.Options = options
+	}
+
+	return b
+}
+
+func (p *Participant) DetectLocale(languageCode string) {
+	if p.Locale == "" {
+		p.Locale = NormalizeLocale(languageCode)
+	}
+}
+
+func (p *Participant) SetLocale(locale string) error {
+	l, err := NewLocale(locale)
+	if err != nil {
+		return err
+	}
+	p.Locale = l
+	return nil
+}
@@ -0,0 +1,113 @@
+package bots_test
+
+import (
+	"math/rand/v2"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+
+	"github.com/bmstu-itstech/itsreg-bots/internal/common/commonerrs"
+	"github.com/bmstu-itstech/itsreg-bots/internal/domain/bots"
+)
+
+func TestNormalizeLocale(t *testing.T) {
+	require.Equal(t, "en", bots.NormalizeLocale("en"))
+	require.Equal(t, "pt-br", bots.NormalizeLocale("pt_BR"))
+	require.Equal(t, "", bots.NormalizeLocale("english"))
+	require.Equal(t, "", bots.NormalizeLocale(""))
+
+	_, err := bots.NewLocale("x")
+	require.ErrorAs(t, err, &commonerrs.InvalidInputError{})
+}
+
+func TestNewLanguageBlock(t *testing.T) {
+	t.Run("should reject option without locale", func(t *testing.T) {
+		_, err := bots.NewLanguageBlock(1, 2, []bots.Option{bots.MustNewOption("English", 2)}, "Language", "Choose language")
+		require.ErrorAs(t, err, &commonerrs.InvalidInputError{})
+	})
+}
+
+func TestBot_ProcessLocale(t *testing.T) {
+	en, err := bots.MustNewOption("English", 2).WithLocale("en")
+	require.NoError(t, err)
+	ru, err := bots.MustNewOption("Русский", 2).WithLocale("ru")
+	require.NoError(t, err)
+	languageBlock := bots.MustNewLanguageBlock(1, 2, []bots.Option{en, ru}, "Language", "Выберите язык / Choose language")
+
+	yes, err := bots.MustNewOption("Да", 3).WithTranslation("en", "Yes")
+	require.NoError(t, err)
+	no, err := bots.MustNewOption("Нет", 3).WithTranslation("en", "No")
+	require.NoError(t, err)
+	agreeBlock, err := bots.MustNewSelectionBlock(2, 3, []bots.Option{yes, no}, "Согласие", "Вы согласны?").
+		WithTranslation("en", bots.MustNewTranslation("Agreement", "Do you agree?"))
+	require.NoError(t, err)
+
+	finishBlock, err := bots.MustNewMessageBlock(3, 0, "Финиш", "Спасибо!").
+		WithTranslation("en", bots.MustNewTranslation("", "Thank you!"))
+	require.NoError(t, err)
+
+	entries := []bots.EntryPoint{
+		bots.MustNewEntryPoint("start", 1),
+	}
+	botUUID := uuid.NewString()
+	bot := bots.MustNewBot(
+		botUUID, uuid.NewString(), entries, nil, []bots.Block{languageBlock, agreeBlock, finishBlock},
+		"Test bot", "12345678:XXXXXXXXXXXXXXXXXXXXXXXXXXXXXXXXXXX",
+	)
+
+	t.Run("should switch locale by language block", func(t *testing.T) {
+		prt := bots.MustNewParticipant(botUUID, rand.Int64())
+		prt.DetectLocale("ru")
+		prt.SwitchTo(1)
+
+		resp, err := bot.Process(prt, "English")
+		require.NoError(t, err)
+		require.Equal(t, "en", prt.Locale)
+
+		requireMessages(t, []bots.Message{
+			bots.MustNewMessageWithButtons("Do you agree?", []bots.Option{
+				bots.MustNewOption("Yes", 3),
+				bots.MustNewOption("No", 3),
+			}),
+		}, resp)
+	})
+
+	t.Run("should store canonical answer for translated option", func(t *testing.T) {
+		prt := bots.MustNewParticipant(botUUID, rand.Int64())
+		prt.DetectLocale("en-US")
+		prt.SwitchTo(2)
+
+		resp, err := bot.Process(prt, "Yes")
+		require.NoError(t, err)
+		requireMessages(t, []bots.Message{bots.MustNewPlainMessage("Thank you!")}, resp)
+
+		answer, ok := prt.Answer(2)
+		require.True(t, ok)
+		require.Equal(t, "Да", answer.Text)
+
+		table := bots.NewAnswersTable(bot, []*bots.Participant{prt})
+		require.Contains(t, table.Head, "Согласие")
+		require.Contains(t, table.Body[0], "Да")
+	})
+
+	t.Run("should fall back to original text without translation", func(t *testing.T) {
+		prt := bots.MustNewParticipant(botUUID, rand.Int64())
+		prt.DetectLocale("de")
+		prt.SwitchTo(2)
+
+		resp, err := bot.Process(prt, "Нет")
+		require.NoError(t, err)
+		requireMessages(t, []bots.Message{bots.MustNewPlainMessage("Спасибо!")}, resp)
+	})
+}
+
+func TestParticipant_DetectLocale(t *testing.T) {
+	prt := bots.MustNewParticipant(uuid.NewString(), rand.Int64())
+
+	prt.DetectLocale("en_US")
+	require.Equal(t, "en-us", prt.Locale)
+
+	prt.DetectLocale("ru")
+	require.Equal(t, "en-us", prt.Locale)
+}
@@ -0,0 +1,128 @@
+package bots
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/bmstu-itstech/itsreg-bots/internal/common/commonerrs"
+)
+
+type Member struct {
+	UserUUID string
+	Role     Role
+}
+
+func (m Member) IsZero() bool {
+	return m == Member{}
+}
+
+func NewMember(userUUID string, role string) (Member, error) {
+	if userUUID == "" {
+		return Member{}, commonerrs.NewInvalidInputError("expected not empty member user uuid")
+	}
+
+	r, err := NewRoleFromString(role)
+	if err != nil {
+		return Member{}, err
+	}
+
+	return Member{
+		UserUUID: userUUID,
+		Role:     r,
+	}, nil
+}
+
+func MustNewMember(userUUID string, role string) Member {
+	m, err := NewMember(userUUID, role)
+	if err != nil {
+		panic(err)
+	}
+	return m
+}
+
+type MemberNotFoundError struct {
+	UserUUID string
+}
+
+func (e MemberNotFoundError) Error() string {
+	return fmt.Sprintf("member '%s' not found", e.UserUUID)
+}
+
+var errCreatorRoleChange = commonerrs.NewInvalidInputError("the role of the bot creator cannot be changed")
+
+func (b *Bot) Members() []Member {
+	members := make([]Member, 0, len(b.members))
+	for _, m := range b.members {
+		members = append(members, m)
+	}
+	return members
+}
+
+func (b *Bot) SetMember(member Member) error {
+	if member.IsZero() {
+		return commonerrs.NewInvalidInputError("expected not empty member")
+	}
+
+	if member.UserUUID == b.OwnerUUID && member.Role != OwnerRole {
+		return errCreatorRoleChange
+	}
+
+	b.members[member.UserUUID] = member
+
+	return nil
+}
+
+func (b *Bot) RemoveMember(userUUID string) error {
+	if userUUID == b.OwnerUUID {
+		return errCreatorRoleChange
+	}
+
+	if _, ok := b.members[userUUID]; !ok {
+		return MemberNotFoundError{UserUUID: userUUID}
+	}
+
+	delete(b.members, userUUID)
+
+	return nil
+}
+
+var ErrPermissionDenied = errors.New("permission denied")
+
+func (b *Bot) CanSeeBot(userUUID string) error {
+	return b.requireRole(userUUID, ViewerRole)
+}
+
+func (b *Bot) CanOperateBot(userUUID string) error {
+	return b.requireRole(userUUID, OperatorRole)
+}
+
+func (b *Bot) CanEditBot(userUUID string) error {
+	return b.requireRole(userUUID, EditorRole)
+}
+
+func (b *Bot) CanManageBot(userUUID string) error {
+	return b.requireRole(userUUID, OwnerRole)
+}
+
+func (b *Bot) requireRole(userUUID string, role Role) error {
+	m, ok := b.members[userUUID]
+	if !ok || !m.Role.Includes(role) {
+		return ErrPermissionDenied
+	}
+	return nil
+}
+
+func mapMembers(ownerUUID string, members []Member) (map[string]Member, error) {
+	mapped := make(map[string]Member, len(members)+1)
+	for _, m := range members {
+		if m.IsZero() {
+			return nil, commonerrs.NewInvalidInputError("expected not empty member")
+		}
+		if _, ok := mapped[m.UserUUID]; ok {
+			return nil, commonerrs.NewInvalidInputErrorf("member '%s' is duplicated", m.UserUUID)
+		}
+		mapped[m.UserUUID] = m
+	}
+	mapped[ownerUUID] = Member{UserUUID: ownerUUID, Role: OwnerRole}
+	return mapped, nil
+}
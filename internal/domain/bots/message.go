@@ -9,6 +9,10 @@ import (
 type Message struct {
 	Text    string
 	Buttons []string
+	Ticket  string
+
+	Attachment Attachment
+	Format     FormatMode
 }
 
 func (m Message) IsZero() bool {
@@ -68,8 +72,38 @@ func MustNewMessageWithButtons(
 	return m
 }
 
+func NewTicketMessage(text string, ticket string) (Message, error) {
+	if text == "" {
+		return Message{}, commonerrs.NewInvalidInputError("expected not empty message text")
+	}
+
+	if ticket == "" {
+		return Message{}, commonerrs.NewInvalidInputError("expected not empty ticket code")
+	}
+
+	return Message{
+		Text:    text,
+		Buttons: make([]string, 0),
+		Ticket:  ticket,
+	}, nil
+}
+
+func MustNewTicketMessage(text string, ticket string) Message {
+	m, err := NewTicketMessage(text, ticket)
+	if err != nil {
+		panic(err)
+	}
+	return m
+}
+
+func (m Message) WithAttachment(a Attachment) Message {
+	m.Attachment = a
+	return m
+}
+
 func (m Message) Equal(o Message) bool {
-	return m.Text == o.Text && buttonsEqual(m.Buttons, o.Buttons)
+	return m.Text == o.Text && buttonsEqual(m.Buttons, o.Buttons) && m.Ticket == o.Ticket && m.Attachment == o.Attachment &&
+		m.Format == o.Format
 }
 
 func buttonsEqual(a, b []string) bool {
@@ -1,14 +1,26 @@
 package bots
 
-import "errors"
+import (
+	"errors"
+	"maps"
+	"slices"
+	"strings"
+)
 
 type Option struct {
-	Text string
-	Next int
+	Text  string
+	Value string
+	ID    string
+	Next  int
+
+	Aliases []string
+
+	Locale       string
+	Translations map[string]string
 }
 
 func (o Option) IsZero() bool {
-	return o == Option{}
+	return o.Text == ""
 }
 
 func NewOption(text string, next int) (Option, error) {
@@ -17,8 +29,9 @@ func NewOption(text string, next int) (Option, error) {
 	}
 
 	return Option{
-		Text: text,
-		Next: next,
+		Text:  text,
+		Value: text,
+		Next:  next,
 	}, nil
 }
 
@@ -30,6 +43,95 @@ func MustNewOption(text string, next int) Option {
 	return o
 }
 
+func (o Option) WithTranslation(locale string, text string) (Option, error) {
+	l, err := NewLocale(locale)
+	if err != nil {
+		return Option{}, err
+	}
+
+	if text == "" {
+		return Option{}, errors.New("missing translation text")
+	}
+
+	ts := maps.Clone(o.Translations)
+	if ts == nil {
+		ts = make(map[string]string)
+	}
+	ts[l] = text
+	o.Translations = ts
+
+	return o, nil
+}
+
+func (o Option) WithLocale(locale string) (Option, error) {
+	l, err := NewLocale(locale)
+	if err != nil {
+		return Option{}, err
+	}
+	o.Locale = l
+	return o, nil
+}
+
+func (o Option) WithValue(value string) (Option, error) {
+	value = strings.TrimSpace(value)
+	if value == "" {
+		return Option{}, errors.New("missing value")
+	}
+	o.Value = value
+	return o, nil
+}
+
+func (o Option) WithID(id string) (Option, error) {
+	id = strings.TrimSpace(id)
+	if id == "" {
+		return Option{}, errors.New("missing id")
+	}
+	o.ID = id
+	return o, nil
+}
+
+func (o Option) WithAliases(aliases ...string) (Option, error) {
+	res := slices.Clone(o.Aliases)
+	for _, alias := range aliases {
+		alias = strings.TrimSpace(alias)
+		if alias == "" {
+			return Option{}, errors.New("missing alias")
+		}
+		res = append(res, alias)
+	}
+	o.Aliases = res
+	return o, nil
+}
+
 func (o Option) Match(text string) bool {
-	return o.Text == text
+	text = normalizeOptionText(text)
+	if text == "" {
+		return false
+	}
+
+	if normalizeOptionText(o.Text) == text || normalizeOptionText(o.Value) == text {
+		return true
+	}
+	for _, alias := range o.Aliases {
+		if normalizeOptionText(alias) == text {
+			return true
+		}
+	}
+	for _, t := range o.Translations {
+		if normalizeOptionText(t) == text {
+			return true
+		}
+	}
+	return false
+}
+
+func normalizeOptionText(s string) string {
+	return strings.ToLower(strings.TrimSpace(s))
+}
+
+func (o Option) Localize(locale string) Option {
+	if t, ok := lookupLocale(o.Translations, locale); ok {
+		o.Text = t
+	}
+	return o
 }
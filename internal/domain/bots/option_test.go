@@ -0,0 +1,81 @@
+package bots_test
+
+import (
+	"math/rand/v2"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+
+	"github.com/bmstu-itstech/itsreg-bots/internal/common/commonerrs"
+	"github.com/bmstu-itstech/itsreg-bots/internal/domain/bots"
+)
+
+func TestOption_Match(t *testing.T) {
+	opt, err := bots.MustNewOption("Да, конечно", 2).WithValue("yes")
+	require.NoError(t, err)
+	opt, err = opt.WithAliases("да", "Yes")
+	require.NoError(t, err)
+
+	require.True(t, opt.Match("Да, конечно"))
+	require.True(t, opt.Match("  да, КОНЕЧНО "))
+	require.True(t, opt.Match("YES"))
+	require.True(t, opt.Match("Да"))
+	require.False(t, opt.Match("нет"))
+	require.False(t, opt.Match("  "))
+}
+
+func TestNewSelectionBlock_OptionIDs(t *testing.T) {
+	a, err := bots.MustNewOption("A", 2).WithID("opt")
+	require.NoError(t, err)
+	b, err := bots.MustNewOption("B", 2).WithID("opt")
+	require.NoError(t, err)
+
+	_, err = bots.NewSelectionBlock(1, 2, []bots.Option{a, b}, "Choice", "Choose")
+	require.ErrorAs(t, err, &commonerrs.InvalidInputError{})
+}
+
+func TestBot_ProcessOptionValue(t *testing.T) {
+	yes, err := bots.MustNewOption("Конечно!", 2).WithValue("yes")
+	require.NoError(t, err)
+	yes, err = yes.WithAliases("да")
+	require.NoError(t, err)
+	no, err := bots.MustNewOption("Нет", 2).WithValue("no")
+	require.NoError(t, err)
+
+	entries := []bots.EntryPoint{
+		bots.MustNewEntryPoint("start", 1),
+	}
+	botUUID := uuid.NewString()
+	bot := bots.MustNewBot(
+		botUUID, uuid.NewString(), entries, nil, []bots.Block{
+			bots.MustNewSelectionBlock(1, 2, []bots.Option{yes, no}, "Agreement", "Do you agree?"),
+			bots.MustNewMessageBlock(2, 0, "Finish", "Thank you!"),
+		},
+		"Test bot", "12345678:XXXXXXXXXXXXXXXXXXXXXXXXXXXXXXXXXXX",
+	)
+
+	t.Run("should store option value instead of label", func(t *testing.T) {
+		prt := bots.MustNewParticipant(botUUID, rand.Int64())
+		prt.SwitchTo(1)
+
+		_, err := bot.Process(prt, " ДА ")
+		require.NoError(t, err)
+
+		answer, ok := prt.Answer(1)
+		require.True(t, ok)
+		require.Equal(t, "yes", answer.Text)
+	})
+
+	t.Run("should store free text as is", func(t *testing.T) {
+		prt := bots.MustNewParticipant(botUUID, rand.Int64())
+		prt.SwitchTo(1)
+
+		_, err := bot.Process(prt, "Может быть")
+		require.NoError(t, err)
+
+		answer, ok := prt.Answer(1)
+		require.True(t, ok)
+		require.Equal(t, "Может быть", answer.Text)
+	})
+}
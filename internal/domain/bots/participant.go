@@ -1,6 +1,8 @@
 package bots
 
 import (
+	"time"
+
 	"github.com/bmstu-itstech/itsreg-bots/internal/common/commonerrs"
 )
 
@@ -8,6 +10,17 @@ type Participant struct {
 	BotUUID string
 	UserID  int64
 	State   int
+
+	EntryKey      string
+	FlowEntry     string
+	FlowStartedAt time.Time
+	Locale        string
+
+	LastActivityAt time.Time
+	RemindersSent  int
+
+	paused  bool
+	blocked bool
 	answers map[int]Answer
 }
 
@@ -46,6 +59,14 @@ func UnmarshallParticipantFromDB(
 	botUUID string,
 	id int64,
 	state int,
+	entryKey string,
+	flowEntry string,
+	flowStartedAt time.Time,
+	locale string,
+	lastActivityAt time.Time,
+	remindersSent int,
+	paused bool,
+	blocked bool,
 	answers []Answer,
 ) (*Participant, error) {
 	if botUUID == "" {
@@ -62,10 +83,19 @@ func UnmarshallParticipantFromDB(
 	}
 
 	return &Participant{
-		BotUUID: botUUID,
-		UserID:  id,
-		State:   state,
-		answers: m,
+		BotUUID:       botUUID,
+		UserID:        id,
+		State:         state,
+		EntryKey:      entryKey,
+		FlowEntry:     flowEntry,
+		FlowStartedAt: flowStartedAt,
+		Locale:        NormalizeLocale(locale),
+		paused:        paused,
+		blocked:       blocked,
+		answers:       m,
+
+		LastActivityAt: lastActivityAt,
+		RemindersSent:  remindersSent,
 	}, nil
 }
 
@@ -77,10 +107,48 @@ func (p *Participant) Answers() []Answer {
 	return answers
 }
 
+func (p *Participant) Answer(state int) (Answer, bool) {
+	a, ok := p.answers[state]
+	return a, ok
+}
+
 func (p *Participant) IsProcessing() bool {
 	return p.State != 0
 }
 
+func (p *Participant) IsPaused() bool {
+	return p.paused
+}
+
+func (p *Participant) Pause() {
+	p.paused = true
+}
+
+func (p *Participant) Resume() {
+	p.paused = false
+}
+
+func (p *Participant) IsBlocked() bool {
+	return p.blocked
+}
+
+func (p *Participant) Block() {
+	p.blocked = true
+}
+
+func (p *Participant) Unblock() {
+	p.blocked = false
+}
+
+func (p *Participant) AwaitsOperator() bool {
+	return p.paused || !p.IsProcessing()
+}
+
+func (p *Participant) Touch(t time.Time) {
+	p.LastActivityAt = t
+	p.RemindersSent = 0
+}
+
 func (p *Participant) SwitchTo(state int) {
 	p.State = state
 }
@@ -94,6 +162,15 @@ func (p *Participant) AddAnswer(text string) error {
 	return nil
 }
 
+func (p *Participant) AddUpload(upload Upload) error {
+	ans, err := NewUploadAnswer(p.State, upload)
+	if err != nil {
+		return err
+	}
+	p.answers[p.State] = ans
+	return nil
+}
+
 func (p *Participant) CleanAnswerIfExists(state int) {
 	if _, ok := p.answers[state]; ok {
 		delete(p.answers, state)
@@ -2,14 +2,30 @@ package bots
 
 import (
 	"context"
+	"fmt"
+	"time"
 )
 
+type ParticipantNotFoundError struct {
+	BotUUID string
+	UserID  int64
+}
+
+func (e ParticipantNotFoundError) Error() string {
+	return fmt.Sprintf("participant %d of bot '%s' not found", e.UserID, e.BotUUID)
+}
+
 type ParticipantRepository interface {
+	Participant(ctx context.Context, botUUID string, userID int64) (*Participant, error)
 	ParticipantsOfBot(ctx context.Context, botUUID string) ([]*Participant, error)
+	ParticipantsPage(ctx context.Context, botUUID string, offset int, limit int) ([]*Participant, int, error)
+	StalledParticipants(ctx context.Context, botUUID string, inactiveSince time.Time) ([]*Participant, error)
+	ExpiredParticipants(ctx context.Context, botUUID string, startedBefore time.Time) ([]*Participant, error)
 	UpdateOrCreate(
 		ctx context.Context,
 		botUUID string,
 		userID int64,
 		updateFn func(context.Context, *Participant) error,
 	) error
+	Delete(ctx context.Context, botUUID string, userID int64) error
 }
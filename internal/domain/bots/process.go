@@ -1,10 +1,12 @@
 package bots
 
 func (b Block) Process(text string) int {
-	for _, opt := range b.Options {
-		if opt.Match(text) {
-			return opt.Next
-		}
+	if opt, ok := b.Option(text); ok {
+		return opt.Next
+	}
+
+	if b.Fallback == StateFallback {
+		return b.FallbackState
 	}
 
 	return b.NextState
@@ -16,32 +18,95 @@ func (b *Bot) Process(
 ) ([]Message, error) {
 	messages := make([]Message, 0)
 
-	if !prt.IsProcessing() {
+	if prt.IsBlocked() || prt.AwaitsOperator() {
 		return messages, nil
 	}
 
 	current := b.blocks[prt.State]
 
-	if current.Type != MessageBlock {
-		err := prt.AddAnswer(text)
+	if current.Type == FileBlock {
+		return b.reask(prt, current)
+	}
+
+	if current.Rejects(text) {
+		return b.rejectFallback(prt, current)
+	}
+
+	if current.ExpectsAnswer() {
+		err := prt.AddAnswer(current.Canonical(text))
 		if err != nil {
 			return nil, err
 		}
 	}
 
-	nextState := current.Process(text)
+	if opt, ok := current.Option(text); ok && current.Type == LanguageBlock {
+		prt.Locale = opt.Locale
+	}
+
+	return b.advance(prt, current.Process(text))
+}
+
+func (b *Bot) ProcessUpload(
+	prt *Participant,
+	upload Upload,
+) ([]Message, error) {
+	if prt.IsBlocked() || prt.AwaitsOperator() {
+		return make([]Message, 0), nil
+	}
+
+	current, ok := b.blocks[prt.State]
+	if !ok {
+		return make([]Message, 0), nil
+	}
+
+	if current.Type != FileBlock {
+		return b.reask(prt, current)
+	}
+
+	err := prt.AddUpload(upload)
+	if err != nil {
+		return nil, err
+	}
+
+	return b.advance(prt, current.NextState)
+}
+
+func (b *Bot) ExpectsUpload(prt *Participant) bool {
+	current, ok := b.blocks[prt.State]
+	return ok && current.Type == FileBlock && !prt.IsBlocked() && !prt.AwaitsOperator()
+}
+
+func (b *Bot) reask(prt *Participant, current Block) ([]Message, error) {
+	if !current.ExpectsAnswer() {
+		return make([]Message, 0), nil
+	}
+
+	msg, err := b.blockMessage(prt, current)
+	if err != nil {
+		return nil, err
+	}
+
+	return []Message{msg}, nil
+}
+
+func (b *Bot) advance(
+	prt *Participant,
+	nextState int,
+) ([]Message, error) {
+	messages := make([]Message, 0)
+
 	prt.SwitchTo(nextState)
 
 	next, ok := b.blocks[nextState]
 	if ok {
-		message, err := next.Message()
+		message, err := b.blockMessage(prt, next)
 		if err != nil {
 			return nil, err
 		}
 		messages = append(messages, message)
 	}
 
-	if next.Type == MessageBlock {
+	if ok && !next.ExpectsAnswer() {
 		ms, err := b.Process(prt, "")
 		if err != nil {
 			return nil, err
@@ -59,13 +124,13 @@ func (b *Bot) processStart(
 
 	start := b.blocks[prt.State]
 
-	msg, err := start.Message()
+	msg, err := b.blockMessage(prt, start)
 	if err != nil {
 		return nil, err
 	}
 	messages = append(messages, msg)
 
-	if start.Type == MessageBlock {
+	if !start.ExpectsAnswer() {
 		ms, err := b.Process(prt, "")
 		if err != nil {
 			return nil, err
@@ -75,3 +140,24 @@ func (b *Bot) processStart(
 
 	return messages, nil
 }
+
+func (b *Bot) Resume(prt *Participant) ([]Message, error) {
+	prt.Resume()
+
+	messages := make([]Message, 0, 1)
+	if !prt.IsProcessing() {
+		return messages, nil
+	}
+
+	current, ok := b.blocks[prt.State]
+	if !ok {
+		return messages, nil
+	}
+
+	msg, err := b.blockMessage(prt, current)
+	if err != nil {
+		return nil, err
+	}
+
+	return append(messages, msg), nil
+}
@@ -7,6 +7,7 @@ import (
 	"github.com/google/uuid"
 	"github.com/stretchr/testify/require"
 
+	"github.com/bmstu-itstech/itsreg-bots/internal/common/commonerrs"
 	"github.com/bmstu-itstech/itsreg-bots/internal/domain/bots"
 )
 
@@ -155,6 +156,76 @@ func TestBot_Process(t *testing.T) {
 		require.NoError(t, err)
 		requireAnswers(t, []bots.Answer{}, prt.Answers())
 	})
+
+	t.Run("should ignore messages of paused participant", func(t *testing.T) {
+		userID := rand.Int64()
+		prt := bots.MustNewParticipant(botUUID, userID)
+		prt.SwitchTo(2)
+		prt.Pause()
+
+		resp, err := bot.Process(prt, "Ivan")
+		require.NoError(t, err)
+		requireMessages(t, []bots.Message{}, resp)
+		requireAnswers(t, []bots.Answer{}, prt.Answers())
+		require.Equal(t, 2, prt.State)
+
+		resp, err = bot.Entry(prt, "start")
+		require.NoError(t, err)
+		requireMessages(t, []bots.Message{}, resp)
+		require.Equal(t, 2, prt.State)
+	})
+
+	t.Run("should ignore blocked participant", func(t *testing.T) {
+		userID := rand.Int64()
+		prt := bots.MustNewParticipant(botUUID, userID)
+		prt.SwitchTo(2)
+		prt.Block()
+
+		resp, err := bot.Process(prt, "Ivan")
+		require.NoError(t, err)
+		requireMessages(t, []bots.Message{}, resp)
+		requireAnswers(t, []bots.Answer{}, prt.Answers())
+
+		resp, err = bot.Entry(prt, "start")
+		require.NoError(t, err)
+		requireMessages(t, []bots.Message{}, resp)
+		require.Equal(t, 2, prt.State)
+	})
+
+	t.Run("should reset participant to entry point", func(t *testing.T) {
+		userID := rand.Int64()
+		prt := bots.MustNewParticipant(botUUID, userID)
+
+		_, err := bot.Entry(prt, "start")
+		require.NoError(t, err)
+		_, err = bot.Process(prt, "Ivan")
+		require.NoError(t, err)
+
+		resp, err := bot.Reset(prt, "start")
+		require.NoError(t, err)
+		requireMessages(t, []bots.Message{
+			bots.MustNewPlainMessage(greetingBlock.Text),
+			bots.MustNewPlainMessage(usernameBlock.Text),
+		}, resp)
+		requireAnswers(t, []bots.Answer{}, prt.Answers())
+
+		_, err = bot.Reset(prt, "unknown")
+		require.ErrorAs(t, err, &bots.EntryNotFoundError{})
+	})
+
+	t.Run("should resend current block on resume", func(t *testing.T) {
+		userID := rand.Int64()
+		prt := bots.MustNewParticipant(botUUID, userID)
+		prt.SwitchTo(2)
+		prt.Pause()
+
+		resp, err := bot.Resume(prt)
+		require.NoError(t, err)
+		require.False(t, prt.IsPaused())
+		requireMessages(t, []bots.Message{
+			bots.MustNewPlainMessage(usernameBlock.Text),
+		}, resp)
+	})
 }
 
 func requireMessages(t *testing.T, expected []bots.Message, actual []bots.Message) {
@@ -170,3 +241,49 @@ func requireAnswers(t *testing.T, expected []bots.Answer, actual []bots.Answer)
 		require.Equalf(t, expected[i], ans, "expected answer %v, got %v", expected[i], ans)
 	}
 }
+
+func TestBlock_WithUnique(t *testing.T) {
+	t.Run("should make question block unique", func(t *testing.T) {
+		block, err := bots.MustNewQuestionBlock(1, 0, "Email", "Your email?").WithUnique("")
+		require.NoError(t, err)
+		require.True(t, block.Unique)
+		require.Equal(t, bots.DefaultUniqueText, block.UniqueText)
+	})
+
+	t.Run("should return error for not question block", func(t *testing.T) {
+		_, err := bots.MustNewMessageBlock(1, 0, "Greeting", "Hello!").WithUnique("")
+		require.ErrorAs(t, err, &commonerrs.InvalidInputError{})
+	})
+}
+
+func TestBot_RejectAnswer(t *testing.T) {
+	emailBlock, err := bots.MustNewQuestionBlock(1, 2, "Email", "Your email?").WithUnique("Email is taken")
+	require.NoError(t, err)
+	endBlock := bots.MustNewMessageBlock(2, 0, "End", "Thanks!")
+
+	entries := []bots.EntryPoint{
+		bots.MustNewEntryPoint("start", 1),
+	}
+	botUUID := uuid.NewString()
+	bot := bots.MustNewBot(botUUID, uuid.NewString(), entries, nil, []bots.Block{emailBlock, endBlock}, "Test bot", "12345678:XXXXXXXXXXXXXXXXXXXXXXXXXXXXXXXXXXX")
+
+	t.Run("should ask unique question again", func(t *testing.T) {
+		prt := bots.MustNewParticipant(botUUID, rand.Int64())
+		prt.SwitchTo(1)
+
+		resp, err := bot.RejectAnswer(prt, 1)
+		require.NoError(t, err)
+		requireMessages(t, []bots.Message{
+			bots.MustNewPlainMessage("Email is taken"),
+			bots.MustNewPlainMessage(emailBlock.Text),
+		}, resp)
+	})
+
+	t.Run("should return error for not unique block", func(t *testing.T) {
+		prt := bots.MustNewParticipant(botUUID, rand.Int64())
+		prt.SwitchTo(2)
+
+		_, err := bot.RejectAnswer(prt, 2)
+		require.Error(t, err)
+	})
+}
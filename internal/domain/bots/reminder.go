@@ -0,0 +1,112 @@
+package bots
+
+import (
+	"time"
+
+	"github.com/bmstu-itstech/itsreg-bots/internal/common/commonerrs"
+)
+
+const (
+	DefaultReminderText  = "Вы не закончили регистрацию. Давайте продолжим!"
+	DefaultReminderLimit = 1
+	MinReminderDelay     = time.Minute
+)
+
+type Reminder struct {
+	After time.Duration
+	Limit int
+	Text  string
+}
+
+func (r Reminder) IsZero() bool {
+	return r.After == 0
+}
+
+func NewReminder(after time.Duration, limit int, text string) (Reminder, error) {
+	if after == 0 {
+		if limit != 0 || text != "" {
+			return Reminder{}, commonerrs.NewInvalidInputError("expected reminder delay")
+		}
+		return Reminder{}, nil
+	}
+
+	if after < MinReminderDelay {
+		return Reminder{}, commonerrs.NewInvalidInputErrorf("expected reminder delay of at least %s", MinReminderDelay)
+	}
+
+	if limit < 0 {
+		return Reminder{}, commonerrs.NewInvalidInputError("expected non-negative reminder limit")
+	}
+
+	if limit == 0 {
+		limit = DefaultReminderLimit
+	}
+
+	if text == "" {
+		text = DefaultReminderText
+	}
+
+	return Reminder{
+		After: after,
+		Limit: limit,
+		Text:  text,
+	}, nil
+}
+
+func MustNewReminder(after time.Duration, limit int, text string) Reminder {
+	r, err := NewReminder(after, limit, text)
+	if err != nil {
+		panic(err)
+	}
+	return r
+}
+
+func (e EntryPoint) WithReminder(r Reminder) EntryPoint {
+	e.Reminder = r
+	return e
+}
+
+func (b *Bot) ReminderDelay() time.Duration {
+	var delay time.Duration
+	for _, e := range b.entryPoints {
+		if e.Reminder.IsZero() {
+			continue
+		}
+		if delay == 0 || e.Reminder.After < delay {
+			delay = e.Reminder.After
+		}
+	}
+	return delay
+}
+
+func (b *Bot) Remind(prt *Participant, now time.Time) ([]Message, error) {
+	messages := make([]Message, 0)
+
+	if prt.IsBlocked() || prt.AwaitsOperator() || b.IsExpired(prt, now) {
+		return messages, nil
+	}
+
+	entry, ok := b.entryPoints[prt.FlowEntry]
+	if !ok || entry.Reminder.IsZero() || prt.LastActivityAt.IsZero() || prt.RemindersSent >= entry.Reminder.Limit {
+		return messages, nil
+	}
+
+	due := prt.LastActivityAt.Add(entry.Reminder.After * time.Duration(prt.RemindersSent+1))
+	if now.Before(due) {
+		return messages, nil
+	}
+
+	current, ok := b.blocks[prt.State]
+	if !ok || !current.ExpectsAnswer() {
+		return messages, nil
+	}
+
+	msg, err := b.blockMessage(prt, current)
+	if err != nil {
+		return nil, err
+	}
+
+	prt.RemindersSent++
+
+	return append(messages, MustNewPlainMessage(entry.Reminder.Text), msg), nil
+}
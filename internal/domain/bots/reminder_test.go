@@ -0,0 +1,113 @@
+package bots_test
+
+import (
+	"math/rand/v2"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+
+	"github.com/bmstu-itstech/itsreg-bots/internal/common/commonerrs"
+	"github.com/bmstu-itstech/itsreg-bots/internal/domain/bots"
+)
+
+func TestNewReminder(t *testing.T) {
+	t.Run("should set defaults", func(t *testing.T) {
+		r, err := bots.NewReminder(time.Hour, 0, "")
+		require.NoError(t, err)
+		require.Equal(t, bots.DefaultReminderLimit, r.Limit)
+		require.Equal(t, bots.DefaultReminderText, r.Text)
+	})
+
+	t.Run("should reject too short delay", func(t *testing.T) {
+		_, err := bots.NewReminder(time.Second, 1, "")
+		require.ErrorAs(t, err, &commonerrs.InvalidInputError{})
+	})
+
+	t.Run("should reject text without delay", func(t *testing.T) {
+		_, err := bots.NewReminder(0, 0, "Hey")
+		require.ErrorAs(t, err, &commonerrs.InvalidInputError{})
+	})
+}
+
+func TestBot_Remind(t *testing.T) {
+	entries := []bots.EntryPoint{
+		bots.MustNewEntryPoint("start", 1).WithReminder(bots.MustNewReminder(time.Hour, 2, "Still there?")),
+		bots.MustNewEntryPoint("quiet", 1),
+	}
+	botUUID := uuid.NewString()
+	bot := bots.MustNewBot(
+		botUUID, uuid.NewString(), entries, nil, []bots.Block{
+			bots.MustNewQuestionBlock(1, 2, "Name", "What's your name?"),
+			bots.MustNewMessageBlock(2, 0, "Finish", "Bye"),
+		},
+		"Test bot", "12345678:XXXXXXXXXXXXXXXXXXXXXXXXXXXXXXXXXXX",
+	)
+	require.Equal(t, time.Hour, bot.ReminderDelay())
+
+	start := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+	enter := func(t *testing.T, key string) *bots.Participant {
+		prt := bots.MustNewParticipant(botUUID, rand.Int64())
+		prt.Touch(start)
+		_, err := bot.EntryAt(prt, key, start)
+		require.NoError(t, err)
+		return prt
+	}
+
+	t.Run("should remind up to limit", func(t *testing.T) {
+		prt := enter(t, "start")
+
+		resp, err := bot.Remind(prt, start.Add(30*time.Minute))
+		require.NoError(t, err)
+		require.Empty(t, resp)
+
+		resp, err = bot.Remind(prt, start.Add(time.Hour))
+		require.NoError(t, err)
+		requireMessages(t, []bots.Message{
+			bots.MustNewPlainMessage("Still there?"),
+			bots.MustNewPlainMessage("What's your name?"),
+		}, resp)
+		require.Equal(t, 1, prt.RemindersSent)
+
+		resp, err = bot.Remind(prt, start.Add(90*time.Minute))
+		require.NoError(t, err)
+		require.Empty(t, resp)
+
+		resp, err = bot.Remind(prt, start.Add(2*time.Hour))
+		require.NoError(t, err)
+		require.Len(t, resp, 2)
+
+		resp, err = bot.Remind(prt, start.Add(10*time.Hour))
+		require.NoError(t, err)
+		require.Empty(t, resp)
+	})
+
+	t.Run("should reset reminders on activity", func(t *testing.T) {
+		prt := enter(t, "start")
+
+		_, err := bot.Remind(prt, start.Add(time.Hour))
+		require.NoError(t, err)
+		require.Equal(t, 1, prt.RemindersSent)
+
+		prt.Touch(start.Add(3 * time.Hour))
+		require.Zero(t, prt.RemindersSent)
+	})
+
+	t.Run("should not remind without reminder on entry", func(t *testing.T) {
+		prt := enter(t, "quiet")
+
+		resp, err := bot.Remind(prt, start.Add(24*time.Hour))
+		require.NoError(t, err)
+		require.Empty(t, resp)
+	})
+
+	t.Run("should not remind paused participant", func(t *testing.T) {
+		prt := enter(t, "start")
+		prt.Pause()
+
+		resp, err := bot.Remind(prt, start.Add(24*time.Hour))
+		require.NoError(t, err)
+		require.Empty(t, resp)
+	})
+}
@@ -3,6 +3,7 @@ package bots
 import (
 	"context"
 	"fmt"
+	"time"
 )
 
 type BotNotFoundError struct {
@@ -21,6 +22,7 @@ type Repository interface {
 	) error
 	UpdateOrCreate(ctx context.Context, bot *Bot) error
 	UpdateStatus(ctx context.Context, botUUID string, status Status) error
+	MarkFailed(ctx context.Context, botUUID string, reason string, failedAt time.Time) error
 	Delete(ctx context.Context, uuid string) error
 
 	Bot(ctx context.Context, uuid string) (*Bot, error)
@@ -0,0 +1,53 @@
+package bots
+
+import (
+	"fmt"
+
+	"github.com/bmstu-itstech/itsreg-bots/internal/common/commonerrs"
+)
+
+type Role struct {
+	s string
+}
+
+var (
+	OwnerRole    = Role{s: "owner"}
+	EditorRole   = Role{s: "editor"}
+	OperatorRole = Role{s: "operator"}
+	ViewerRole   = Role{s: "viewer"}
+)
+
+var roleRanks = map[Role]int{
+	ViewerRole:   1,
+	OperatorRole: 2,
+	EditorRole:   3,
+	OwnerRole:    4,
+}
+
+func (r Role) String() string {
+	return r.s
+}
+
+func (r Role) IsZero() bool {
+	return r == Role{}
+}
+
+func (r Role) Includes(o Role) bool {
+	return roleRanks[r] >= roleRanks[o]
+}
+
+func NewRoleFromString(s string) (Role, error) {
+	switch s {
+	case "owner":
+		return OwnerRole, nil
+	case "editor":
+		return EditorRole, nil
+	case "operator":
+		return OperatorRole, nil
+	case "viewer":
+		return ViewerRole, nil
+	}
+	return Role{}, commonerrs.NewInvalidInputError(
+		fmt.Sprintf("invalid role %s, expected one of ['owner', 'editor', 'operator', 'viewer']", s),
+	)
+}
@@ -0,0 +1,14 @@
+package bots
+
+import "context"
+
+type SeatsRepository interface {
+	Seats(ctx context.Context, botUUID string, entryKey string) (*Seats, error)
+	UserEntries(ctx context.Context, botUUID string, userID int64) ([]string, error)
+	UpdateSeats(
+		ctx context.Context,
+		botUUID string,
+		entryKey string,
+		updateFn func(innerCtx context.Context, seats *Seats) error,
+	) error
+}
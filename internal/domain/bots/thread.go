@@ -0,0 +1,125 @@
+package bots
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/bmstu-itstech/itsreg-bots/internal/common/commonerrs"
+)
+
+type MessageDirection struct {
+	s string
+}
+
+var (
+	IncomingDirection = MessageDirection{s: "incoming"}
+	OutgoingDirection = MessageDirection{s: "outgoing"}
+)
+
+func (d MessageDirection) String() string {
+	return d.s
+}
+
+func (d MessageDirection) IsZero() bool {
+	return d == MessageDirection{}
+}
+
+func NewMessageDirectionFromString(s string) (MessageDirection, error) {
+	switch s {
+	case "incoming":
+		return IncomingDirection, nil
+	case "outgoing":
+		return OutgoingDirection, nil
+	}
+	return MessageDirection{}, commonerrs.NewInvalidInputError(
+		fmt.Sprintf("invalid message direction %s, expected one of ['incoming', 'outgoing']", s),
+	)
+}
+
+type ThreadMessage struct {
+	BotUUID    string
+	UserID     int64
+	Direction  MessageDirection
+	Text       string
+	AuthorUUID string
+	CreatedAt  time.Time
+}
+
+func NewIncomingThreadMessage(botUUID string, userID int64, text string) (ThreadMessage, error) {
+	if botUUID == "" {
+		return ThreadMessage{}, commonerrs.NewInvalidInputError("expected not empty botUUID")
+	}
+
+	if userID == 0 {
+		return ThreadMessage{}, commonerrs.NewInvalidInputError("expected not empty user id")
+	}
+
+	if text == "" {
+		return ThreadMessage{}, commonerrs.NewInvalidInputError("expected not empty message text")
+	}
+
+	return ThreadMessage{
+		BotUUID:   botUUID,
+		UserID:    userID,
+		Direction: IncomingDirection,
+		Text:      text,
+		CreatedAt: time.Now(),
+	}, nil
+}
+
+func NewOutgoingThreadMessage(botUUID string, userID int64, authorUUID string, text string) (ThreadMessage, error) {
+	if botUUID == "" {
+		return ThreadMessage{}, commonerrs.NewInvalidInputError("expected not empty botUUID")
+	}
+
+	if userID == 0 {
+		return ThreadMessage{}, commonerrs.NewInvalidInputError("expected not empty user id")
+	}
+
+	if authorUUID == "" {
+		return ThreadMessage{}, commonerrs.NewInvalidInputError("expected not empty author uuid")
+	}
+
+	if text == "" {
+		return ThreadMessage{}, commonerrs.NewInvalidInputError("expected not empty message text")
+	}
+
+	return ThreadMessage{
+		BotUUID:    botUUID,
+		UserID:     userID,
+		Direction:  OutgoingDirection,
+		Text:       text,
+		AuthorUUID: authorUUID,
+		CreatedAt:  time.Now(),
+	}, nil
+}
+
+func UnmarshallThreadMessageFromDB(
+	botUUID string,
+	userID int64,
+	direction string,
+	text string,
+	authorUUID string,
+	createdAt time.Time,
+) (ThreadMessage, error) {
+	d, err := NewMessageDirectionFromString(direction)
+	if err != nil {
+		return ThreadMessage{}, err
+	}
+
+	return ThreadMessage{
+		BotUUID:    botUUID,
+		UserID:     userID,
+		Direction:  d,
+		Text:       text,
+		AuthorUUID: authorUUID,
+		CreatedAt:  createdAt,
+	}, nil
+}
+
+type Thread struct {
+	BotUUID     string
+	UserID      int64
+	Paused      bool
+	LastMessage ThreadMessage
+}
@@ -0,0 +1,9 @@
+package bots
+
+import "context"
+
+type ThreadRepository interface {
+	AddMessage(ctx context.Context, msg ThreadMessage) error
+	Threads(ctx context.Context, botUUID string) ([]Thread, error)
+	Messages(ctx context.Context, botUUID string, userID int64) ([]ThreadMessage, error)
+}
@@ -0,0 +1,113 @@
+package bots
+
+import (
+	"crypto/rand"
+	"encoding/base32"
+	"fmt"
+	"time"
+
+	"github.com/bmstu-itstech/itsreg-bots/internal/common/commonerrs"
+)
+
+const ticketCodeBytes = 10
+
+type Ticket struct {
+	BotUUID    string
+	UserID     int64
+	Code       string
+	AttendedAt time.Time
+}
+
+func UnmarshallTicketFromDB(
+	botUUID string,
+	userID int64,
+	code string,
+	attendedAt time.Time,
+) (*Ticket, error) {
+	if botUUID == "" {
+		return nil, commonerrs.NewInvalidInputError("expected not empty botUUID")
+	}
+
+	if userID == 0 {
+		return nil, commonerrs.NewInvalidInputError("expected not empty userID")
+	}
+
+	if code == "" {
+		return nil, commonerrs.NewInvalidInputError("expected not empty ticket code")
+	}
+
+	return &Ticket{
+		BotUUID:    botUUID,
+		UserID:     userID,
+		Code:       code,
+		AttendedAt: attendedAt,
+	}, nil
+}
+
+func (t *Ticket) IsAttended() bool {
+	return !t.AttendedAt.IsZero()
+}
+
+func (t *Ticket) CheckIn() error {
+	if t.IsAttended() {
+		return TicketAlreadyUsedError{Code: t.Code, AttendedAt: t.AttendedAt}
+	}
+	t.AttendedAt = time.Now()
+	return nil
+}
+
+type TicketNotFoundError struct {
+	Code string
+}
+
+func (e TicketNotFoundError) Error() string {
+	return fmt.Sprintf("ticket '%s' not found", e.Code)
+}
+
+type TicketAlreadyUsedError struct {
+	Code       string
+	AttendedAt time.Time
+}
+
+func (e TicketAlreadyUsedError) Error() string {
+	return fmt.Sprintf("ticket '%s' already used at %s", e.Code, e.AttendedAt.Format(time.DateTime))
+}
+
+func NewTicketCode() (string, error) {
+	b := make([]byte, ticketCodeBytes)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return base32.StdEncoding.WithPadding(base32.NoPadding).EncodeToString(b), nil
+}
+
+func (b *Bot) HasTickets() bool {
+	for _, block := range b.blocks {
+		if block.Type == TicketBlock {
+			return true
+		}
+	}
+	return false
+}
+
+func (b *Bot) ticketMessage(prt *Participant, block Block) (Message, error) {
+	ans, ok := prt.Answer(block.State)
+	if !ok {
+		code, err := NewTicketCode()
+		if err != nil {
+			return Message{}, err
+		}
+		ans, err = NewAnswer(block.State, code)
+		if err != nil {
+			return Message{}, err
+		}
+		prt.answers[block.State] = ans
+	}
+
+	msg, err := NewTicketMessage(block.Text, ans.Text)
+	if err != nil {
+		return Message{}, err
+	}
+	msg.Format = block.Format
+	return msg, nil
+}
@@ -0,0 +1,13 @@
+package bots
+
+import "context"
+
+type TicketRepository interface {
+	Tickets(ctx context.Context, botUUID string) ([]*Ticket, error)
+	UpdateTicket(
+		ctx context.Context,
+		botUUID string,
+		code string,
+		updateFn func(innerCtx context.Context, ticket *Ticket) error,
+	) error
+}
@@ -0,0 +1,118 @@
+package bots_test
+
+import (
+	"math/rand/v2"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+
+	"github.com/bmstu-itstech/itsreg-bots/internal/domain/bots"
+)
+
+func TestBot_Ticket(t *testing.T) {
+	questionBlock := bots.MustNewQuestionBlock(1, 2, "Name", "What's your name?")
+	ticketBlock := bots.MustNewTicketBlock(2, 3, "Ticket", "Your ticket")
+	endBlock := bots.MustNewMessageBlock(3, 0, "End", "See you!")
+
+	blocks := []bots.Block{questionBlock, ticketBlock, endBlock}
+	entries := []bots.EntryPoint{
+		bots.MustNewEntryPoint("start", 1),
+	}
+	botUUID := uuid.NewString()
+	userUUID := uuid.NewString()
+	bot := bots.MustNewBot(botUUID, userUUID, entries, nil, blocks, "Test bot", "12345678:XXXXXXXXXXXXXXXXXXXXXXXXXXXXXXXXXXX")
+
+	t.Run("should issue ticket and advance", func(t *testing.T) {
+		prt := bots.MustNewParticipant(botUUID, rand.Int64())
+		prt.SwitchTo(1)
+
+		resp, err := bot.Process(prt, "Ivan")
+		require.NoError(t, err)
+
+		code, ok := prt.Answer(2)
+		require.True(t, ok)
+		require.NotEmpty(t, code.Text)
+
+		requireMessages(t, []bots.Message{
+			bots.MustNewTicketMessage(ticketBlock.Text, code.Text),
+			bots.MustNewPlainMessage(endBlock.Text),
+		}, resp)
+	})
+
+	t.Run("should keep issued ticket code", func(t *testing.T) {
+		prt := bots.MustNewParticipant(botUUID, rand.Int64())
+		prt.SwitchTo(1)
+
+		_, err := bot.Process(prt, "Ivan")
+		require.NoError(t, err)
+		first, _ := prt.Answer(2)
+
+		prt.SwitchTo(1)
+		_, err = bot.Process(prt, "Ivan")
+		require.NoError(t, err)
+		second, _ := prt.Answer(2)
+
+		require.Equal(t, first.Text, second.Text)
+	})
+
+	t.Run("should report tickets", func(t *testing.T) {
+		require.True(t, bot.HasTickets())
+	})
+}
+
+func TestTicket_CheckIn(t *testing.T) {
+	t.Run("should check in once", func(t *testing.T) {
+		code, err := bots.NewTicketCode()
+		require.NoError(t, err)
+
+		ticket, err := bots.UnmarshallTicketFromDB(uuid.NewString(), rand.Int64(), code, time.Time{})
+		require.NoError(t, err)
+		require.False(t, ticket.IsAttended())
+
+		require.NoError(t, ticket.CheckIn())
+		require.True(t, ticket.IsAttended())
+
+		err = ticket.CheckIn()
+		require.ErrorAs(t, err, &bots.TicketAlreadyUsedError{})
+	})
+
+	t.Run("should generate distinct codes", func(t *testing.T) {
+		a, err := bots.NewTicketCode()
+		require.NoError(t, err)
+		b, err := bots.NewTicketCode()
+		require.NoError(t, err)
+		require.NotEqual(t, a, b)
+	})
+}
+
+func TestNewAnswersTableWithTickets(t *testing.T) {
+	t.Run("should add attended column", func(t *testing.T) {
+		entries := []bots.EntryPoint{
+			bots.MustNewEntryPoint("start", 1),
+		}
+		blocks := []bots.Block{
+			bots.MustNewQuestionBlock(1, 2, "Name", "What is your name?"),
+			bots.MustNewTicketBlock(2, 0, "Ticket", "Your ticket"),
+		}
+		botUUID := uuid.NewString()
+		userUUID := uuid.NewString()
+		bot := bots.MustNewBot(botUUID, userUUID, entries, nil, blocks, "Test bot", "12345678:XXXXXXXXXXXXXXXXXXXXXXXXXXXXXXXXXXX")
+
+		ivan := bots.MustNewParticipant(botUUID, 10)
+		ivan.SwitchTo(1)
+		require.NoError(t, ivan.AddAnswer("Ivan"))
+
+		attendedAt := time.Date(2024, 9, 1, 10, 0, 0, 0, time.Local)
+		tickets := []*bots.Ticket{
+			{BotUUID: botUUID, UserID: 10, Code: "CODE", AttendedAt: attendedAt},
+		}
+
+		table := bots.NewAnswersTableWithTickets(bot, []*bots.Participant{ivan}, tickets)
+		require.Equal(t, []string{"UserID", "Name", "Ticket", "Attended"}, table.Head)
+		require.Equal(t, [][]string{
+			{"10", "Ivan", "", attendedAt.Format(time.DateTime)},
+		}, table.Body)
+	})
+}
@@ -0,0 +1,80 @@
+package bots
+
+import (
+	"context"
+	"regexp"
+	"strings"
+
+	"github.com/bmstu-itstech/itsreg-bots/internal/common/commonerrs"
+)
+
+var tokenRegexp = regexp.MustCompile(`^[0-9]{8,10}:[a-zA-Z0-9_-]{35}$`)
+
+var (
+	ErrInvalidToken = commonerrs.NewInvalidInputError("invalid token")
+	ErrTokenInUse   = commonerrs.NewInvalidInputError("token is already used by another bot")
+)
+
+type TelegramIdentity struct {
+	ID       int64
+	Username string
+}
+
+func (i TelegramIdentity) IsZero() bool {
+	return i.ID == 0
+}
+
+type TokenVerifier interface {
+	Verify(ctx context.Context, token string) (TelegramIdentity, error)
+}
+
+type SealedToken struct {
+	KeyID        string
+	EncryptedKey []byte
+	Ciphertext   []byte
+}
+
+func (t SealedToken) IsZero() bool {
+	return len(t.Ciphertext) == 0
+}
+
+type TokenCipher interface {
+	Seal(token string) (SealedToken, error)
+	Open(sealed SealedToken) (string, error)
+	Rotate(sealed SealedToken) (SealedToken, error)
+}
+
+const tokenMaskVisible = 4
+
+func MaskToken(token string) string {
+	id, secret, ok := strings.Cut(token, ":")
+	if !ok || len(secret) <= tokenMaskVisible {
+		return strings.Repeat("*", len(token))
+	}
+	return id + ":" + strings.Repeat("*", len(secret)-tokenMaskVisible) + secret[len(secret)-tokenMaskVisible:]
+}
+
+type redactedTokenError struct {
+	err   error
+	token string
+}
+
+func (e redactedTokenError) Error() string {
+	return strings.ReplaceAll(e.err.Error(), e.token, "<token>")
+}
+
+func (e redactedTokenError) Unwrap() error {
+	return e.err
+}
+
+func RedactToken(err error, token string) error {
+	if err == nil || token == "" {
+		return err
+	}
+	return redactedTokenError{err: err, token: token}
+}
+
+func (b *Bot) SetTelegramIdentity(identity TelegramIdentity) {
+	b.TelegramID = identity.ID
+	b.Username = identity.Username
+}
@@ -0,0 +1,251 @@
+package bots
+
+import (
+	"fmt"
+	"net/netip"
+	"net/url"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/bmstu-itstech/itsreg-bots/internal/common/commonerrs"
+)
+
+const webhookSecretMinLength = 16
+
+type Webhook struct {
+	UUID    string
+	BotUUID string
+
+	URL    string
+	Secret string
+	Events []EventType
+
+	CreatedAt time.Time
+}
+
+func NewWebhook(
+	uuid string,
+	botUUID string,
+	rawURL string,
+	secret string,
+	events []string,
+) (Webhook, error) {
+	if uuid == "" {
+		return Webhook{}, commonerrs.NewInvalidInputError("expected not empty webhook uuid")
+	}
+
+	if botUUID == "" {
+		return Webhook{}, commonerrs.NewInvalidInputError("expected not empty bot uuid")
+	}
+
+	u, err := url.Parse(rawURL)
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		return Webhook{}, commonerrs.NewInvalidInputErrorf("invalid webhook url %q", rawURL)
+	}
+
+	if isInternalWebhookHost(u.Hostname()) {
+		return Webhook{}, commonerrs.NewInvalidInputErrorf("webhook url %q points to an internal address", rawURL)
+	}
+
+	if len(secret) < webhookSecretMinLength {
+		return Webhook{}, commonerrs.NewInvalidInputErrorf(
+			"expected webhook secret of at least %d characters", webhookSecretMinLength,
+		)
+	}
+
+	ets, err := mapEventTypes(events)
+	if err != nil {
+		return Webhook{}, err
+	}
+
+	return Webhook{
+		UUID:      uuid,
+		BotUUID:   botUUID,
+		URL:       rawURL,
+		Secret:    secret,
+		Events:    ets,
+		CreatedAt: time.Now(),
+	}, nil
+}
+
+func MustNewWebhook(
+	uuid string,
+	botUUID string,
+	rawURL string,
+	secret string,
+	events []string,
+) Webhook {
+	w, err := NewWebhook(uuid, botUUID, rawURL, secret, events)
+	if err != nil {
+		panic(err)
+	}
+	return w
+}
+
+func UnmarshallWebhookFromDB(
+	uuid string,
+	botUUID string,
+	rawURL string,
+	secret string,
+	events []string,
+	createdAt time.Time,
+) (Webhook, error) {
+	if uuid == "" {
+		return Webhook{}, commonerrs.NewInvalidInputError("expected not empty webhook uuid")
+	}
+
+	if botUUID == "" {
+		return Webhook{}, commonerrs.NewInvalidInputError("expected not empty bot uuid")
+	}
+
+	if createdAt.IsZero() {
+		return Webhook{}, commonerrs.NewInvalidInputError("expected not empty created at timestamp")
+	}
+
+	ets, err := mapEventTypes(events)
+	if err != nil {
+		return Webhook{}, err
+	}
+
+	return Webhook{
+		UUID:      uuid,
+		BotUUID:   botUUID,
+		URL:       rawURL,
+		Secret:    secret,
+		Events:    ets,
+		CreatedAt: createdAt,
+	}, nil
+}
+
+func (w Webhook) IsZero() bool {
+	return w.UUID == ""
+}
+
+func (w Webhook) Subscribed(t EventType) bool {
+	return slices.Contains(w.Events, t)
+}
+
+func IsPublicWebhookAddr(addr netip.Addr) bool {
+	addr = addr.Unmap()
+	return addr.IsGlobalUnicast() && !addr.IsPrivate()
+}
+
+func isInternalWebhookHost(host string) bool {
+	host = strings.TrimSuffix(strings.ToLower(host), ".")
+	if host == "localhost" || strings.HasSuffix(host, ".localhost") {
+		return true
+	}
+
+	addr, err := netip.ParseAddr(host)
+	if err != nil {
+		return false
+	}
+	return !IsPublicWebhookAddr(addr)
+}
+
+func mapEventTypes(events []string) ([]EventType, error) {
+	if len(events) == 0 {
+		return nil, commonerrs.NewInvalidInputError("expected not empty webhook events")
+	}
+
+	res := make([]EventType, 0, len(events))
+	for _, e := range events {
+		t, err := NewEventTypeFromString(e)
+		if err != nil {
+			return nil, err
+		}
+		if !slices.Contains(res, t) {
+			res = append(res, t)
+		}
+	}
+	return res, nil
+}
+
+type DeliveryStatus struct {
+	s string
+}
+
+var (
+	DeliveryPending   = DeliveryStatus{s: "pending"}
+	DeliveryDelivered = DeliveryStatus{s: "delivered"}
+	DeliveryFailed    = DeliveryStatus{s: "failed"}
+)
+
+func (s DeliveryStatus) String() string {
+	return s.s
+}
+
+func (s DeliveryStatus) IsZero() bool {
+	return s == DeliveryStatus{}
+}
+
+func NewDeliveryStatusFromString(s string) (DeliveryStatus, error) {
+	switch s {
+	case "pending":
+		return DeliveryPending, nil
+	case "delivered":
+		return DeliveryDelivered, nil
+	case "failed":
+		return DeliveryFailed, nil
+	}
+	return DeliveryStatus{}, commonerrs.NewInvalidInputError(
+		fmt.Sprintf("invalid delivery status %s, expected one of ['pending', 'delivered', 'failed']", s),
+	)
+}
+
+type WebhookDelivery struct {
+	UUID        string
+	WebhookUUID string
+
+	Event        EventType
+	Status       DeliveryStatus
+	Attempts     int
+	ResponseCode int
+	LastError    string
+
+	CreatedAt time.Time
+	UpdatedAt time.Time
+}
+
+func UnmarshallWebhookDeliveryFromDB(
+	uuid string,
+	webhookUUID string,
+	event string,
+	status string,
+	attempts int,
+	responseCode int,
+	lastError string,
+	createdAt time.Time,
+	updatedAt time.Time,
+) (WebhookDelivery, error) {
+	if uuid == "" {
+		return WebhookDelivery{}, commonerrs.NewInvalidInputError("expected not empty delivery uuid")
+	}
+
+	if webhookUUID == "" {
+		return WebhookDelivery{}, commonerrs.NewInvalidInputError("expected not empty webhook uuid")
+	}
+
+	et, err := NewEventTypeFromString(event)
+	if err != nil {
+		return WebhookDelivery{}, err
+	}
+
+	st, err := NewDeliveryStatusFromString(status)
+	if err != nil {
+		return WebhookDelivery{}, err
+	}
+
+	return WebhookDelivery{
+		UUID:         uuid,
+		WebhookUUID:  webhookUUID,
+		Event:        et,
+		Status:       st,
+		Attempts:     attempts,
+		ResponseCode: responseCode,
+		LastError:    lastError,
+		CreatedAt:    createdAt,
+		UpdatedAt:    updatedAt,
+	}, nil
+}
@@ -0,0 +1,22 @@
+package bots
+
+import (
+	"context"
+	"fmt"
+)
+
+type WebhookNotFoundError struct {
+	UUID string
+}
+
+func (e WebhookNotFoundError) Error() string {
+	return fmt.Sprintf("webhook not found: %s", e.UUID)
+}
+
+type WebhookRepository interface {
+	Create(ctx context.Context, webhook Webhook) error
+	Delete(ctx context.Context, botUUID string, uuid string) error
+
+	BotWebhooks(ctx context.Context, botUUID string) ([]Webhook, error)
+	Deliveries(ctx context.Context, botUUID string, webhookUUID string) ([]WebhookDelivery, error)
+}
@@ -0,0 +1,134 @@
+package bots
+
+import (
+	"fmt"
+	"time"
+	_ "time/tzdata"
+
+	"github.com/bmstu-itstech/itsreg-bots/internal/common/commonerrs"
+)
+
+const (
+	DefaultWindowTimezone    = "UTC"
+	DefaultWindowNotOpenText = "Регистрация ещё не открыта."
+	DefaultWindowClosedText  = "Регистрация закрыта."
+)
+
+type WindowState struct {
+	s string
+}
+
+var (
+	WindowOpen    = WindowState{s: "open"}
+	WindowNotOpen = WindowState{s: "not_open"}
+	WindowClosed  = WindowState{s: "closed"}
+)
+
+func (s WindowState) String() string {
+	return s.s
+}
+
+func (s WindowState) IsZero() bool {
+	return s == WindowState{}
+}
+
+type Window struct {
+	OpensAt     time.Time
+	ClosesAt    time.Time
+	Timezone    string
+	NotOpenText string
+	ClosedText  string
+}
+
+func (w Window) IsZero() bool {
+	return w.OpensAt.IsZero() && w.ClosesAt.IsZero()
+}
+
+func (w Window) StateAt(t time.Time) WindowState {
+	if !w.OpensAt.IsZero() && t.Before(w.OpensAt) {
+		return WindowNotOpen
+	}
+	if !w.ClosesAt.IsZero() && !t.Before(w.ClosesAt) {
+		return WindowClosed
+	}
+	return WindowOpen
+}
+
+func (w Window) messageAt(t time.Time) (Message, bool) {
+	switch w.StateAt(t) {
+	case WindowNotOpen:
+		return MustNewPlainMessage(w.NotOpenText), true
+	case WindowClosed:
+		return MustNewPlainMessage(w.ClosedText), true
+	}
+	return Message{}, false
+}
+
+func NewWindow(
+	opensAt time.Time,
+	closesAt time.Time,
+	timezone string,
+	notOpenText string,
+	closedText string,
+) (Window, error) {
+	if opensAt.IsZero() && closesAt.IsZero() {
+		if timezone != "" || notOpenText != "" || closedText != "" {
+			return Window{}, commonerrs.NewInvalidInputError("expected window opening or closing time")
+		}
+		return Window{}, nil
+	}
+
+	if timezone == "" {
+		timezone = DefaultWindowTimezone
+	}
+
+	loc, err := time.LoadLocation(timezone)
+	if err != nil {
+		return Window{}, commonerrs.NewInvalidInputError(fmt.Sprintf("invalid window timezone %s", timezone))
+	}
+
+	if !opensAt.IsZero() && !closesAt.IsZero() && !closesAt.After(opensAt) {
+		return Window{}, commonerrs.NewInvalidInputError("expected window closing time after opening time")
+	}
+
+	if notOpenText == "" {
+		notOpenText = DefaultWindowNotOpenText
+	}
+
+	if closedText == "" {
+		closedText = DefaultWindowClosedText
+	}
+
+	w := Window{
+		Timezone:    timezone,
+		NotOpenText: notOpenText,
+		ClosedText:  closedText,
+	}
+	if !opensAt.IsZero() {
+		w.OpensAt = opensAt.In(loc)
+	}
+	if !closesAt.IsZero() {
+		w.ClosesAt = closesAt.In(loc)
+	}
+
+	return w, nil
+}
+
+func MustNewWindow(
+	opensAt time.Time,
+	closesAt time.Time,
+	timezone string,
+	notOpenText string,
+	closedText string,
+) Window {
+	w, err := NewWindow(opensAt, closesAt, timezone, notOpenText, closedText)
+	if err != nil {
+		panic(err)
+	}
+	return w
+}
+
+func (b *Bot) IsEntryOpen(key string, t time.Time) bool {
+	e, ok := b.entryPoints[key]
+	return ok && e.Window.StateAt(t) == WindowOpen
+}
@@ -0,0 +1,91 @@
+package bots_test
+
+import (
+	"math/rand/v2"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+
+	"github.com/bmstu-itstech/itsreg-bots/internal/common/commonerrs"
+	"github.com/bmstu-itstech/itsreg-bots/internal/domain/bots"
+)
+
+func TestNewWindow(t *testing.T) {
+	opensAt := time.Date(2024, 9, 1, 9, 0, 0, 0, time.UTC)
+	closesAt := opensAt.Add(24 * time.Hour)
+
+	t.Run("should create empty window", func(t *testing.T) {
+		w, err := bots.NewWindow(time.Time{}, time.Time{}, "", "", "")
+		require.NoError(t, err)
+		require.True(t, w.IsZero())
+		require.Equal(t, bots.WindowOpen, w.StateAt(time.Now()))
+	})
+
+	t.Run("should create window in timezone", func(t *testing.T) {
+		w, err := bots.NewWindow(opensAt, closesAt, "Europe/Moscow", "", "")
+		require.NoError(t, err)
+		require.Equal(t, "Europe/Moscow", w.OpensAt.Location().String())
+		require.True(t, opensAt.Equal(w.OpensAt))
+		require.Equal(t, bots.DefaultWindowNotOpenText, w.NotOpenText)
+		require.Equal(t, bots.DefaultWindowClosedText, w.ClosedText)
+	})
+
+	t.Run("should return window state", func(t *testing.T) {
+		w := bots.MustNewWindow(opensAt, closesAt, "", "", "")
+		require.Equal(t, bots.WindowNotOpen, w.StateAt(opensAt.Add(-time.Second)))
+		require.Equal(t, bots.WindowOpen, w.StateAt(opensAt))
+		require.Equal(t, bots.WindowClosed, w.StateAt(closesAt))
+	})
+
+	t.Run("should return error if window closes before opening", func(t *testing.T) {
+		_, err := bots.NewWindow(closesAt, opensAt, "", "", "")
+		require.ErrorAs(t, err, &commonerrs.InvalidInputError{})
+	})
+
+	t.Run("should return error if timezone is invalid", func(t *testing.T) {
+		_, err := bots.NewWindow(opensAt, closesAt, "Mars/Olympus", "", "")
+		require.ErrorAs(t, err, &commonerrs.InvalidInputError{})
+	})
+}
+
+func TestBot_EntryWindow(t *testing.T) {
+	opensAt := time.Date(2024, 9, 1, 9, 0, 0, 0, time.UTC)
+	closesAt := opensAt.Add(24 * time.Hour)
+
+	greetingBlock := bots.MustNewQuestionBlock(1, 0, "Greeting", "What's your name?")
+	entries := []bots.EntryPoint{
+		bots.MustNewEntryPoint("start", 1).
+			WithWindow(bots.MustNewWindow(opensAt, closesAt, "", "Not yet", "Too late")),
+	}
+	botUUID := uuid.NewString()
+	bot := bots.MustNewBot(botUUID, uuid.NewString(), entries, nil, []bots.Block{greetingBlock}, "Test bot", "12345678:XXXXXXXXXXXXXXXXXXXXXXXXXXXXXXXXXXX")
+
+	t.Run("should not start flow before window opens", func(t *testing.T) {
+		prt := bots.MustNewParticipant(botUUID, rand.Int64())
+
+		resp, err := bot.EntryAt(prt, "start", opensAt.Add(-time.Hour))
+		require.NoError(t, err)
+		requireMessages(t, []bots.Message{bots.MustNewPlainMessage("Not yet")}, resp)
+		require.False(t, prt.IsProcessing())
+	})
+
+	t.Run("should start flow inside window", func(t *testing.T) {
+		prt := bots.MustNewParticipant(botUUID, rand.Int64())
+
+		resp, err := bot.EntryAt(prt, "start", opensAt.Add(time.Hour))
+		require.NoError(t, err)
+		requireMessages(t, []bots.Message{bots.MustNewPlainMessage(greetingBlock.Text)}, resp)
+		require.Equal(t, 1, prt.State)
+	})
+
+	t.Run("should not start flow after window closes", func(t *testing.T) {
+		prt := bots.MustNewParticipant(botUUID, rand.Int64())
+
+		resp, err := bot.EntryAt(prt, "start", closesAt)
+		require.NoError(t, err)
+		requireMessages(t, []bots.Message{bots.MustNewPlainMessage("Too late")}, resp)
+		require.False(t, bot.IsEntryOpen("start", closesAt))
+	})
+}
@@ -0,0 +1,183 @@
+package infra
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/bmstu-itstech/itsreg-bots/internal/domain/bots"
+)
+
+const tokenKeySize = 32
+
+type aesTokenCipher struct {
+	activeKeyID string
+	keys        map[string]cipher.AEAD
+}
+
+func NewAESTokenCipher() bots.TokenCipher {
+	c, err := NewAESTokenCipherFromKeys(os.Getenv("TOKEN_ENCRYPTION_KEYS"))
+	if err != nil {
+		panic(err)
+	}
+	return c
+}
+
+// NewAESTokenCipherFromKeys parses comma-separated "id:base64" keys. The first key
+// encrypts new tokens, the rest are only used to open tokens sealed before rotation.
+func NewAESTokenCipherFromKeys(keys string) (bots.TokenCipher, error) {
+	c := &aesTokenCipher{keys: make(map[string]cipher.AEAD)}
+
+	for _, entry := range strings.Split(keys, ",") {
+		entry = strings.TrimSpace(entry)
+		if entry == "" {
+			continue
+		}
+
+		id, encoded, ok := strings.Cut(entry, ":")
+		if !ok || id == "" {
+			return nil, fmt.Errorf("invalid token encryption key %q: expected id:base64", id)
+		}
+
+		if _, ok = c.keys[id]; ok {
+			return nil, fmt.Errorf("duplicate token encryption key %q", id)
+		}
+
+		key, err := base64.StdEncoding.DecodeString(encoded)
+		if err != nil {
+			return nil, fmt.Errorf("invalid token encryption key %q: %w", id, err)
+		}
+
+		if len(key) != tokenKeySize {
+			return nil, fmt.Errorf("invalid token encryption key %q: expected %d bytes, got %d", id, tokenKeySize, len(key))
+		}
+
+		c.keys[id], err = newAEAD(key)
+		if err != nil {
+			return nil, err
+		}
+
+		if c.activeKeyID == "" {
+			c.activeKeyID = id
+		}
+	}
+
+	if c.activeKeyID == "" {
+		return nil, errors.New("no token encryption keys configured")
+	}
+
+	return c, nil
+}
+
+func (c *aesTokenCipher) Seal(token string) (bots.SealedToken, error) {
+	dataKey := make([]byte, tokenKeySize)
+	if _, err := io.ReadFull(rand.Reader, dataKey); err != nil {
+		return bots.SealedToken{}, err
+	}
+
+	dataAEAD, err := newAEAD(dataKey)
+	if err != nil {
+		return bots.SealedToken{}, err
+	}
+
+	ciphertext, err := sealAEAD(dataAEAD, []byte(token), nil)
+	if err != nil {
+		return bots.SealedToken{}, err
+	}
+
+	encryptedKey, err := sealAEAD(c.keys[c.activeKeyID], dataKey, []byte(c.activeKeyID))
+	if err != nil {
+		return bots.SealedToken{}, err
+	}
+
+	return bots.SealedToken{
+		KeyID:        c.activeKeyID,
+		EncryptedKey: encryptedKey,
+		Ciphertext:   ciphertext,
+	}, nil
+}
+
+func (c *aesTokenCipher) Open(sealed bots.SealedToken) (string, error) {
+	dataKey, err := c.unwrap(sealed)
+	if err != nil {
+		return "", err
+	}
+
+	dataAEAD, err := newAEAD(dataKey)
+	if err != nil {
+		return "", err
+	}
+
+	token, err := openAEAD(dataAEAD, sealed.Ciphertext, nil)
+	if err != nil {
+		return "", fmt.Errorf("failed to decrypt token: %w", err)
+	}
+
+	return string(token), nil
+}
+
+func (c *aesTokenCipher) Rotate(sealed bots.SealedToken) (bots.SealedToken, error) {
+	if sealed.KeyID == c.activeKeyID {
+		return sealed, nil
+	}
+
+	dataKey, err := c.unwrap(sealed)
+	if err != nil {
+		return bots.SealedToken{}, err
+	}
+
+	encryptedKey, err := sealAEAD(c.keys[c.activeKeyID], dataKey, []byte(c.activeKeyID))
+	if err != nil {
+		return bots.SealedToken{}, err
+	}
+
+	return bots.SealedToken{
+		KeyID:        c.activeKeyID,
+		EncryptedKey: encryptedKey,
+		Ciphertext:   sealed.Ciphertext,
+	}, nil
+}
+
+func (c *aesTokenCipher) unwrap(sealed bots.SealedToken) ([]byte, error) {
+	keyAEAD, ok := c.keys[sealed.KeyID]
+	if !ok {
+		return nil, fmt.Errorf("unknown token encryption key %q", sealed.KeyID)
+	}
+
+	dataKey, err := openAEAD(keyAEAD, sealed.EncryptedKey, []byte(sealed.KeyID))
+	if err != nil {
+		return nil, fmt.Errorf("failed to decrypt token key: %w", err)
+	}
+
+	return dataKey, nil
+}
+
+func newAEAD(key []byte) (cipher.AEAD, error) {
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, err
+	}
+	return cipher.NewGCM(block)
+}
+
+func sealAEAD(aead cipher.AEAD, plaintext []byte, additionalData []byte) ([]byte, error) {
+	nonce := make([]byte, aead.NonceSize())
+	if _, err := io.ReadFull(rand.Reader, nonce); err != nil {
+		return nil, err
+	}
+	return aead.Seal(nonce, nonce, plaintext, additionalData), nil
+}
+
+func openAEAD(aead cipher.AEAD, ciphertext []byte, additionalData []byte) ([]byte, error) {
+	if len(ciphertext) < aead.NonceSize() {
+		return nil, errors.New("ciphertext too short")
+	}
+	nonce, ciphertext := ciphertext[:aead.NonceSize()], ciphertext[aead.NonceSize():]
+	return aead.Open(nil, nonce, ciphertext, additionalData)
+}
@@ -0,0 +1,70 @@
+package infra_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/bmstu-itstech/itsreg-bots/internal/infra"
+)
+
+func TestAESTokenCipher(t *testing.T) {
+	const token = "12345678:XXXXXXXXXXXXXXXXXXXXXXXXXXXXXXXXXXX"
+
+	cipher, err := infra.NewAESTokenCipherFromKeys(testTokenKeys)
+	require.NoError(t, err)
+
+	t.Run("should seal and open token", func(t *testing.T) {
+		sealed, err := cipher.Seal(token)
+		require.NoError(t, err)
+		require.Equal(t, "test", sealed.KeyID)
+		require.NotContains(t, string(sealed.Ciphertext), token)
+
+		opened, err := cipher.Open(sealed)
+		require.NoError(t, err)
+		require.Equal(t, token, opened)
+	})
+
+	t.Run("should rotate token to active key", func(t *testing.T) {
+		sealed, err := cipher.Seal(token)
+		require.NoError(t, err)
+
+		rotatedCipher, err := infra.NewAESTokenCipherFromKeys(testRotatedTokenKeys)
+		require.NoError(t, err)
+
+		rotated, err := rotatedCipher.Rotate(sealed)
+		require.NoError(t, err)
+		require.Equal(t, "rotated", rotated.KeyID)
+		require.Equal(t, sealed.Ciphertext, rotated.Ciphertext)
+
+		newOnly, err := infra.NewAESTokenCipherFromKeys("rotated:ZmVkY2JhOTg3NjU0MzIxMGZlZGNiYTk4NzY1NDMyMTA=")
+		require.NoError(t, err)
+
+		opened, err := newOnly.Open(rotated)
+		require.NoError(t, err)
+		require.Equal(t, token, opened)
+
+		_, err = newOnly.Open(sealed)
+		require.Error(t, err)
+	})
+
+	t.Run("should reject tampered token", func(t *testing.T) {
+		sealed, err := cipher.Seal(token)
+		require.NoError(t, err)
+
+		sealed.Ciphertext[len(sealed.Ciphertext)-1] ^= 0xff
+		_, err = cipher.Open(sealed)
+		require.Error(t, err)
+	})
+
+	t.Run("should reject invalid keys", func(t *testing.T) {
+		_, err := infra.NewAESTokenCipherFromKeys("")
+		require.Error(t, err)
+
+		_, err = infra.NewAESTokenCipherFromKeys("short:c2hvcnQ=")
+		require.Error(t, err)
+
+		_, err = infra.NewAESTokenCipherFromKeys("no-separator")
+		require.Error(t, err)
+	})
+}
@@ -63,6 +63,11 @@ type ampqBotMessage struct {
 	UserID  int64    `json:"user_id"`
 	Text    string   `json:"text"`
 	Buttons []string `json:"buttons"`
+	Ticket  string   `json:"ticket,omitempty"`
+
+	AttachmentType   string `json:"attachment_type,omitempty"`
+	AttachmentFileID string `json:"attachment_file_id,omitempty"`
+	Format           string `json:"format,omitempty"`
 }
 
 func mapBotMessageToAMPQ(botUUID string, userID int64, msg bots.Message) ampqBotMessage {
@@ -71,5 +76,10 @@ func mapBotMessageToAMPQ(botUUID string, userID int64, msg bots.Message) ampqBot
 		UserID:  userID,
 		Text:    msg.Text,
 		Buttons: msg.Buttons,
+		Ticket:  msg.Ticket,
+
+		AttachmentType:   msg.Attachment.Type.String(),
+		AttachmentFileID: msg.Attachment.FileID,
+		Format:           msg.Format.String(),
 	}
 }
@@ -3,6 +3,7 @@ package infra_test
 import (
 	"context"
 	"fmt"
+	"maps"
 	"os"
 	"slices"
 	"strings"
@@ -29,10 +30,185 @@ func TestPgBotsRepository(t *testing.T) {
 		require.NoError(t, err)
 	})
 
-	repos := infra.NewPgBotsRepository(db)
+	cipher, err := infra.NewAESTokenCipherFromKeys(testTokenKeys)
+	require.NoError(t, err)
+
+	repos := infra.NewPgBotsRepository(db, cipher)
 	testBotsRepository(t, repos)
 }
 
+const (
+	testTokenKeys        = "test:MDEyMzQ1Njc4OWFiY2RlZjAxMjM0NTY3ODlhYmNkZWY=,rotated:ZmVkY2JhOTg3NjU0MzIxMGZlZGNiYTk4NzY1NDMyMTA="
+	testRotatedTokenKeys = "rotated:ZmVkY2JhOTg3NjU0MzIxMGZlZGNiYTk4NzY1NDMyMTA=,test:MDEyMzQ1Njc4OWFiY2RlZjAxMjM0NTY3ODlhYmNkZWY="
+)
+
+func TestPgBotsRepository_TokenEncryption(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping integration test in short mode")
+	}
+
+	url := os.Getenv("DATABASE_URI")
+	db := sqlx.MustConnect("postgres", url)
+	t.Cleanup(func() {
+		err := db.Close()
+		require.NoError(t, err)
+	})
+
+	cipher, err := infra.NewAESTokenCipherFromKeys(testTokenKeys)
+	require.NoError(t, err)
+	repos := infra.NewPgBotsRepository(db, cipher)
+
+	t.Run("should store token encrypted", func(t *testing.T) {
+		ctx := context.Background()
+
+		bot := createBot(gofakeit.UUID())
+		err := repos.UpdateOrCreate(ctx, bot)
+		require.NoError(t, err)
+
+		var stored struct {
+			Token      *string `db:"token"`
+			Ciphertext []byte  `db:"token_ciphertext"`
+		}
+		err = db.GetContext(ctx, &stored, `SELECT token, token_ciphertext FROM bots WHERE uuid = $1`, bot.UUID)
+		require.NoError(t, err)
+		require.Nil(t, stored.Token)
+		require.NotContains(t, string(stored.Ciphertext), bot.Token)
+
+		got, err := repos.Bot(ctx, bot.UUID)
+		require.NoError(t, err)
+		require.Empty(t, got.Token)
+		require.Equal(t, bots.MaskToken(bot.Token), got.TokenMask)
+
+		token, err := cipher.Open(got.SealedToken)
+		require.NoError(t, err)
+		require.Equal(t, bot.Token, token)
+	})
+
+	t.Run("should re-encrypt tokens with active key", func(t *testing.T) {
+		ctx := context.Background()
+
+		bot := createBot(gofakeit.UUID())
+		err := repos.UpdateOrCreate(ctx, bot)
+		require.NoError(t, err)
+
+		_, err = db.ExecContext(ctx,
+			`UPDATE bots SET token = $2, token_ciphertext = NULL, token_key = NULL, token_key_id = NULL WHERE uuid = $1`,
+			bot.UUID, bot.Token,
+		)
+		require.NoError(t, err)
+
+		rotated, err := infra.NewAESTokenCipherFromKeys(testRotatedTokenKeys)
+		require.NoError(t, err)
+
+		updated, err := infra.ReencryptBotTokens(ctx, db, rotated)
+		require.NoError(t, err)
+		require.Positive(t, updated)
+
+		got, err := infra.NewPgBotsRepository(db, rotated).Bot(ctx, bot.UUID)
+		require.NoError(t, err)
+		require.Equal(t, "rotated", got.SealedToken.KeyID)
+
+		token, err := cipher.Open(got.SealedToken)
+		require.NoError(t, err)
+		require.Equal(t, bot.Token, token)
+	})
+
+	t.Run("should decrypt tokens back to plaintext", func(t *testing.T) {
+		ctx := context.Background()
+
+		bot := createBot(gofakeit.UUID())
+		err := repos.UpdateOrCreate(ctx, bot)
+		require.NoError(t, err)
+
+		updated, err := infra.DecryptBotTokens(ctx, db, cipher)
+		require.NoError(t, err)
+		require.Positive(t, updated)
+
+		var stored struct {
+			Token      *string `db:"token"`
+			Ciphertext []byte  `db:"token_ciphertext"`
+		}
+		err = db.GetContext(ctx, &stored, `SELECT token, token_ciphertext FROM bots WHERE uuid = $1`, bot.UUID)
+		require.NoError(t, err)
+		require.NotNil(t, stored.Token)
+		require.Equal(t, bot.Token, *stored.Token)
+		require.Nil(t, stored.Ciphertext)
+
+		got, err := repos.Bot(ctx, bot.UUID)
+		require.NoError(t, err)
+
+		token, err := cipher.Open(got.SealedToken)
+		require.NoError(t, err)
+		require.Equal(t, bot.Token, token)
+	})
+}
+
+func TestPgBotsRepository_Edit(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping integration test in short mode")
+	}
+
+	url := os.Getenv("DATABASE_URI")
+	db := sqlx.MustConnect("postgres", url)
+	t.Cleanup(func() {
+		err := db.Close()
+		require.NoError(t, err)
+	})
+
+	cipher, err := infra.NewAESTokenCipherFromKeys(testTokenKeys)
+	require.NoError(t, err)
+	repos := infra.NewPgBotsRepository(db, cipher)
+
+	ctx := context.Background()
+	ownerUUID := gofakeit.UUID()
+	bot := createBot(ownerUUID)
+	require.NoError(t, repos.UpdateOrCreate(ctx, bot))
+
+	webhook := bots.MustNewWebhook(gofakeit.UUID(), bot.UUID, "https://example.com/hook", "secret", []string{"answer.given"})
+	require.NoError(t, infra.NewPgWebhooksRepository(db).Create(ctx, webhook))
+
+	leases := infra.NewPgLeasesRepository(db)
+	instanceID := gofakeit.UUID()
+	lease, err := bots.NewLease(bot.UUID, instanceID, time.Now().Add(bots.LeaseTTL))
+	require.NoError(t, err)
+	require.NoError(t, leases.Acquire(ctx, lease))
+
+	seatsRepos := infra.NewPgSeatsRepository(db)
+	capacity := bots.MustNewCapacity(10, 2, 0, 0)
+	require.NoError(t, seatsRepos.UpdateSeats(ctx, bot.UUID, "start", func(_ context.Context, seats *bots.Seats) error {
+		seats.Take(capacity, 1)
+		return nil
+	}))
+
+	edited := createBotWithUUID(bot.UUID, ownerUUID)
+	require.NoError(t, repos.UpdateOrCreate(ctx, edited))
+
+	t.Run("should save edited bot", func(t *testing.T) {
+		got, err := repos.Bot(ctx, bot.UUID)
+		require.NoError(t, err)
+		requireBot(t, *edited, *got)
+	})
+
+	t.Run("should keep webhooks", func(t *testing.T) {
+		webhooks, err := infra.NewPgWebhooksRepository(db).BotWebhooks(ctx, bot.UUID)
+		require.NoError(t, err)
+		require.Len(t, webhooks, 1)
+		require.Equal(t, webhook.UUID, webhooks[0].UUID)
+	})
+
+	t.Run("should keep lease", func(t *testing.T) {
+		owner, err := leases.Owner(ctx, bot.UUID)
+		require.NoError(t, err)
+		require.Equal(t, instanceID, owner)
+	})
+
+	t.Run("should keep seats", func(t *testing.T) {
+		seats, err := seatsRepos.Seats(ctx, bot.UUID, "start")
+		require.NoError(t, err)
+		require.Equal(t, []int64{1}, seats.Registered())
+	})
+}
+
 func testBotsRepository(t *testing.T, repos bots.Repository) {
 	t.Parallel()
 
@@ -175,6 +351,28 @@ func testBotsRepository(t *testing.T, repos bots.Repository) {
 		require.Empty(t, bs)
 	})
 
+	t.Run("should save bot members", func(t *testing.T) {
+		t.Parallel()
+
+		ctx := context.Background()
+
+		bot := createBot(gofakeit.UUID())
+		require.NoError(t, repos.UpdateOrCreate(ctx, bot))
+
+		editorUUID := gofakeit.UUID()
+		require.NoError(t, repos.Update(ctx, bot.UUID, func(innerCtx context.Context, bot *bots.Bot) error {
+			return bot.SetMember(bots.MustNewMember(editorUUID, "editor"))
+		}))
+
+		got, err := repos.Bot(ctx, bot.UUID)
+		require.NoError(t, err)
+		require.NoError(t, got.CanEditBot(editorUUID))
+
+		bs, err := repos.UserBots(ctx, editorUUID)
+		require.NoError(t, err)
+		require.Len(t, bs, 1)
+	})
+
 	t.Run("should update status", func(t *testing.T) {
 		t.Parallel()
 
@@ -191,11 +389,81 @@ func testBotsRepository(t *testing.T, repos bots.Repository) {
 		require.NoError(t, err)
 		require.Equal(t, bots.Started, got.Status)
 	})
+
+	t.Run("should mark bot as failed", func(t *testing.T) {
+		t.Parallel()
+
+		ctx := context.Background()
+
+		bot := createBot(gofakeit.UUID())
+		err := repos.UpdateOrCreate(ctx, bot)
+		require.NoError(t, err)
+
+		failedAt := time.Now().Truncate(time.Second)
+		err = repos.MarkFailed(ctx, bot.UUID, "Unauthorized", failedAt)
+		require.NoError(t, err)
+
+		got, err := repos.Bot(ctx, bot.UUID)
+		require.NoError(t, err)
+		require.Equal(t, bots.Failed, got.Status)
+		require.Equal(t, "Unauthorized", got.LastError)
+		require.True(t, failedAt.Equal(got.FailedAt))
+
+		err = repos.UpdateOrCreate(ctx, got)
+		require.NoError(t, err)
+
+		got, err = repos.Bot(ctx, bot.UUID)
+		require.NoError(t, err)
+		require.Equal(t, "Unauthorized", got.LastError)
+	})
+
+	t.Run("should return error when marking non-existent bot as failed", func(t *testing.T) {
+		t.Parallel()
+
+		err := repos.MarkFailed(context.Background(), gofakeit.UUID(), "Unauthorized", time.Now())
+		require.ErrorAs(t, err, &bots.BotNotFoundError{})
+	})
+
+	t.Run("should save telegram identity", func(t *testing.T) {
+		t.Parallel()
+
+		ctx := context.Background()
+
+		bot := createBot(gofakeit.UUID())
+		bot.SetTelegramIdentity(bots.TelegramIdentity{ID: gofakeit.Int64(), Username: gofakeit.Username()})
+		err := repos.UpdateOrCreate(ctx, bot)
+		require.NoError(t, err)
+
+		got, err := repos.Bot(ctx, bot.UUID)
+		require.NoError(t, err)
+		requireBot(t, *bot, *got)
+	})
+
+	t.Run("should return error if telegram bot is used by another bot", func(t *testing.T) {
+		t.Parallel()
+
+		ctx := context.Background()
+		identity := bots.TelegramIdentity{ID: gofakeit.Int64(), Username: gofakeit.Username()}
+
+		bot := createBot(gofakeit.UUID())
+		bot.SetTelegramIdentity(identity)
+		err := repos.UpdateOrCreate(ctx, bot)
+		require.NoError(t, err)
+
+		other := createBot(gofakeit.UUID())
+		other.SetTelegramIdentity(identity)
+		err = repos.UpdateOrCreate(ctx, other)
+		require.ErrorIs(t, err, bots.ErrTokenInUse)
+	})
 }
 
 func createBot(ownerUUID string) *bots.Bot {
+	return createBotWithUUID(gofakeit.UUID(), ownerUUID)
+}
+
+func createBotWithUUID(botUUID string, ownerUUID string) *bots.Bot {
 	return bots.MustNewBot(
-		gofakeit.UUID(),
+		botUUID,
 		ownerUUID,
 		[]bots.EntryPoint{
 			bots.MustNewEntryPoint("start", 1),
@@ -237,7 +505,9 @@ func equalOptions(a, b []bots.Option) bool {
 	slices.SortFunc(b, optionComparator)
 
 	for i := range a {
-		if a[i] != b[i] {
+		if a[i].Text != b[i].Text || a[i].Value != b[i].Value || a[i].ID != b[i].ID || a[i].Next != b[i].Next ||
+			a[i].Locale != b[i].Locale || !slices.Equal(a[i].Aliases, b[i].Aliases) ||
+			!maps.Equal(a[i].Translations, b[i].Translations) {
 			return false
 		}
 	}
@@ -333,7 +603,9 @@ func equalBots(a, b bots.Bot) bool {
 	return a.UUID == b.UUID &&
 		a.OwnerUUID == b.OwnerUUID &&
 		a.Name == b.Name &&
-		a.Token == b.Token &&
+		a.TokenMask == b.TokenMask &&
+		a.TelegramID == b.TelegramID &&
+		a.Username == b.Username &&
 		a.CreatedAt.Sub(b.CreatedAt).Abs() < time.Microsecond &&
 		a.UpdatedAt.Sub(b.UpdatedAt).Abs() < time.Microsecond &&
 		equalEntriesSlices(a.Entries(), b.Entries()) &&
@@ -0,0 +1,208 @@
+package infra_test
+
+import (
+	"context"
+	"math/rand/v2"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/brianvoe/gofakeit/v6"
+	"github.com/jmoiron/sqlx"
+	"github.com/stretchr/testify/require"
+	"github.com/zhikh23/pgutils"
+
+	"github.com/bmstu-itstech/itsreg-bots/internal/domain/bots"
+	"github.com/bmstu-itstech/itsreg-bots/internal/infra"
+)
+
+func TestNATSEventStream(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping integration test in short mode")
+	}
+
+	url := os.Getenv("DATABASE_URI")
+	db := sqlx.MustConnect("postgres", url)
+	t.Cleanup(func() {
+		err := db.Close()
+		require.NoError(t, err)
+	})
+
+	botUUID := gofakeit.UUID()
+	_, err := pgutils.Exec(context.Background(), db,
+		`INSERT INTO 
+			bots (uuid, name, token, status, created_at, updated_at) 
+		VALUES 
+			($1, $2, $3, $4, $5, $6)`,
+		botUUID, gofakeit.Name(), gofakeit.UUID(), "stopped", time.Now(), time.Now(),
+	)
+	require.NoError(t, err)
+
+	pub, sub, closeFn := infra.NewNATSEventStream(db)
+	t.Cleanup(func() {
+		err := closeFn()
+		require.NoError(t, err)
+	})
+
+	testEventStream(t, botUUID, pub, sub)
+}
+
+func testEventStream(t *testing.T, botUUID string, pub bots.EventPublisher, sub bots.EventSubscriber) {
+	userID := rand.Int64()
+
+	t.Run("should receive published event", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+
+		events, err := sub.Subscribe(ctx, botUUID, 0)
+		require.NoError(t, err)
+
+		err = pub.Publish(ctx, bots.NewParticipantStartedEvent(botUUID, userID, "start"))
+		require.NoError(t, err)
+
+		event := requireEvent(t, events)
+		require.Equal(t, bots.ParticipantStartedEvent, event.Type)
+		require.Equal(t, userID, event.UserID)
+		require.Equal(t, "start", event.EntryKey)
+		require.NotZero(t, event.ID)
+	})
+
+	t.Run("should replay events after last event id", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+
+		events, err := sub.Subscribe(ctx, botUUID, 0)
+		require.NoError(t, err)
+
+		err = pub.Publish(ctx, bots.NewAnswerGivenEvent(botUUID, userID, bots.MustNewAnswer(1, "Ivan")))
+		require.NoError(t, err)
+		first := requireEvent(t, events)
+
+		err = pub.Publish(ctx, bots.NewFlowFinishedEvent(botUUID, userID))
+		require.NoError(t, err)
+		second := requireEvent(t, events)
+		cancel()
+
+		ctx, cancel = context.WithCancel(context.Background())
+		defer cancel()
+
+		replayed, err := sub.Subscribe(ctx, botUUID, first.ID)
+		require.NoError(t, err)
+
+		event := requireEvent(t, replayed)
+		require.Equal(t, second.ID, event.ID)
+		require.Equal(t, bots.FlowFinishedEvent, event.Type)
+	})
+}
+
+func TestNATSEventStream_ReplayPages(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping integration test in short mode")
+	}
+
+	url := os.Getenv("DATABASE_URI")
+	db := sqlx.MustConnect("postgres", url)
+	t.Cleanup(func() {
+		err := db.Close()
+		require.NoError(t, err)
+	})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	botUUID := gofakeit.UUID()
+	_, err := pgutils.Exec(ctx, db,
+		`INSERT INTO 
+			bots (uuid, name, token, status, created_at, updated_at) 
+		VALUES 
+			($1, $2, $3, $4, $5, $6)`,
+		botUUID, gofakeit.Name(), gofakeit.UUID(), "stopped", time.Now(), time.Now(),
+	)
+	require.NoError(t, err)
+
+	pub, sub, closeFn := infra.NewNATSEventStream(db)
+	t.Cleanup(func() {
+		err := closeFn()
+		require.NoError(t, err)
+	})
+
+	events, err := sub.Subscribe(ctx, botUUID, 0)
+	require.NoError(t, err)
+	require.NoError(t, pub.Publish(ctx, bots.NewFlowFinishedEvent(botUUID, rand.Int64())))
+	first := requireEvent(t, events)
+
+	const missed = 2500
+	_, err = pgutils.Exec(ctx, db,
+		`INSERT INTO bot_events (bot_uuid, type, user_id, occurred_at)
+		 SELECT $1, 'flow.finished', n, now()
+		 FROM   generate_series(1, $2) AS n`,
+		botUUID, missed,
+	)
+	require.NoError(t, err)
+
+	replayed, err := sub.Subscribe(ctx, botUUID, first.ID)
+	require.NoError(t, err)
+
+	last := first.ID
+	for range missed {
+		event := requireEvent(t, replayed)
+		require.Greater(t, event.ID, last)
+		last = event.ID
+	}
+}
+
+func requireEvent(t *testing.T, events <-chan bots.Event) bots.Event {
+	select {
+	case event, ok := <-events:
+		require.True(t, ok)
+		return event
+	case <-time.After(5 * time.Second):
+		require.FailNow(t, "timeout waiting for event")
+	}
+	return bots.Event{}
+}
+
+func TestPruneBotEvents(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping integration test in short mode")
+	}
+
+	url := os.Getenv("DATABASE_URI")
+	db := sqlx.MustConnect("postgres", url)
+	t.Cleanup(func() {
+		err := db.Close()
+		require.NoError(t, err)
+	})
+
+	ctx := context.Background()
+
+	botUUID := gofakeit.UUID()
+	_, err := pgutils.Exec(ctx, db,
+		`INSERT INTO 
+			bots (uuid, name, token, status, created_at, updated_at) 
+		VALUES 
+			($1, $2, $3, $4, $5, $6)`,
+		botUUID, gofakeit.Name(), gofakeit.UUID(), "stopped", time.Now(), time.Now(),
+	)
+	require.NoError(t, err)
+
+	now := time.Now().UTC()
+	_, err = pgutils.Exec(ctx, db,
+		`INSERT INTO bot_events (bot_uuid, type, occurred_at)
+		 VALUES ($1, 'flow.finished', $2), ($1, 'flow.finished', $3)`,
+		botUUID, now.Add(-48*time.Hour), now,
+	)
+	require.NoError(t, err)
+
+	_, err = infra.PruneBotEvents(ctx, db, now.Add(-24*time.Hour))
+	require.NoError(t, err)
+
+	var occurred []time.Time
+	err = pgutils.Select(ctx, db, &occurred,
+		`SELECT occurred_at FROM bot_events WHERE bot_uuid = $1`,
+		botUUID,
+	)
+	require.NoError(t, err)
+	require.Len(t, occurred, 1)
+	require.WithinDuration(t, now, occurred[0], time.Second)
+}
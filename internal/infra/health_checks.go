@@ -0,0 +1,43 @@
+package infra
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/jmoiron/sqlx"
+	nc "github.com/nats-io/nats.go"
+
+	"github.com/bmstu-itstech/itsreg-bots/internal/common/health"
+)
+
+func NewPgHealthCheck(db *sqlx.DB) health.Check {
+	return db.PingContext
+}
+
+func NewNATSHealthCheck() (health.Check, func() error) {
+	uri := os.Getenv("NATS_URI")
+	if uri == "" {
+		panic("NATS_URI environment variable not set")
+	}
+
+	conn, err := nc.Connect(uri,
+		nc.RetryOnFailedConnect(true),
+		nc.MaxReconnects(-1),
+		nc.ReconnectWait(1*time.Second),
+	)
+	if err != nil {
+		panic(err)
+	}
+
+	return func(_ context.Context) error {
+			if status := conn.Status(); status != nc.CONNECTED {
+				return fmt.Errorf("nats connection is %s", status)
+			}
+			return nil
+		}, func() error {
+			conn.Close()
+			return nil
+		}
+}
@@ -0,0 +1,152 @@
+package infra_test
+
+import (
+	"context"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/brianvoe/gofakeit/v6"
+	"github.com/jmoiron/sqlx"
+	"github.com/stretchr/testify/require"
+
+	"github.com/bmstu-itstech/itsreg-bots/internal/domain/bots"
+	"github.com/bmstu-itstech/itsreg-bots/internal/infra"
+)
+
+func TestPgLeasesRepository(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping integration test in short mode")
+	}
+
+	url := os.Getenv("DATABASE_URI")
+	db := sqlx.MustConnect("postgres", url)
+	t.Cleanup(func() {
+		err := db.Close()
+		require.NoError(t, err)
+	})
+
+	cipher, err := infra.NewAESTokenCipherFromKeys(testTokenKeys)
+	require.NoError(t, err)
+
+	botsRepos := infra.NewPgBotsRepository(db, cipher)
+	leases := infra.NewPgLeasesRepository(db)
+
+	createLeasedBot := func(t *testing.T) string {
+		bot := createBot(gofakeit.UUID())
+		err := botsRepos.UpdateOrCreate(context.Background(), bot)
+		require.NoError(t, err)
+		return bot.UUID
+	}
+
+	newLease := func(t *testing.T, botUUID string, instanceID string, ttl time.Duration) bots.Lease {
+		lease, err := bots.NewLease(botUUID, instanceID, time.Now().Add(ttl))
+		require.NoError(t, err)
+		return lease
+	}
+
+	t.Run("should acquire unowned bot", func(t *testing.T) {
+		t.Parallel()
+
+		ctx := context.Background()
+		botUUID := createLeasedBot(t)
+		instanceID := gofakeit.UUID()
+
+		err := leases.Acquire(ctx, newLease(t, botUUID, instanceID, bots.LeaseTTL))
+		require.NoError(t, err)
+
+		owner, err := leases.Owner(ctx, botUUID)
+		require.NoError(t, err)
+		require.Equal(t, instanceID, owner)
+	})
+
+	t.Run("should not acquire bot owned by another instance", func(t *testing.T) {
+		t.Parallel()
+
+		ctx := context.Background()
+		botUUID := createLeasedBot(t)
+
+		err := leases.Acquire(ctx, newLease(t, botUUID, gofakeit.UUID(), bots.LeaseTTL))
+		require.NoError(t, err)
+
+		err = leases.Acquire(ctx, newLease(t, botUUID, gofakeit.UUID(), bots.LeaseTTL))
+		require.ErrorIs(t, err, bots.ErrLeaseHeld)
+	})
+
+	t.Run("should take over expired lease", func(t *testing.T) {
+		t.Parallel()
+
+		ctx := context.Background()
+		botUUID := createLeasedBot(t)
+		dead := gofakeit.UUID()
+		alive := gofakeit.UUID()
+
+		err := leases.Acquire(ctx, newLease(t, botUUID, dead, time.Millisecond))
+		require.NoError(t, err)
+		time.Sleep(10 * time.Millisecond)
+
+		owner, err := leases.Owner(ctx, botUUID)
+		require.NoError(t, err)
+		require.Empty(t, owner)
+
+		err = leases.Renew(ctx, dead, time.Now().Add(bots.LeaseTTL))
+		require.NoError(t, err)
+
+		err = leases.Acquire(ctx, newLease(t, botUUID, alive, bots.LeaseTTL))
+		require.NoError(t, err)
+
+		owner, err = leases.Owner(ctx, botUUID)
+		require.NoError(t, err)
+		require.Equal(t, alive, owner)
+	})
+
+	t.Run("should renew and release leases", func(t *testing.T) {
+		t.Parallel()
+
+		ctx := context.Background()
+		botUUID := createLeasedBot(t)
+		instanceID := gofakeit.UUID()
+
+		err := leases.Acquire(ctx, newLease(t, botUUID, instanceID, time.Second))
+		require.NoError(t, err)
+
+		err = leases.Renew(ctx, instanceID, time.Now().Add(bots.LeaseTTL))
+		require.NoError(t, err)
+
+		all, err := leases.Leases(ctx)
+		require.NoError(t, err)
+		require.Contains(t, leaseOwners(all), botUUID)
+		require.True(t, all[indexOfLease(all, botUUID)].ExpiresAt.After(time.Now().Add(bots.LeaseTTL/2)))
+
+		err = leases.Release(ctx, botUUID, gofakeit.UUID())
+		require.NoError(t, err)
+
+		owner, err := leases.Owner(ctx, botUUID)
+		require.NoError(t, err)
+		require.Equal(t, instanceID, owner)
+
+		err = leases.Release(ctx, botUUID, instanceID)
+		require.NoError(t, err)
+
+		owner, err = leases.Owner(ctx, botUUID)
+		require.NoError(t, err)
+		require.Empty(t, owner)
+	})
+}
+
+func leaseOwners(leases []bots.Lease) map[string]string {
+	res := make(map[string]string, len(leases))
+	for _, lease := range leases {
+		res[lease.BotUUID] = lease.InstanceID
+	}
+	return res
+}
+
+func indexOfLease(leases []bots.Lease, botUUID string) int {
+	for i, lease := range leases {
+		if lease.BotUUID == botUUID {
+			return i
+		}
+	}
+	return -1
+}
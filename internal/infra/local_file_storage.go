@@ -0,0 +1,148 @@
+package infra
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+
+	"github.com/bmstu-itstech/itsreg-bots/internal/domain/bots"
+)
+
+const defaultFilesDir = "files"
+
+type localFileStorage struct {
+	dir string
+}
+
+func NewLocalFileStorage() bots.FileStorage {
+	dir := os.Getenv("FILES_DIR")
+	if dir == "" {
+		dir = defaultFilesDir
+	}
+	return NewLocalFileStorageAt(dir)
+}
+
+func NewLocalFileStorageAt(dir string) bots.FileStorage {
+	return &localFileStorage{
+		dir: dir,
+	}
+}
+
+func (s *localFileStorage) Save(_ context.Context, file bots.File, content io.Reader) (bots.File, error) {
+	path, err := s.path(file.BotUUID, file.ID)
+	if err != nil {
+		return bots.File{}, err
+	}
+
+	if err = os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return bots.File{}, err
+	}
+
+	tmp, err := os.CreateTemp(filepath.Dir(path), file.ID+".*.tmp")
+	if err != nil {
+		return bots.File{}, err
+	}
+	defer os.Remove(tmp.Name())
+
+	size, err := io.Copy(tmp, content)
+	if err != nil {
+		_ = tmp.Close()
+		return bots.File{}, err
+	}
+	if err = tmp.Close(); err != nil {
+		return bots.File{}, err
+	}
+	file.Size = size
+
+	meta, err := json.Marshal(mapFileToMeta(file))
+	if err != nil {
+		return bots.File{}, err
+	}
+	if err = os.WriteFile(path+".json", meta, 0o644); err != nil {
+		return bots.File{}, err
+	}
+
+	if err = os.Rename(tmp.Name(), path); err != nil {
+		return bots.File{}, err
+	}
+
+	return file, nil
+}
+
+func (s *localFileStorage) Open(_ context.Context, botUUID string, id string) (bots.File, io.ReadCloser, error) {
+	path, err := s.path(botUUID, id)
+	if err != nil {
+		return bots.File{}, nil, err
+	}
+
+	raw, err := os.ReadFile(path + ".json")
+	if errors.Is(err, fs.ErrNotExist) {
+		return bots.File{}, nil, bots.FileNotFoundError{BotUUID: botUUID, ID: id}
+	} else if err != nil {
+		return bots.File{}, nil, err
+	}
+
+	var meta fileMeta
+	if err = json.Unmarshal(raw, &meta); err != nil {
+		return bots.File{}, nil, err
+	}
+
+	file, err := bots.NewFile(id, botUUID, meta.Name, meta.MimeType, meta.Size)
+	if err != nil {
+		return bots.File{}, nil, err
+	}
+
+	content, err := os.Open(path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return bots.File{}, nil, bots.FileNotFoundError{BotUUID: botUUID, ID: id}
+	} else if err != nil {
+		return bots.File{}, nil, err
+	}
+
+	return file, content, nil
+}
+
+func (s *localFileStorage) Delete(_ context.Context, botUUID string, id string) error {
+	path, err := s.path(botUUID, id)
+	if err != nil {
+		return err
+	}
+
+	for _, name := range []string{path, path + ".json"} {
+		err = os.Remove(name)
+		if err != nil && !errors.Is(err, fs.ErrNotExist) {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (s *localFileStorage) path(botUUID string, id string) (string, error) {
+	if !isPlainName(botUUID) || !isPlainName(id) {
+		return "", bots.FileNotFoundError{BotUUID: botUUID, ID: id}
+	}
+	return filepath.Join(s.dir, botUUID, id), nil
+}
+
+func isPlainName(name string) bool {
+	return name != "" && name != "." && name != ".." && filepath.Base(name) == name
+}
+
+type fileMeta struct {
+	Name     string `json:"name"`
+	MimeType string `json:"mime_type"`
+	Size     int64  `json:"size"`
+}
+
+func mapFileToMeta(file bots.File) fileMeta {
+	return fileMeta{
+		Name:     file.Name,
+		MimeType: file.MimeType,
+		Size:     file.Size,
+	}
+}
@@ -0,0 +1,62 @@
+package infra_test
+
+import (
+	"context"
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/brianvoe/gofakeit/v6"
+	"github.com/stretchr/testify/require"
+
+	"github.com/bmstu-itstech/itsreg-bots/internal/domain/bots"
+	"github.com/bmstu-itstech/itsreg-bots/internal/infra"
+)
+
+func TestLocalFileStorage(t *testing.T) {
+	storage := infra.NewLocalFileStorageAt(t.TempDir())
+
+	t.Run("should save and open file", func(t *testing.T) {
+		ctx := context.Background()
+
+		file := bots.MustNewFile(gofakeit.UUID(), gofakeit.UUID(), "poster.png", "image/png", 0)
+		saved, err := storage.Save(ctx, file, strings.NewReader("content"))
+		require.NoError(t, err)
+		require.Equal(t, int64(len("content")), saved.Size)
+
+		opened, content, err := storage.Open(ctx, file.BotUUID, file.ID)
+		require.NoError(t, err)
+		t.Cleanup(func() {
+			require.NoError(t, content.Close())
+		})
+		require.Equal(t, saved, opened)
+
+		b, err := io.ReadAll(content)
+		require.NoError(t, err)
+		require.Equal(t, "content", string(b))
+	})
+
+	t.Run("should delete file", func(t *testing.T) {
+		ctx := context.Background()
+
+		file := bots.MustNewFile(gofakeit.UUID(), gofakeit.UUID(), "cv.pdf", "application/pdf", 0)
+		_, err := storage.Save(ctx, file, strings.NewReader("content"))
+		require.NoError(t, err)
+
+		require.NoError(t, storage.Delete(ctx, file.BotUUID, file.ID))
+		_, _, err = storage.Open(ctx, file.BotUUID, file.ID)
+		require.ErrorAs(t, err, &bots.FileNotFoundError{})
+
+		require.NoError(t, storage.Delete(ctx, file.BotUUID, file.ID))
+	})
+
+	t.Run("should return error if file not found", func(t *testing.T) {
+		_, _, err := storage.Open(context.Background(), gofakeit.UUID(), gofakeit.UUID())
+		require.ErrorAs(t, err, &bots.FileNotFoundError{})
+	})
+
+	t.Run("should reject path traversal", func(t *testing.T) {
+		_, _, err := storage.Open(context.Background(), "..", "passwd")
+		require.ErrorAs(t, err, &bots.FileNotFoundError{})
+	})
+}
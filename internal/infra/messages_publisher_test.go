@@ -12,8 +12,10 @@ import (
 	"github.com/ThreeDotsLabs/watermill/message"
 	"github.com/stretchr/testify/require"
 
+	"github.com/bmstu-itstech/itsreg-bots/internal/common/instance"
 	"github.com/bmstu-itstech/itsreg-bots/internal/domain/bots"
 	"github.com/bmstu-itstech/itsreg-bots/internal/infra"
+	"github.com/bmstu-itstech/itsreg-bots/internal/service/mocks"
 )
 
 func TestNATSMessagesPublisher(t *testing.T) {
@@ -21,21 +23,111 @@ func TestNATSMessagesPublisher(t *testing.T) {
 		t.Skip("skipping integration test")
 	}
 
-	pub, messages, closeFn := infra.NewNATSMessagesPublisher()
+	leases := mocks.NewMockLeaseRepository()
+	pub, messages, closeFn := infra.NewNATSMessagesPublisher(leases)
 	t.Cleanup(func() {
 		err := closeFn()
 		require.NoError(t, err)
 	})
 
-	testMessagesPublisher(t, pub, messages)
+	testMessagesPublisher(t, pub, leases, messages)
+
+	t.Run("should route message to owning instance", func(t *testing.T) {
+		ctx := context.Background()
+
+		botUUID := gofakeit.UUID()
+		userID := rand.Int64()
+		acquireLease(t, leases, botUUID)
+
+		botMessage := bots.MustNewPlainMessage(gofakeit.Sentence(5))
+		err := pub.Publish(ctx, botUUID, userID, botMessage)
+		require.NoError(t, err)
+
+		require.Eventually(t,
+			func() bool {
+				jsonMsg := <-messages
+				rec, err := unmarshalJSONMessage(jsonMsg)
+				if err != nil {
+					return false
+				}
+				jsonMsg.Ack()
+				return botUUID == rec.BotUUID && userID == rec.UserID
+			},
+			time.Second, time.Second/10,
+		)
+	})
+
+	t.Run("should hold message until bot has owner", func(t *testing.T) {
+		ctx := context.Background()
+
+		botUUID := gofakeit.UUID()
+		userID := rand.Int64()
+
+		published := make(chan error, 1)
+		go func() {
+			published <- pub.Publish(ctx, botUUID, userID, bots.MustNewPlainMessage(gofakeit.Sentence(5)))
+		}()
+
+		time.Sleep(2 * time.Second)
+		select {
+		case err := <-published:
+			t.Fatalf("message published without owner: %v", err)
+		default:
+		}
+
+		acquireLease(t, leases, botUUID)
+		require.NoError(t, <-published)
+
+		jsonMsg := <-messages
+		rec, err := unmarshalJSONMessage(jsonMsg)
+		require.NoError(t, err)
+		jsonMsg.Ack()
+		require.Equal(t, botUUID, rec.BotUUID)
+		require.Equal(t, userID, rec.UserID)
+	})
+
+	t.Run("should redeliver nacked message", func(t *testing.T) {
+		ctx := context.Background()
+
+		botUUID := gofakeit.UUID()
+		userID := rand.Int64()
+		acquireLease(t, leases, botUUID)
+
+		botMessage := bots.MustNewPlainMessage(gofakeit.Sentence(5))
+		err := pub.Publish(ctx, botUUID, userID, botMessage)
+		require.NoError(t, err)
+
+		first := <-messages
+		first.Nack()
+
+		redelivered := <-messages
+		rec, err := unmarshalJSONMessage(redelivered)
+		require.NoError(t, err)
+		redelivered.Ack()
+		require.Equal(t, first.UUID, redelivered.UUID)
+		require.Equal(t, botMessage.Text, rec.Text)
+	})
 }
 
-func testMessagesPublisher(t *testing.T, pub bots.MessagesPublisher, messages <-chan *message.Message) {
+func acquireLease(t *testing.T, leases bots.LeaseRepository, botUUID string) {
+	lease, err := bots.NewLease(botUUID, instance.ID(), time.Now().Add(bots.LeaseTTL))
+	require.NoError(t, err)
+	require.NoError(t, leases.Acquire(context.Background(), lease))
+}
+
+func testMessagesPublisher(
+	t *testing.T,
+	pub bots.MessagesPublisher,
+	leases bots.LeaseRepository,
+	messages <-chan *message.Message,
+) {
 	t.Run("should publish message", func(t *testing.T) {
 		ctx := context.Background()
 
 		botUUID := gofakeit.UUID()
 		userID := rand.Int64()
+		acquireLease(t, leases, botUUID)
+
 		botMessage := bots.MustNewPlainMessage(gofakeit.Sentence(5))
 		err := pub.Publish(ctx, botUUID, userID, botMessage)
 		require.NoError(t, err)
@@ -63,6 +155,7 @@ func testMessagesPublisher(t *testing.T, pub bots.MessagesPublisher, messages <-
 
 		botUUID := gofakeit.UUID()
 		userID := rand.Int64()
+		acquireLease(t, leases, botUUID)
 
 		const messagesNum = 50
 
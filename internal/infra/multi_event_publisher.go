@@ -0,0 +1,24 @@
+package infra
+
+import (
+	"context"
+	"errors"
+
+	"github.com/bmstu-itstech/itsreg-bots/internal/domain/bots"
+)
+
+type multiEventPublisher struct {
+	pubs []bots.EventPublisher
+}
+
+func NewMultiEventPublisher(pubs ...bots.EventPublisher) bots.EventPublisher {
+	return &multiEventPublisher{pubs: pubs}
+}
+
+func (p *multiEventPublisher) Publish(ctx context.Context, event bots.Event) error {
+	var err error
+	for _, pub := range p.pubs {
+		err = errors.Join(err, pub.Publish(ctx, event))
+	}
+	return err
+}
@@ -0,0 +1,395 @@
+package infra
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"log/slog"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/ThreeDotsLabs/watermill"
+	"github.com/ThreeDotsLabs/watermill-nats/v2/pkg/nats"
+	"github.com/ThreeDotsLabs/watermill/message"
+	"github.com/jmoiron/sqlx"
+	nc "github.com/nats-io/nats.go"
+	"github.com/zhikh23/pgutils"
+
+	"github.com/bmstu-itstech/itsreg-bots/internal/common/logs"
+	"github.com/bmstu-itstech/itsreg-bots/internal/common/logs/sl"
+	"github.com/bmstu-itstech/itsreg-bots/internal/common/tracing"
+	"github.com/bmstu-itstech/itsreg-bots/internal/domain/bots"
+)
+
+const (
+	eventsTopic = "events"
+
+	eventListenerBuffer = 64
+	eventReplayLimit    = 1000
+	eventPruneBatch     = 10000
+)
+
+type natsEventStream struct {
+	db  *sqlx.DB
+	pub *nats.Publisher
+	log *slog.Logger
+
+	mu        sync.Mutex
+	listeners map[*eventListener]struct{}
+}
+
+type eventListener struct {
+	botUUID string
+	ch      chan bots.Event
+	lagged  chan struct{}
+}
+
+func NewNATSEventStream(db *sqlx.DB) (bots.EventPublisher, bots.EventSubscriber, func() error) {
+	marshaller := &nats.GobMarshaler{}
+	log := logs.DefaultLogger()
+	logger := sl.NewWatermillLoggerAdapter(log)
+	options := []nc.Option{
+		nc.RetryOnFailedConnect(true),
+		nc.Timeout(10 * time.Second),
+		nc.ReconnectWait(1 * time.Second),
+	}
+
+	jsConfig := nats.JetStreamConfig{Disabled: true}
+
+	uri := os.Getenv("NATS_URI")
+	if uri == "" {
+		panic("NATS_URI environment variable not set")
+	}
+
+	sub, err := nats.NewSubscriber(
+		nats.SubscriberConfig{
+			URL:            uri,
+			CloseTimeout:   10 * time.Second,
+			AckWaitTimeout: 10 * time.Second,
+			NatsOptions:    options,
+			Unmarshaler:    marshaller,
+			JetStream:      jsConfig,
+		},
+		logger,
+	)
+	if err != nil {
+		panic(err)
+	}
+
+	messages, err := sub.Subscribe(context.Background(), eventsTopic)
+	if err != nil {
+		panic(err)
+	}
+
+	pub, err := nats.NewPublisher(
+		nats.PublisherConfig{
+			URL:         uri,
+			NatsOptions: options,
+			Marshaler:   marshaller,
+			JetStream:   jsConfig,
+		},
+		logger,
+	)
+	if err != nil {
+		panic(err)
+	}
+
+	s := &natsEventStream{
+		db:        db,
+		pub:       pub,
+		log:       log,
+		listeners: make(map[*eventListener]struct{}),
+	}
+	go s.consume(messages)
+
+	return s, s, func() error {
+		err = sub.Close()
+		err = errors.Join(err, pub.Close())
+		return err
+	}
+}
+
+func (s *natsEventStream) Publish(ctx context.Context, event bots.Event) error {
+	row := convertEventToDB(event)
+
+	err := pgutils.Get(ctx, s.db, &row.ID,
+		`INSERT INTO bot_events
+			(bot_uuid, type, user_id, entry_key, state, answer, recipients, reason, occurred_at)
+		 VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)
+		 RETURNING id`,
+		row.BotUUID, row.Type, row.UserID, row.EntryKey, row.State,
+		row.Answer, row.Recipients, row.Reason, row.OccurredAt,
+	)
+	if err != nil {
+		return err
+	}
+
+	b, err := json.Marshal(row)
+	if err != nil {
+		return err
+	}
+
+	wmMsg := message.NewMessage(watermill.NewUUID(), b)
+	tracing.InjectMetadata(ctx, wmMsg.Metadata)
+	return s.pub.Publish(eventsTopic, wmMsg)
+}
+
+func (s *natsEventStream) Subscribe(ctx context.Context, botUUID string, lastEventID int64) (<-chan bots.Event, error) {
+	l := &eventListener{
+		botUUID: botUUID,
+		ch:      make(chan bots.Event, eventListenerBuffer),
+		lagged:  make(chan struct{}, 1),
+	}
+	s.addListener(l)
+
+	missed, err := s.eventsSince(ctx, botUUID, lastEventID)
+	if err != nil {
+		s.removeListener(l)
+		return nil, err
+	}
+
+	out := make(chan bots.Event)
+	go func() {
+		defer close(out)
+		defer s.removeListener(l)
+
+		last := lastEventID
+		send := func(events ...bots.Event) bool {
+			for _, e := range events {
+				if e.ID <= last {
+					continue
+				}
+				select {
+				case out <- e:
+					last = e.ID
+				case <-ctx.Done():
+					return false
+				}
+			}
+			return true
+		}
+
+		replay := func(events []bots.Event) bool {
+			for {
+				if !send(events...) {
+					return false
+				}
+				if len(events) < eventReplayLimit {
+					return true
+				}
+
+				var err error
+				events, err = s.eventsSince(ctx, botUUID, last)
+				if err != nil {
+					s.log.Error("failed to replay bot events", "bot_uuid", botUUID, "error", err.Error())
+					return false
+				}
+			}
+		}
+
+		if !replay(missed) {
+			return
+		}
+
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case e := <-l.ch:
+				if !send(e) {
+					return
+				}
+			case <-l.lagged:
+				missed, err := s.eventsSince(ctx, botUUID, last)
+				if err != nil {
+					s.log.Error("failed to replay bot events", "bot_uuid", botUUID, "error", err.Error())
+					return
+				}
+				if !replay(missed) {
+					return
+				}
+			}
+		}
+	}()
+
+	return out, nil
+}
+
+func (s *natsEventStream) consume(messages <-chan *message.Message) {
+	for msg := range messages {
+		var row eventRow
+		if err := json.Unmarshal(msg.Payload, &row); err != nil {
+			s.log.Error("failed to unmarshal bot event", "error", err.Error())
+			msg.Ack()
+			continue
+		}
+		msg.Ack()
+
+		event, err := convertEventFromDB(row)
+		if err != nil {
+			s.log.Error("failed to convert bot event", "error", err.Error())
+			continue
+		}
+
+		s.broadcast(event)
+	}
+}
+
+func (s *natsEventStream) broadcast(event bots.Event) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for l := range s.listeners {
+		if l.botUUID != event.BotUUID {
+			continue
+		}
+		select {
+		case l.ch <- event:
+		default:
+			select {
+			case l.lagged <- struct{}{}:
+			default:
+			}
+		}
+	}
+}
+
+func (s *natsEventStream) addListener(l *eventListener) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.listeners[l] = struct{}{}
+}
+
+func (s *natsEventStream) removeListener(l *eventListener) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	delete(s.listeners, l)
+}
+
+func (s *natsEventStream) eventsSince(ctx context.Context, botUUID string, lastEventID int64) ([]bots.Event, error) {
+	if lastEventID == 0 {
+		return nil, nil
+	}
+
+	var rows []eventRow
+	err := pgutils.Select(ctx, s.db, &rows,
+		`SELECT id, bot_uuid, type, user_id, entry_key, state, answer, recipients, reason, occurred_at
+		 FROM   bot_events
+		 WHERE  bot_uuid = $1 AND id > $2
+		 ORDER  BY id
+		 LIMIT  $3`,
+		botUUID, lastEventID, eventReplayLimit,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	events := make([]bots.Event, len(rows))
+	for i, row := range rows {
+		events[i], err = convertEventFromDB(row)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return events, nil
+}
+
+// PruneBotEvents deletes events that occurred before the given time. Events are
+// deleted in batches so that a large backlog does not hold one long lock.
+func PruneBotEvents(ctx context.Context, db *sqlx.DB, before time.Time) (int64, error) {
+	var total int64
+	for {
+		res, err := db.ExecContext(ctx,
+			`DELETE FROM bot_events
+			 WHERE  id IN (
+				SELECT id
+				FROM   bot_events
+				WHERE  occurred_at < $1
+				LIMIT  $2
+			 )`,
+			before.UTC(), eventPruneBatch,
+		)
+		if err != nil {
+			return total, err
+		}
+
+		n, err := res.RowsAffected()
+		if err != nil {
+			return total, err
+		}
+		total += n
+		if n < eventPruneBatch {
+			return total, nil
+		}
+	}
+}
+
+type eventRow struct {
+	ID         int64     `db:"id"          json:"id"`
+	BotUUID    string    `db:"bot_uuid"    json:"bot_uuid"`
+	Type       string    `db:"type"        json:"type"`
+	UserID     *int64    `db:"user_id"     json:"user_id"`
+	EntryKey   *string   `db:"entry_key"   json:"entry_key"`
+	State      *int      `db:"state"       json:"state"`
+	Answer     *string   `db:"answer"      json:"answer"`
+	Recipients *int      `db:"recipients"  json:"recipients"`
+	Reason     *string   `db:"reason"      json:"reason"`
+	OccurredAt time.Time `db:"occurred_at" json:"occurred_at"`
+}
+
+func convertEventToDB(e bots.Event) eventRow {
+	row := eventRow{
+		ID:         e.ID,
+		BotUUID:    e.BotUUID,
+		Type:       e.Type.String(),
+		State:      nilOnZero(e.Answer.State),
+		Recipients: nilOnZero(e.Recipients),
+		OccurredAt: e.OccurredAt.UTC(),
+	}
+	if e.UserID != 0 {
+		row.UserID = &e.UserID
+	}
+	if e.EntryKey != "" {
+		row.EntryKey = &e.EntryKey
+	}
+	if e.Answer.Text != "" {
+		row.Answer = &e.Answer.Text
+	}
+	if e.Reason != "" {
+		row.Reason = &e.Reason
+	}
+	return row
+}
+
+func convertEventFromDB(row eventRow) (bots.Event, error) {
+	t, err := bots.NewEventTypeFromString(row.Type)
+	if err != nil {
+		return bots.Event{}, err
+	}
+
+	e := bots.Event{
+		ID:         row.ID,
+		Type:       t,
+		BotUUID:    row.BotUUID,
+		Recipients: zeroOnNil(row.Recipients),
+		OccurredAt: row.OccurredAt.Local(),
+	}
+	if row.UserID != nil {
+		e.UserID = *row.UserID
+	}
+	if row.EntryKey != nil {
+		e.EntryKey = *row.EntryKey
+	}
+	if row.State != nil {
+		e.Answer.State = *row.State
+	}
+	if row.Answer != nil {
+		e.Answer.Text = *row.Answer
+	}
+	if row.Reason != nil {
+		e.Reason = *row.Reason
+	}
+	return e, nil
+}
@@ -4,8 +4,12 @@ import (
 	"context"
 	"encoding/json"
 	"errors"
+	"fmt"
 	"github.com/bmstu-itstech/itsreg-bots/internal/common/logs/sl"
+	"log/slog"
 	"os"
+	"strconv"
+	"sync"
 	"time"
 
 	"github.com/ThreeDotsLabs/watermill"
@@ -13,15 +17,35 @@ import (
 	"github.com/ThreeDotsLabs/watermill/message"
 	nc "github.com/nats-io/nats.go"
 
+	"github.com/bmstu-itstech/itsreg-bots/internal/common/instance"
 	"github.com/bmstu-itstech/itsreg-bots/internal/common/logs"
+	"github.com/bmstu-itstech/itsreg-bots/internal/common/metrics"
+	"github.com/bmstu-itstech/itsreg-bots/internal/common/tracing"
 	"github.com/bmstu-itstech/itsreg-bots/internal/domain/bots"
 )
 
+const (
+	ownerCacheTTL     = 5 * time.Second
+	ownerWaitInterval = time.Second
+	ownerWaitTimeout  = bots.LeaseTTL
+	maxRedeliveries   = 5
+
+	botUUIDMetadata      = "bot_uuid"
+	redeliveriesMetadata = "redeliveries"
+)
+
+var errMessagesPublisherClosed = errors.New("messages publisher is closed")
+
 type natsMessagesPublisher struct {
-	pub *nats.Publisher
+	pub     *nats.Publisher
+	owners  *leaseOwnerCache
+	log     *slog.Logger
+	closing chan struct{}
 }
 
-func NewNATSMessagesPublisher() (bots.MessagesPublisher, <-chan *message.Message, func() error) {
+func NewNATSMessagesPublisher(
+	leases bots.LeaseRepository,
+) (bots.MessagesPublisher, <-chan *message.Message, func() error) {
 	marshaller := &nats.GobMarshaler{}
 	logger := sl.NewWatermillLoggerAdapter(logs.DefaultLogger())
 	options := []nc.Option{
@@ -52,7 +76,7 @@ func NewNATSMessagesPublisher() (bots.MessagesPublisher, <-chan *message.Message
 		panic(err)
 	}
 
-	messages, err := sub.Subscribe(context.Background(), messagesTopic)
+	routed, err := sub.Subscribe(context.Background(), instanceMessagesTopic(instance.ID()))
 	if err != nil {
 		panic(err)
 	}
@@ -70,16 +94,22 @@ func NewNATSMessagesPublisher() (bots.MessagesPublisher, <-chan *message.Message
 		panic(err)
 	}
 
-	return natsMessagesPublisher{
-			pub: pub,
-		}, messages, func() error {
-			err = sub.Close()
-			err = errors.Join(err, pub.Close())
-			return err
-		}
+	m := &natsMessagesPublisher{
+		pub:     pub,
+		owners:  newLeaseOwnerCache(leases),
+		log:     logs.DefaultLogger(),
+		closing: make(chan struct{}),
+	}
+
+	return m, m.redeliverNacked(routed), func() error {
+		close(m.closing)
+		err = sub.Close()
+		err = errors.Join(err, pub.Close())
+		return err
+	}
 }
 
-func (m natsMessagesPublisher) Publish(_ context.Context, botUUID string, userID int64, msg bots.Message) error {
+func (m *natsMessagesPublisher) Publish(ctx context.Context, botUUID string, userID int64, msg bots.Message) error {
 	dto := mapBotMessageToNATS(botUUID, userID, msg)
 
 	b, err := json.Marshal(dto)
@@ -88,7 +118,154 @@ func (m natsMessagesPublisher) Publish(_ context.Context, botUUID string, userID
 	}
 
 	wmMsg := message.NewMessage(watermill.NewUUID(), b)
-	return m.pub.Publish(messagesTopic, wmMsg)
+	wmMsg.Metadata.Set(metrics.PublishedAtMetadata, time.Now().Format(time.RFC3339Nano))
+	wmMsg.Metadata.Set(botUUIDMetadata, botUUID)
+	tracing.InjectMetadata(ctx, wmMsg.Metadata)
+
+	err = m.route(ctx, botUUID, wmMsg)
+	if err != nil {
+		return err
+	}
+
+	metrics.IncBotMessages(botUUID, metrics.MessagePublished)
+	return nil
+}
+
+func (m *natsMessagesPublisher) route(ctx context.Context, botUUID string, msg *message.Message) error {
+	owner, err := m.waitOwner(ctx, botUUID)
+	if err != nil {
+		return err
+	}
+
+	return m.pub.Publish(instanceMessagesTopic(owner), msg)
+}
+
+func (m *natsMessagesPublisher) waitOwner(ctx context.Context, botUUID string) (string, error) {
+	deadline := time.Now().Add(ownerWaitTimeout)
+	for {
+		owner, err := m.owners.owner(ctx, botUUID)
+		if err != nil {
+			return "", err
+		}
+		if owner != "" {
+			return owner, nil
+		}
+
+		if time.Now().After(deadline) {
+			return "", fmt.Errorf("no instance owns bot %s", botUUID)
+		}
+
+		select {
+		case <-ctx.Done():
+			return "", ctx.Err()
+		case <-m.closing:
+			return "", errMessagesPublisherClosed
+		case <-time.After(ownerWaitInterval):
+		}
+	}
+}
+
+func (m *natsMessagesPublisher) redeliverNacked(in <-chan *message.Message) <-chan *message.Message {
+	out := make(chan *message.Message)
+
+	go func() {
+		defer close(out)
+		for msg := range in {
+			go m.redeliverOnNack(msg)
+			out <- msg
+		}
+	}()
+
+	return out
+}
+
+func (m *natsMessagesPublisher) redeliverOnNack(msg *message.Message) {
+	select {
+	case <-msg.Acked():
+		return
+	case <-m.closing:
+		return
+	case <-msg.Nacked():
+	}
+
+	botUUID := msg.Metadata.Get(botUUIDMetadata)
+	redeliveries, _ := strconv.Atoi(msg.Metadata.Get(redeliveriesMetadata))
+	if botUUID == "" || redeliveries >= maxRedeliveries {
+		m.log.Error("dropping undeliverable bot message", "bot_uuid", botUUID, "redeliveries", redeliveries)
+		metrics.IncBotMessages(botUUID, metrics.MessageFailed)
+		return
+	}
+
+	m.owners.forget(botUUID)
+	select {
+	case <-m.closing:
+		return
+	case <-time.After(ownerWaitInterval):
+	}
+
+	resend := msg.Copy()
+	resend.Metadata.Set(redeliveriesMetadata, strconv.Itoa(redeliveries+1))
+
+	err := m.route(context.Background(), botUUID, resend)
+	if err != nil {
+		m.log.Error("failed to redeliver bot message", "bot_uuid", botUUID, "error", err.Error())
+		metrics.IncBotMessages(botUUID, metrics.MessageFailed)
+	}
+}
+
+func instanceMessagesTopic(instanceID string) string {
+	return messagesTopic + "." + instanceID
+}
+
+type cachedOwner struct {
+	instanceID string
+	expiresAt  time.Time
+}
+
+type leaseOwnerCache struct {
+	mu     sync.Mutex
+	leases bots.LeaseRepository
+	owners map[string]cachedOwner
+}
+
+func newLeaseOwnerCache(leases bots.LeaseRepository) *leaseOwnerCache {
+	return &leaseOwnerCache{
+		leases: leases,
+		owners: make(map[string]cachedOwner),
+	}
+}
+
+func (c *leaseOwnerCache) owner(ctx context.Context, botUUID string) (string, error) {
+	now := time.Now()
+
+	c.mu.Lock()
+	cached, ok := c.owners[botUUID]
+	c.mu.Unlock()
+	if ok && now.Before(cached.expiresAt) {
+		return cached.instanceID, nil
+	}
+
+	owner, err := c.leases.Owner(ctx, botUUID)
+	if err != nil {
+		return "", err
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if owner == "" {
+		delete(c.owners, botUUID)
+	} else {
+		c.owners[botUUID] = cachedOwner{instanceID: owner, expiresAt: now.Add(ownerCacheTTL)}
+	}
+
+	return owner, nil
+}
+
+func (c *leaseOwnerCache) forget(botUUID string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	delete(c.owners, botUUID)
 }
 
 type natsBotMessage struct {
@@ -96,6 +273,11 @@ type natsBotMessage struct {
 	UserID  int64    `json:"user_id"`
 	Text    string   `json:"text"`
 	Buttons []string `json:"buttons"`
+	Ticket  string   `json:"ticket,omitempty"`
+
+	AttachmentType   string `json:"attachment_type,omitempty"`
+	AttachmentFileID string `json:"attachment_file_id,omitempty"`
+	Format           string `json:"format,omitempty"`
 }
 
 func mapBotMessageToNATS(botUUID string, userID int64, msg bots.Message) ampqBotMessage {
@@ -104,5 +286,10 @@ func mapBotMessageToNATS(botUUID string, userID int64, msg bots.Message) ampqBot
 		UserID:  userID,
 		Text:    msg.Text,
 		Buttons: msg.Buttons,
+		Ticket:  msg.Ticket,
+
+		AttachmentType:   msg.Attachment.Type.String(),
+		AttachmentFileID: msg.Attachment.FileID,
+		Format:           msg.Format.String(),
 	}
 }
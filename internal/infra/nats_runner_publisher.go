@@ -13,6 +13,8 @@ import (
 	nc "github.com/nats-io/nats.go"
 
 	"github.com/bmstu-itstech/itsreg-bots/internal/common/logs"
+	"github.com/bmstu-itstech/itsreg-bots/internal/common/metrics"
+	"github.com/bmstu-itstech/itsreg-bots/internal/common/tracing"
 	"github.com/bmstu-itstech/itsreg-bots/internal/common/logs/sl"
 	"github.com/bmstu-itstech/itsreg-bots/internal/domain/bots"
 )
@@ -87,7 +89,7 @@ func (p natsRunnerPublisher) PublishStop(ctx context.Context, botUUID string) er
 	return p.publish(ctx, botUUID, "stop")
 }
 
-func (p natsRunnerPublisher) publish(_ context.Context, botUUID string, command string) error {
+func (p natsRunnerPublisher) publish(ctx context.Context, botUUID string, command string) error {
 	dto := natsRunnerMessage{
 		BotUUID: botUUID,
 		Command: command,
@@ -99,6 +101,8 @@ func (p natsRunnerPublisher) publish(_ context.Context, botUUID string, command
 	}
 
 	wmMsg := message.NewMessage(watermill.NewUUID(), b)
+	wmMsg.Metadata.Set(metrics.PublishedAtMetadata, time.Now().Format(time.RFC3339Nano))
+	tracing.InjectMetadata(ctx, wmMsg.Metadata)
 	return p.pub.Publish(runnerTopic, wmMsg)
 }
 
@@ -3,6 +3,8 @@ package infra_test
 import (
 	"context"
 	"os"
+	"strings"
+	"sync"
 	"testing"
 	"time"
 
@@ -88,6 +90,311 @@ func testParticipantsRepository(t *testing.T, repos bots.ParticipantRepository)
 		expected.SwitchTo(2)
 		require.Contains(t, participants, expected)
 	})
+
+	t.Run("should serialise concurrent updates", func(t *testing.T) {
+		t.Parallel()
+
+		ctx := context.Background()
+
+		const updates = 10
+		userID := gofakeit.Int64()
+
+		wg := sync.WaitGroup{}
+		errs := make(chan error, updates)
+		for range updates {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				errs <- repos.UpdateOrCreate(ctx, randomBotUUID, userID, func(ctx context.Context, prt *bots.Participant) error {
+					state := prt.State
+					time.Sleep(10 * time.Millisecond)
+					prt.SwitchTo(state + 1)
+					return nil
+				})
+			}()
+		}
+		wg.Wait()
+		close(errs)
+
+		for err := range errs {
+			require.NoError(t, err)
+		}
+
+		prt, err := repos.Participant(ctx, randomBotUUID, userID)
+		require.NoError(t, err)
+		require.Equal(t, updates, prt.State)
+	})
+
+	t.Run("should pause participant", func(t *testing.T) {
+		t.Parallel()
+
+		ctx := context.Background()
+
+		userID := gofakeit.Int64()
+		err := repos.UpdateOrCreate(ctx, randomBotUUID, userID, func(ctx context.Context, prt *bots.Participant) error {
+			prt.SwitchTo(1)
+			prt.Pause()
+			return nil
+		})
+		require.NoError(t, err)
+
+		prt, err := repos.Participant(ctx, randomBotUUID, userID)
+		require.NoError(t, err)
+		require.True(t, prt.IsPaused())
+	})
+
+	t.Run("should delete participant", func(t *testing.T) {
+		t.Parallel()
+
+		ctx := context.Background()
+
+		userID := gofakeit.Int64()
+		err := repos.UpdateOrCreate(ctx, randomBotUUID, userID, func(ctx context.Context, prt *bots.Participant) error {
+			prt.SwitchTo(1)
+			return prt.AddAnswer("answer")
+		})
+		require.NoError(t, err)
+
+		err = repos.Delete(ctx, randomBotUUID, userID)
+		require.NoError(t, err)
+
+		_, err = repos.Participant(ctx, randomBotUUID, userID)
+		require.ErrorAs(t, err, &bots.ParticipantNotFoundError{})
+
+		err = repos.Delete(ctx, randomBotUUID, userID)
+		require.ErrorAs(t, err, &bots.ParticipantNotFoundError{})
+	})
+
+	t.Run("should return participants page", func(t *testing.T) {
+		t.Parallel()
+
+		ctx := context.Background()
+
+		userID := gofakeit.Int64()
+		err := repos.UpdateOrCreate(ctx, randomBotUUID, userID, func(ctx context.Context, prt *bots.Participant) error {
+			prt.SwitchTo(1)
+			return nil
+		})
+		require.NoError(t, err)
+
+		prts, total, err := repos.ParticipantsPage(ctx, randomBotUUID, 0, 1)
+		require.NoError(t, err)
+		require.Len(t, prts, 1)
+		require.GreaterOrEqual(t, total, 1)
+	})
+
+	t.Run("should reject duplicate unique answer", func(t *testing.T) {
+		t.Parallel()
+
+		ctx := context.Background()
+
+		email := gofakeit.Email()
+		err := repos.UpdateOrCreate(ctx, randomBotUUID, gofakeit.Int64(), func(ctx context.Context, prt *bots.Participant) error {
+			prt.SwitchTo(3)
+			return prt.AddAnswer(email)
+		})
+		require.NoError(t, err)
+
+		userID := gofakeit.Int64()
+		err = repos.UpdateOrCreate(ctx, randomBotUUID, userID, func(ctx context.Context, prt *bots.Participant) error {
+			prt.SwitchTo(3)
+			return prt.AddAnswer(" " + strings.ToUpper(email) + " ")
+		})
+		require.ErrorAs(t, err, &bots.AnswerNotUniqueError{})
+
+		_, err = repos.Participant(ctx, randomBotUUID, userID)
+		require.ErrorAs(t, err, &bots.ParticipantNotFoundError{})
+	})
+
+	t.Run("should delete answers cleared by reset", func(t *testing.T) {
+		t.Parallel()
+
+		ctx := context.Background()
+
+		bot := createFlowBot()
+		email := gofakeit.Email()
+		userID := gofakeit.Int64()
+		err := repos.UpdateOrCreate(ctx, randomBotUUID, userID, func(ctx context.Context, prt *bots.Participant) error {
+			if _, err := bot.Entry(prt, "start"); err != nil {
+				return err
+			}
+			for _, text := range []string{"John", "30", email} {
+				if _, err := bot.Process(prt, text); err != nil {
+					return err
+				}
+			}
+			return nil
+		})
+		require.NoError(t, err)
+
+		prt, err := repos.Participant(ctx, randomBotUUID, userID)
+		require.NoError(t, err)
+		require.Len(t, prt.Answers(), 3)
+
+		err = repos.UpdateOrCreate(ctx, randomBotUUID, userID, func(ctx context.Context, prt *bots.Participant) error {
+			_, err := bot.Reset(prt, "start")
+			return err
+		})
+		require.NoError(t, err)
+
+		prt, err = repos.Participant(ctx, randomBotUUID, userID)
+		require.NoError(t, err)
+		require.Empty(t, prt.Answers())
+
+		err = repos.UpdateOrCreate(ctx, randomBotUUID, gofakeit.Int64(), func(ctx context.Context, prt *bots.Participant) error {
+			prt.SwitchTo(3)
+			return prt.AddAnswer(email)
+		})
+		require.NoError(t, err)
+	})
+
+	t.Run("should delete answers cleared by expiry", func(t *testing.T) {
+		t.Parallel()
+
+		ctx := context.Background()
+
+		bot := createFlowBot()
+		userID := gofakeit.Int64()
+		err := repos.UpdateOrCreate(ctx, randomBotUUID, userID, func(ctx context.Context, prt *bots.Participant) error {
+			if _, err := bot.EntryAt(prt, "start", time.Now().Add(-2*time.Hour)); err != nil {
+				return err
+			}
+			_, err := bot.Process(prt, "John")
+			return err
+		})
+		require.NoError(t, err)
+
+		err = repos.UpdateOrCreate(ctx, randomBotUUID, userID, func(ctx context.Context, prt *bots.Participant) error {
+			_, err := bot.Expire(prt, time.Now())
+			return err
+		})
+		require.NoError(t, err)
+
+		prt, err := repos.Participant(ctx, randomBotUUID, userID)
+		require.NoError(t, err)
+		require.Empty(t, prt.Answers())
+		require.Equal(t, 0, prt.State)
+	})
+
+	t.Run("should store upload answer", func(t *testing.T) {
+		t.Parallel()
+
+		ctx := context.Background()
+
+		userID := gofakeit.Int64()
+		upload := bots.MustNewUpload("document", gofakeit.UUID(), gofakeit.UUID(), "cv.pdf", "application/pdf", 1024)
+		err := repos.UpdateOrCreate(ctx, randomBotUUID, userID, func(ctx context.Context, prt *bots.Participant) error {
+			prt.SwitchTo(1)
+			return prt.AddUpload(upload)
+		})
+		require.NoError(t, err)
+
+		prt, err := repos.Participant(ctx, randomBotUUID, userID)
+		require.NoError(t, err)
+
+		ans, ok := prt.Answer(1)
+		require.True(t, ok)
+		require.Equal(t, upload, ans.Upload)
+		require.Equal(t, "cv.pdf", ans.Text)
+	})
+
+	t.Run("should return stalled participants", func(t *testing.T) {
+		t.Parallel()
+
+		ctx := context.Background()
+
+		stalledID := gofakeit.Int64()
+		err := repos.UpdateOrCreate(ctx, randomBotUUID, stalledID, func(ctx context.Context, prt *bots.Participant) error {
+			prt.SwitchTo(1)
+			prt.Touch(time.Now().Add(-2 * time.Hour))
+			prt.RemindersSent = 1
+			return nil
+		})
+		require.NoError(t, err)
+
+		activeID := gofakeit.Int64()
+		err = repos.UpdateOrCreate(ctx, randomBotUUID, activeID, func(ctx context.Context, prt *bots.Participant) error {
+			prt.SwitchTo(1)
+			prt.Touch(time.Now())
+			return nil
+		})
+		require.NoError(t, err)
+
+		prts, err := repos.StalledParticipants(ctx, randomBotUUID, time.Now().Add(-time.Hour))
+		require.NoError(t, err)
+
+		ids := make([]int64, 0, len(prts))
+		for _, prt := range prts {
+			ids = append(ids, prt.UserID)
+			if prt.UserID == stalledID {
+				require.Equal(t, 1, prt.RemindersSent)
+			}
+		}
+		require.Contains(t, ids, stalledID)
+		require.NotContains(t, ids, activeID)
+	})
+
+	t.Run("should return expired participants", func(t *testing.T) {
+		t.Parallel()
+
+		ctx := context.Background()
+
+		expiredID := gofakeit.Int64()
+		err := repos.UpdateOrCreate(ctx, randomBotUUID, expiredID, func(ctx context.Context, prt *bots.Participant) error {
+			prt.SwitchTo(1)
+			prt.FlowEntry = "start"
+			prt.FlowStartedAt = time.Now().Add(-2 * time.Hour)
+			return nil
+		})
+		require.NoError(t, err)
+
+		freshID := gofakeit.Int64()
+		err = repos.UpdateOrCreate(ctx, randomBotUUID, freshID, func(ctx context.Context, prt *bots.Participant) error {
+			prt.SwitchTo(1)
+			prt.FlowEntry = "start"
+			prt.FlowStartedAt = time.Now()
+			return nil
+		})
+		require.NoError(t, err)
+
+		prts, err := repos.ExpiredParticipants(ctx, randomBotUUID, time.Now().Add(-time.Hour))
+		require.NoError(t, err)
+
+		ids := make([]int64, 0, len(prts))
+		for _, prt := range prts {
+			ids = append(ids, prt.UserID)
+			if prt.UserID == expiredID {
+				require.Equal(t, "start", prt.FlowEntry)
+			}
+		}
+		require.Contains(t, ids, expiredID)
+		require.NotContains(t, ids, freshID)
+	})
+
+	t.Run("should return error if participant not found", func(t *testing.T) {
+		t.Parallel()
+
+		_, err := repos.Participant(context.Background(), randomBotUUID, gofakeit.Int64())
+		require.ErrorAs(t, err, &bots.ParticipantNotFoundError{})
+	})
+}
+
+func createFlowBot() *bots.Bot {
+	return bots.MustNewBot(
+		randomBotUUID,
+		gofakeit.UUID(),
+		[]bots.EntryPoint{
+			bots.MustNewEntryPoint("start", 1).WithExpiry(bots.MustNewExpiry(time.Hour, "notify", "")),
+		},
+		nil,
+		[]bots.Block{
+			bots.MustNewQuestionBlock(1, 2, "Question 1", "Some text"),
+			bots.MustNewQuestionBlock(2, 3, "Question 2", "Some text"),
+			bots.MustNewQuestionBlock(3, 0, "Email", "Some text"),
+		},
+		gofakeit.Name(),
+		"12345678:XXXXXXXXXXXXXXXXXXXXXXXXXXXXXXXXXXX",
+	)
 }
 
 func setupDBParticipants(ctx context.Context, db *sqlx.DB) error {
@@ -105,12 +412,14 @@ func setupDBParticipants(ctx context.Context, db *sqlx.DB) error {
 
 		_, err = pgutils.Exec(ctx, tx,
 			`INSERT INTO
-				blocks (bot_uuid, state, type, next_state, title, text)
+				blocks (bot_uuid, state, type, next_state, title, text, is_unique)
 			VALUES 
-				($1, $2, $3, $4, $5, $6),
-				($7, $8, $9, $10, $11, $12)`,
-			randomBotUUID, 1, "question", nil, "Question 1", "Some text",
-			randomBotUUID, 2, "question", nil, "Question 2", "Some text",
+				($1, $2, $3, $4, $5, $6, $7),
+				($8, $9, $10, $11, $12, $13, $14),
+				($15, $16, $17, $18, $19, $20, $21)`,
+			randomBotUUID, 1, "question", nil, "Question 1", "Some text", false,
+			randomBotUUID, 2, "question", nil, "Question 2", "Some text", false,
+			randomBotUUID, 3, "question", nil, "Email", "Some text", true,
 		)
 		if err != nil {
 			return err
@@ -3,22 +3,29 @@ package infra
 import (
 	"context"
 	"database/sql"
+	"encoding/json"
 	"errors"
 	"time"
 
 	"github.com/jmoiron/sqlx"
+	"github.com/lib/pq"
 	"github.com/zhikh23/pgutils"
 
+	"github.com/bmstu-itstech/itsreg-bots/internal/common/tracing"
 	"github.com/bmstu-itstech/itsreg-bots/internal/domain/bots"
 )
 
+const botsTelegramIDIndex = "bots_telegram_id_idx"
+
 type pgBotsRepository struct {
-	db *sqlx.DB
+	db     *sqlx.DB
+	cipher bots.TokenCipher
 }
 
-func NewPgBotsRepository(db *sqlx.DB) bots.Repository {
+func NewPgBotsRepository(db *sqlx.DB, cipher bots.TokenCipher) bots.Repository {
 	return &pgBotsRepository{
-		db: db,
+		db:     db,
+		cipher: cipher,
 	}
 }
 
@@ -27,6 +34,9 @@ func (r *pgBotsRepository) Update(
 	botUUID string,
 	updateFn func(innerCtx context.Context, bot *bots.Bot) error,
 ) error {
+	ctx, span := tracing.Start(ctx, "pgBotsRepository.Update")
+	defer span.End()
+
 	return pgutils.RunTx(ctx, r.db, func(tx *sqlx.Tx) error {
 		bot, err := r.Bot(ctx, botUUID)
 		if err != nil {
@@ -38,26 +48,45 @@ func (r *pgBotsRepository) Update(
 			return err
 		}
 
+		bRow, err := r.convertBotToDB(bot)
+		if err != nil {
+			return err
+		}
+
 		if err = r.checkExecRes(tx.NamedExecContext(ctx,
 			`INSERT INTO bots 
-				(uuid, name, token, status, created_at, updated_at, owner_uuid)
-             VALUES (:uuid, :name, :token, :status, :created_at, :updated_at, :owner_uuid)
+				(uuid, name, token, token_ciphertext, token_key, token_key_id, token_mask, telegram_id, username, status, last_error, failed_at,
+				 created_at, updated_at, owner_uuid)
+             VALUES (:uuid, :name, :token, :token_ciphertext, :token_key, :token_key_id, :token_mask, :telegram_id, :username, :status, :last_error, :failed_at,
+				 :created_at, :updated_at, :owner_uuid)
 			 ON CONFLICT ( uuid )
 				DO UPDATE SET name = :name,
                               token = :token,
+                              token_ciphertext = :token_ciphertext,
+                              token_key = :token_key,
+                              token_key_id = :token_key_id,
+                              token_mask = :token_mask,
+                              telegram_id = :telegram_id,
+                              username = :username,
                               status = :status,
+                              last_error = :last_error,
+                              failed_at = :failed_at,
                               created_at = :created_at,
                               updated_at = :updated_at,
                               owner_uuid = :owner_uuid`,
-			convertBotToDB(bot),
+			bRow,
 		)); err != nil {
-			return err
+			return mapBotUniqueViolation(err)
 		}
 
 		if err = r.noCheckExecRes(tx.NamedExecContext(ctx,
 			`INSERT INTO blocks
-				(bot_uuid, state, type, next_state, title, text) 
-			 VALUES (:bot_uuid, :state, :type, :next_state, :title, :text)
+				(bot_uuid, state, type, next_state, title, text, is_unique, unique_text,
+				 attachment_type, attachment_file_id, format, translations,
+				 fallback, fallback_state, fallback_text) 
+			 VALUES (:bot_uuid, :state, :type, :next_state, :title, :text, :is_unique, :unique_text,
+				 :attachment_type, :attachment_file_id, :format, :translations,
+				 :fallback, :fallback_state, :fallback_text)
              ON CONFLICT ( bot_uuid, state ) DO NOTHING`,
 			convertBlocksToDB(bot.UUID, bot.Blocks()),
 		)); err != nil {
@@ -70,8 +99,8 @@ func (r *pgBotsRepository) Update(
 			}
 			if err = r.noCheckExecRes(tx.NamedExecContext(ctx,
 				`INSERT INTO options
-					(bot_uuid, state, next, text) 
-				 VALUES (:bot_uuid, :state, :next, :text)
+					(bot_uuid, state, next, text, value, option_id, aliases, locale, translations) 
+				 VALUES (:bot_uuid, :state, :next, :text, :value, :option_id, :aliases, :locale, :translations)
 				 ON CONFLICT ( bot_uuid, state, text ) DO NOTHING`,
 				convertOptionsToDB(bot.UUID, block.State, block.Options),
 			)); err != nil {
@@ -81,8 +110,14 @@ func (r *pgBotsRepository) Update(
 
 		if err = r.noCheckExecRes(tx.NamedExecContext(ctx,
 			`INSERT INTO entry_points 
-				(bot_uuid, key, state) 
-			 VALUES (:bot_uuid, :key, :state)
+				(bot_uuid, key, state, capacity, full_state, waitlist_state, promoted_state,
+				 opens_at, closes_at, timezone, not_open_text, closed_text,
+				 reminder_after_seconds, reminder_limit, reminder_text,
+				 expiry_ttl_seconds, expiry_mode, expiry_text) 
+			 VALUES (:bot_uuid, :key, :state, :capacity, :full_state, :waitlist_state, :promoted_state,
+				 :opens_at, :closes_at, :timezone, :not_open_text, :closed_text,
+				 :reminder_after_seconds, :reminder_limit, :reminder_text,
+				 :expiry_ttl_seconds, :expiry_mode, :expiry_text)
              ON CONFLICT ( bot_uuid, key ) DO NOTHING`,
 			convertEntryPointsToDB(bot.UUID, bot.Entries()),
 		)); err != nil {
@@ -101,60 +136,164 @@ func (r *pgBotsRepository) Update(
 			}
 		}
 
+		if _, err = tx.ExecContext(ctx, `DELETE FROM bot_members WHERE bot_uuid = $1`, bot.UUID); err != nil {
+			return err
+		}
+
+		if err = r.checkExecRes(tx.NamedExecContext(ctx,
+			`INSERT INTO bot_members
+				(bot_uuid, user_uuid, role)
+			 VALUES (:bot_uuid, :user_uuid, :role)`,
+			convertMembersToDB(bot.UUID, bot.Members()),
+		)); err != nil {
+			return err
+		}
+
 		return nil
 	})
 }
 
 func (r *pgBotsRepository) UpdateOrCreate(ctx context.Context, bot *bots.Bot) error {
+	ctx, span := tracing.Start(ctx, "pgBotsRepository.UpdateOrCreate")
+	defer span.End()
+
+	bRow, err := r.convertBotToDB(bot)
+	if err != nil {
+		return err
+	}
+
 	return pgutils.RunTx(ctx, r.db, func(tx *sqlx.Tx) error {
-		_, err := tx.ExecContext(ctx, `DELETE FROM bots WHERE uuid = $1`, bot.UUID)
-		if err != nil {
+		if err := r.checkExecRes(tx.NamedExecContext(ctx,
+			`INSERT INTO bots 
+				(uuid, name, token, token_ciphertext, token_key, token_key_id, token_mask, telegram_id, username, status, last_error, failed_at,
+				 created_at, updated_at, owner_uuid)
+             VALUES (:uuid, :name, :token, :token_ciphertext, :token_key, :token_key_id, :token_mask, :telegram_id, :username, :status, :last_error, :failed_at,
+				 :created_at, :updated_at, :owner_uuid)
+			 ON CONFLICT ( uuid )
+				DO UPDATE SET name = :name,
+                              token = :token,
+                              token_ciphertext = :token_ciphertext,
+                              token_key = :token_key,
+                              token_key_id = :token_key_id,
+                              token_mask = :token_mask,
+                              telegram_id = :telegram_id,
+                              username = :username,
+                              status = :status,
+                              last_error = :last_error,
+                              failed_at = :failed_at,
+                              created_at = :created_at,
+                              updated_at = :updated_at,
+                              owner_uuid = :owner_uuid`,
+			bRow,
+		)); err != nil {
+			return mapBotUniqueViolation(err)
+		}
+
+		if _, err := tx.ExecContext(ctx, `DELETE FROM options WHERE bot_uuid = $1`, bot.UUID); err != nil {
 			return err
 		}
 
-		if err = r.checkExecRes(tx.NamedExecContext(ctx,
-			`INSERT INTO bots 
-				(uuid, name, token, status, created_at, updated_at, owner_uuid)
-             VALUES (:uuid, :name, :token, :status, :created_at, :updated_at, :owner_uuid)`,
-			convertBotToDB(bot),
-		)); err != nil {
+		if _, err := tx.ExecContext(ctx, `DELETE FROM mailings WHERE bot_uuid = $1`, bot.UUID); err != nil {
 			return err
 		}
 
-		if err = r.checkExecRes(tx.NamedExecContext(ctx,
+		if err := r.checkExecRes(tx.NamedExecContext(ctx,
 			`INSERT INTO blocks
-				(bot_uuid, state, type, next_state, title, text) 
-			 VALUES (:bot_uuid, :state, :type, :next_state, :title, :text)`,
+				(bot_uuid, state, type, next_state, title, text, is_unique, unique_text,
+				 attachment_type, attachment_file_id, format, translations,
+				 fallback, fallback_state, fallback_text) 
+			 VALUES (:bot_uuid, :state, :type, :next_state, :title, :text, :is_unique, :unique_text,
+				 :attachment_type, :attachment_file_id, :format, :translations,
+				 :fallback, :fallback_state, :fallback_text)
+			 ON CONFLICT ( bot_uuid, state )
+				DO UPDATE SET type = EXCLUDED.type,
+				              next_state = EXCLUDED.next_state,
+				              title = EXCLUDED.title,
+				              text = EXCLUDED.text,
+				              is_unique = EXCLUDED.is_unique,
+				              unique_text = EXCLUDED.unique_text,
+				              attachment_type = EXCLUDED.attachment_type,
+				              attachment_file_id = EXCLUDED.attachment_file_id,
+				              format = EXCLUDED.format,
+				              translations = EXCLUDED.translations,
+				              fallback = EXCLUDED.fallback,
+				              fallback_state = EXCLUDED.fallback_state,
+				              fallback_text = EXCLUDED.fallback_text`,
 			convertBlocksToDB(bot.UUID, bot.Blocks()),
 		)); err != nil {
 			return err
 		}
 
+		states := make([]int64, 0, len(bot.Blocks()))
+		for _, block := range bot.Blocks() {
+			states = append(states, int64(block.State))
+		}
+		if _, err := tx.ExecContext(ctx,
+			`DELETE FROM blocks WHERE bot_uuid = $1 AND NOT ( state = ANY($2) )`,
+			bot.UUID, pq.Array(states),
+		); err != nil {
+			return err
+		}
+
 		for _, block := range bot.Blocks() {
 			if len(block.Options) == 0 {
 				continue
 			}
-			if err = r.checkExecRes(tx.NamedExecContext(ctx,
+			if err := r.checkExecRes(tx.NamedExecContext(ctx,
 				`INSERT INTO options
-					(bot_uuid, state, next, text) 
-				 VALUES (:bot_uuid, :state, :next, :text)`,
+					(bot_uuid, state, next, text, value, option_id, aliases, locale, translations) 
+				 VALUES (:bot_uuid, :state, :next, :text, :value, :option_id, :aliases, :locale, :translations)`,
 				convertOptionsToDB(bot.UUID, block.State, block.Options),
 			)); err != nil {
 				return err
 			}
 		}
 
-		if err = r.checkExecRes(tx.NamedExecContext(ctx,
+		if err := r.checkExecRes(tx.NamedExecContext(ctx,
 			`INSERT INTO entry_points 
-				(bot_uuid, key, state) 
-			 VALUES (:bot_uuid, :key, :state)`,
+				(bot_uuid, key, state, capacity, full_state, waitlist_state, promoted_state,
+				 opens_at, closes_at, timezone, not_open_text, closed_text,
+				 reminder_after_seconds, reminder_limit, reminder_text,
+				 expiry_ttl_seconds, expiry_mode, expiry_text) 
+			 VALUES (:bot_uuid, :key, :state, :capacity, :full_state, :waitlist_state, :promoted_state,
+				 :opens_at, :closes_at, :timezone, :not_open_text, :closed_text,
+				 :reminder_after_seconds, :reminder_limit, :reminder_text,
+				 :expiry_ttl_seconds, :expiry_mode, :expiry_text)
+			 ON CONFLICT ( bot_uuid, key )
+				DO UPDATE SET state = EXCLUDED.state,
+				              capacity = EXCLUDED.capacity,
+				              full_state = EXCLUDED.full_state,
+				              waitlist_state = EXCLUDED.waitlist_state,
+				              promoted_state = EXCLUDED.promoted_state,
+				              opens_at = EXCLUDED.opens_at,
+				              closes_at = EXCLUDED.closes_at,
+				              timezone = EXCLUDED.timezone,
+				              not_open_text = EXCLUDED.not_open_text,
+				              closed_text = EXCLUDED.closed_text,
+				              reminder_after_seconds = EXCLUDED.reminder_after_seconds,
+				              reminder_limit = EXCLUDED.reminder_limit,
+				              reminder_text = EXCLUDED.reminder_text,
+				              expiry_ttl_seconds = EXCLUDED.expiry_ttl_seconds,
+				              expiry_mode = EXCLUDED.expiry_mode,
+				              expiry_text = EXCLUDED.expiry_text`,
 			convertEntryPointsToDB(bot.UUID, bot.Entries()),
 		)); err != nil {
 			return err
 		}
 
+		keys := make([]string, 0, len(bot.Entries()))
+		for _, entry := range bot.Entries() {
+			keys = append(keys, entry.Key)
+		}
+		if _, err := tx.ExecContext(ctx,
+			`DELETE FROM entry_points WHERE bot_uuid = $1 AND NOT ( key = ANY($2) )`,
+			bot.UUID, pq.Array(keys),
+		); err != nil {
+			return err
+		}
+
 		if len(bot.Mailings()) > 0 {
-			if err = r.checkExecRes(tx.NamedExecContext(ctx,
+			if err := r.checkExecRes(tx.NamedExecContext(ctx,
 				`INSERT INTO mailings 
 					(bot_uuid, name, entry_key, required_state) 
 			 	VALUES (:bot_uuid, :name, :entry_key, :required_state)`,
@@ -164,11 +303,27 @@ func (r *pgBotsRepository) UpdateOrCreate(ctx context.Context, bot *bots.Bot) er
 			}
 		}
 
+		if _, err := tx.ExecContext(ctx, `DELETE FROM bot_members WHERE bot_uuid = $1`, bot.UUID); err != nil {
+			return err
+		}
+
+		if err := r.checkExecRes(tx.NamedExecContext(ctx,
+			`INSERT INTO bot_members
+				(bot_uuid, user_uuid, role)
+			 VALUES (:bot_uuid, :user_uuid, :role)`,
+			convertMembersToDB(bot.UUID, bot.Members()),
+		)); err != nil {
+			return err
+		}
+
 		return nil
 	})
 }
 
 func (r *pgBotsRepository) UpdateStatus(ctx context.Context, botUUID string, status bots.Status) error {
+	ctx, span := tracing.Start(ctx, "pgBotsRepository.UpdateStatus")
+	defer span.End()
+
 	err := r.checkExecRes(r.db.ExecContext(ctx,
 		`UPDATE bots SET status = $1 WHERE uuid = $2`, status.String(), botUUID,
 	))
@@ -178,7 +333,25 @@ func (r *pgBotsRepository) UpdateStatus(ctx context.Context, botUUID string, sta
 	return err
 }
 
+func (r *pgBotsRepository) MarkFailed(ctx context.Context, botUUID string, reason string, failedAt time.Time) error {
+	ctx, span := tracing.Start(ctx, "pgBotsRepository.MarkFailed")
+	defer span.End()
+
+	err := r.checkExecRes(r.db.ExecContext(ctx,
+		`UPDATE bots
+		 SET    status = $1, last_error = $2, failed_at = $3
+		 WHERE  uuid = $4`, bots.Failed.String(), reason, failedAt.UTC(), botUUID,
+	))
+	if errors.Is(err, ErrNoAffectedRows) {
+		return bots.BotNotFoundError{UUID: botUUID}
+	}
+	return err
+}
+
 func (r *pgBotsRepository) Delete(ctx context.Context, uuid string) error {
+	ctx, span := tracing.Start(ctx, "pgBotsRepository.Delete")
+	defer span.End()
+
 	err := r.checkExecRes(r.db.ExecContext(ctx, `DELETE FROM bots WHERE uuid = $1`, uuid))
 	if errors.Is(err, ErrNoAffectedRows) {
 		return bots.BotNotFoundError{UUID: uuid}
@@ -187,9 +360,13 @@ func (r *pgBotsRepository) Delete(ctx context.Context, uuid string) error {
 }
 
 func (r *pgBotsRepository) Bot(ctx context.Context, uuid string) (*bots.Bot, error) {
+	ctx, span := tracing.Start(ctx, "pgBotsRepository.Bot")
+	defer span.End()
+
 	var bRow botRow
 	if err := pgutils.Get(ctx, r.db, &bRow,
-		`SELECT uuid, name, token, status, created_at, updated_at, owner_uuid
+		`SELECT uuid, name, token, token_ciphertext, token_key, token_key_id, token_mask,
+		        telegram_id, username, status, last_error, failed_at, created_at, updated_at, owner_uuid
          FROM   bots 
 		 WHERE  uuid = $1`, uuid,
 	); errors.Is(err, sql.ErrNoRows) {
@@ -213,19 +390,35 @@ func (r *pgBotsRepository) Bot(ctx context.Context, uuid string) (*bots.Bot, err
 		return nil, err
 	}
 
+	members, err := r.selectMembers(ctx, bRow.UUID)
+	if err != nil {
+		return nil, err
+	}
+
+	sealedToken, tokenMask, err := r.sealedToken(bRow)
+	if err != nil {
+		return nil, err
+	}
+
 	return bots.UnmarshallBotFromDB(
-		bRow.UUID, bRow.OwnerUUID, entryPoints, mailings, blocks,
-		bRow.Name, bRow.Token, bRow.Status,
+		bRow.UUID, bRow.OwnerUUID, entryPoints, mailings, blocks, members,
+		bRow.Name, sealedToken, tokenMask, zeroOnNil64(bRow.TelegramID), emptyOnNil(bRow.Username),
+		bRow.Status, emptyOnNil(bRow.LastError), zeroTimeOnNil(bRow.FailedAt).Local(),
 		bRow.CreatedAt.Local(), bRow.UpdatedAt.Local(),
 	)
 }
 
 func (r *pgBotsRepository) UserBots(ctx context.Context, userUUID string) ([]*bots.Bot, error) {
+	ctx, span := tracing.Start(ctx, "pgBotsRepository.UserBots")
+	defer span.End()
+
 	var bRows []botRow
 	if err := pgutils.Select(ctx, r.db, &bRows,
-		`SELECT uuid, name, token, status, created_at, updated_at, owner_uuid
-         FROM   bots 
-		 WHERE  owner_uuid = $1`, userUUID,
+		`SELECT b.uuid, b.name, b.token, b.token_ciphertext, b.token_key, b.token_key_id, b.token_mask,
+		        b.telegram_id, b.username, b.status, b.last_error, b.failed_at, b.created_at, b.updated_at, b.owner_uuid
+         FROM   bots b
+		 JOIN   bot_members m ON m.bot_uuid = b.uuid
+		 WHERE  m.user_uuid = $1`, userUUID,
 	); err != nil {
 		return nil, err
 	}
@@ -247,9 +440,20 @@ func (r *pgBotsRepository) UserBots(ctx context.Context, userUUID string) ([]*bo
 			return nil, err
 		}
 
+		members, err := r.selectMembers(ctx, bRow.UUID)
+		if err != nil {
+			return nil, err
+		}
+
+		sealedToken, tokenMask, err := r.sealedToken(bRow)
+		if err != nil {
+			return nil, err
+		}
+
 		bot, err := bots.UnmarshallBotFromDB(
-			bRow.UUID, bRow.OwnerUUID, entryPoints, mailings, blocks,
-			bRow.Name, bRow.Token, bRow.Status,
+			bRow.UUID, bRow.OwnerUUID, entryPoints, mailings, blocks, members,
+			bRow.Name, sealedToken, tokenMask, zeroOnNil64(bRow.TelegramID), emptyOnNil(bRow.Username),
+			bRow.Status, emptyOnNil(bRow.LastError), zeroTimeOnNil(bRow.FailedAt).Local(),
 			bRow.CreatedAt.Local(), bRow.UpdatedAt.Local(),
 		)
 		if err != nil {
@@ -263,9 +467,13 @@ func (r *pgBotsRepository) UserBots(ctx context.Context, userUUID string) ([]*bo
 }
 
 func (r *pgBotsRepository) BotsWithStatus(ctx context.Context, status bots.Status) ([]*bots.Bot, error) {
+	ctx, span := tracing.Start(ctx, "pgBotsRepository.BotsWithStatus")
+	defer span.End()
+
 	var bRows []botRow
 	if err := pgutils.Select(ctx, r.db, &bRows,
-		`SELECT uuid, name, token, status, created_at, updated_at, owner_uuid
+		`SELECT uuid, name, token, token_ciphertext, token_key, token_key_id, token_mask,
+		        telegram_id, username, status, last_error, failed_at, created_at, updated_at, owner_uuid
          FROM   bots 
 		 WHERE  status = $1`, status.String(),
 	); err != nil {
@@ -289,9 +497,20 @@ func (r *pgBotsRepository) BotsWithStatus(ctx context.Context, status bots.Statu
 			return nil, err
 		}
 
+		members, err := r.selectMembers(ctx, bRow.UUID)
+		if err != nil {
+			return nil, err
+		}
+
+		sealedToken, tokenMask, err := r.sealedToken(bRow)
+		if err != nil {
+			return nil, err
+		}
+
 		bot, err := bots.UnmarshallBotFromDB(
-			bRow.UUID, bRow.OwnerUUID, entryPoints, mailings, blocks,
-			bRow.Name, bRow.Token, bRow.Status,
+			bRow.UUID, bRow.OwnerUUID, entryPoints, mailings, blocks, members,
+			bRow.Name, sealedToken, tokenMask, zeroOnNil64(bRow.TelegramID), emptyOnNil(bRow.Username),
+			bRow.Status, emptyOnNil(bRow.LastError), zeroTimeOnNil(bRow.FailedAt).Local(),
 			bRow.CreatedAt.Local(), bRow.UpdatedAt.Local(),
 		)
 		if err != nil {
@@ -307,7 +526,10 @@ func (r *pgBotsRepository) BotsWithStatus(ctx context.Context, status bots.Statu
 func (r *pgBotsRepository) selectEntryPoints(ctx context.Context, uuid string) ([]bots.EntryPoint, error) {
 	var eRows []entryPointRow
 	if err := pgutils.Select(ctx, r.db, &eRows,
-		`SELECT bot_uuid, key, state 
+		`SELECT bot_uuid, key, state, capacity, full_state, waitlist_state, promoted_state,
+		        opens_at, closes_at, timezone, not_open_text, closed_text,
+		        reminder_after_seconds, reminder_limit, reminder_text,
+		        expiry_ttl_seconds, expiry_mode, expiry_text
 		 FROM   entry_points 
          WHERE  bot_uuid = $1`, uuid,
 	); err != nil {
@@ -328,10 +550,22 @@ func (r *pgBotsRepository) selectMailings(ctx context.Context, uuid string) ([]b
 	return convertMailingsToDomain(mRows)
 }
 
+func (r *pgBotsRepository) selectMembers(ctx context.Context, uuid string) ([]bots.Member, error) {
+	var mRows []memberRow
+	if err := pgutils.Select(ctx, r.db, &mRows,
+		`SELECT bot_uuid, user_uuid, role
+		 FROM   bot_members
+		 WHERE  bot_uuid = $1`, uuid,
+	); err != nil {
+		return nil, err
+	}
+	return convertMembersToDomain(mRows)
+}
+
 func (r *pgBotsRepository) selectOptions(ctx context.Context, uuid string, state int) ([]bots.Option, error) {
 	var oRows []optionRow
 	if err := pgutils.Select(ctx, r.db, &oRows,
-		`SELECT bot_uuid, state, text, next 
+		`SELECT bot_uuid, state, text, next, value, option_id, aliases, locale, translations
 		 FROM   options 
 		 WHERE  bot_uuid = $1 AND state = $2`, uuid, state,
 	); err != nil {
@@ -343,7 +577,9 @@ func (r *pgBotsRepository) selectOptions(ctx context.Context, uuid string, state
 func (r *pgBotsRepository) selectBlocks(ctx context.Context, uuid string) ([]bots.Block, error) {
 	var bRows []blockRow
 	if err := pgutils.Select(ctx, r.db, &bRows,
-		`SELECT bot_uuid, state, type, next_state, title, text
+		`SELECT bot_uuid, state, type, next_state, title, text, is_unique, unique_text,
+		        attachment_type, attachment_file_id, format, translations,
+		        fallback, fallback_state, fallback_text
 		 FROM   blocks
          WHERE  bot_uuid = $1`, uuid,
 	); err != nil {
@@ -392,18 +628,28 @@ func (r *pgBotsRepository) noCheckExecRes(_ sql.Result, err error) error {
 }
 
 type optionRow struct {
-	BotUUID string `db:"bot_uuid"`
-	State   int    `db:"state"`
-	Text    string `db:"text"`
-	Next    *int   `db:"next"`
+	BotUUID      string  `db:"bot_uuid"`
+	State        int     `db:"state"`
+	Text         string  `db:"text"`
+	Next         *int    `db:"next"`
+	Value        *string `db:"value"`
+	ID           *string `db:"option_id"`
+	Aliases      *string `db:"aliases"`
+	Locale       *string `db:"locale"`
+	Translations *string `db:"translations"`
 }
 
 func convertOptionToDB(botUUID string, state int, o bots.Option) optionRow {
 	return optionRow{
-		BotUUID: botUUID,
-		State:   state,
-		Text:    o.Text,
-		Next:    nilOnZero(o.Next),
+		BotUUID:      botUUID,
+		State:        state,
+		Text:         o.Text,
+		Next:         nilOnZero(o.Next),
+		Value:        nilOnEmpty(o.Value),
+		ID:           nilOnEmpty(o.ID),
+		Aliases:      nilOnEmptyJSONArray(o.Aliases),
+		Locale:       nilOnEmpty(o.Locale),
+		Translations: nilOnEmptyJSON(o.Translations),
 	}
 }
 
@@ -422,22 +668,92 @@ func convertOptionsToDomain(os []optionRow) ([]bots.Option, error) {
 		if err != nil {
 			return nil, err
 		}
+		if o.Value != nil {
+			option, err = option.WithValue(*o.Value)
+			if err != nil {
+				return nil, err
+			}
+		}
+		if o.ID != nil {
+			option, err = option.WithID(*o.ID)
+			if err != nil {
+				return nil, err
+			}
+		}
+		var aliases []string
+		if err = unmarshalJSONOnNotNil(o.Aliases, &aliases); err != nil {
+			return nil, err
+		}
+		option, err = option.WithAliases(aliases...)
+		if err != nil {
+			return nil, err
+		}
+		if o.Locale != nil {
+			option, err = option.WithLocale(*o.Locale)
+			if err != nil {
+				return nil, err
+			}
+		}
+		var ts map[string]string
+		if err = unmarshalJSONOnNotNil(o.Translations, &ts); err != nil {
+			return nil, err
+		}
+		for locale, text := range ts {
+			option, err = option.WithTranslation(locale, text)
+			if err != nil {
+				return nil, err
+			}
+		}
 		res[i] = option
 	}
 	return res, nil
 }
 
 type entryPointRow struct {
-	BotUUID string `db:"bot_uuid"`
-	Key     string `db:"key"`
-	State   int    `db:"state"`
+	BotUUID       string     `db:"bot_uuid"`
+	Key           string     `db:"key"`
+	State         int        `db:"state"`
+	Capacity      *int       `db:"capacity"`
+	FullState     *int       `db:"full_state"`
+	WaitlistState *int       `db:"waitlist_state"`
+	PromotedState *int       `db:"promoted_state"`
+	OpensAt       *time.Time `db:"opens_at"`
+	ClosesAt      *time.Time `db:"closes_at"`
+	Timezone      *string    `db:"timezone"`
+	NotOpenText   *string    `db:"not_open_text"`
+	ClosedText    *string    `db:"closed_text"`
+
+	ReminderAfterSeconds *int    `db:"reminder_after_seconds"`
+	ReminderLimit        *int    `db:"reminder_limit"`
+	ReminderText         *string `db:"reminder_text"`
+
+	ExpiryTTLSeconds *int    `db:"expiry_ttl_seconds"`
+	ExpiryMode       *string `db:"expiry_mode"`
+	ExpiryText       *string `db:"expiry_text"`
 }
 
 func convertEntryPointToDB(botUUID string, e bots.EntryPoint) entryPointRow {
 	return entryPointRow{
-		BotUUID: botUUID,
-		Key:     e.Key,
-		State:   e.State,
+		BotUUID:       botUUID,
+		Key:           e.Key,
+		State:         e.State,
+		Capacity:      nilOnZero(e.Capacity.Limit),
+		FullState:     nilOnZero(e.Capacity.FullState),
+		WaitlistState: nilOnZero(e.Capacity.WaitlistState),
+		PromotedState: nilOnZero(e.Capacity.PromotedState),
+		OpensAt:       nilOnZeroTime(e.Window.OpensAt),
+		ClosesAt:      nilOnZeroTime(e.Window.ClosesAt),
+		Timezone:      nilOnEmpty(e.Window.Timezone),
+		NotOpenText:   nilOnEmpty(e.Window.NotOpenText),
+		ClosedText:    nilOnEmpty(e.Window.ClosedText),
+
+		ReminderAfterSeconds: nilOnZero(int(e.Reminder.After.Seconds())),
+		ReminderLimit:        nilOnZero(e.Reminder.Limit),
+		ReminderText:         nilOnEmpty(e.Reminder.Text),
+
+		ExpiryTTLSeconds: nilOnZero(int(e.Expiry.TTL.Seconds())),
+		ExpiryMode:       nilOnEmpty(e.Expiry.Mode.String()),
+		ExpiryText:       nilOnEmpty(e.Expiry.Text),
 	}
 }
 
@@ -456,7 +772,42 @@ func convertEntryPointsToDomain(es []entryPointRow) ([]bots.EntryPoint, error) {
 		if err != nil {
 			return nil, err
 		}
-		res[i] = entryPoint
+		capacity, err := bots.NewCapacity(
+			zeroOnNil(e.Capacity),
+			zeroOnNil(e.FullState),
+			zeroOnNil(e.WaitlistState),
+			zeroOnNil(e.PromotedState),
+		)
+		if err != nil {
+			return nil, err
+		}
+		window, err := bots.NewWindow(
+			zeroTimeOnNil(e.OpensAt),
+			zeroTimeOnNil(e.ClosesAt),
+			emptyOnNil(e.Timezone),
+			emptyOnNil(e.NotOpenText),
+			emptyOnNil(e.ClosedText),
+		)
+		if err != nil {
+			return nil, err
+		}
+		reminder, err := bots.NewReminder(
+			time.Duration(zeroOnNil(e.ReminderAfterSeconds))*time.Second,
+			zeroOnNil(e.ReminderLimit),
+			emptyOnNil(e.ReminderText),
+		)
+		if err != nil {
+			return nil, err
+		}
+		expiry, err := bots.NewExpiry(
+			time.Duration(zeroOnNil(e.ExpiryTTLSeconds))*time.Second,
+			emptyOnNil(e.ExpiryMode),
+			emptyOnNil(e.ExpiryText),
+		)
+		if err != nil {
+			return nil, err
+		}
+		res[i] = entryPoint.WithCapacity(capacity).WithWindow(window).WithReminder(reminder).WithExpiry(expiry)
 	}
 	return res, nil
 }
@@ -497,13 +848,64 @@ func convertMailingsToDomain(ms []mailingRow) ([]bots.Mailing, error) {
 	return res, nil
 }
 
+type memberRow struct {
+	BotUUID  string `db:"bot_uuid"`
+	UserUUID string `db:"user_uuid"`
+	Role     string `db:"role"`
+}
+
+func convertMemberToDB(botUUID string, m bots.Member) memberRow {
+	return memberRow{
+		BotUUID:  botUUID,
+		UserUUID: m.UserUUID,
+		Role:     m.Role.String(),
+	}
+}
+
+func convertMembersToDB(botUUID string, ms []bots.Member) []memberRow {
+	res := make([]memberRow, len(ms))
+	for i, m := range ms {
+		res[i] = convertMemberToDB(botUUID, m)
+	}
+	return res
+}
+
+func convertMembersToDomain(ms []memberRow) ([]bots.Member, error) {
+	res := make([]bots.Member, len(ms))
+	for i, m := range ms {
+		member, err := bots.NewMember(m.UserUUID, m.Role)
+		if err != nil {
+			return nil, err
+		}
+		res[i] = member
+	}
+	return res, nil
+}
+
 type blockRow struct {
-	BotUUID   string `db:"bot_uuid"`
-	Type      string `db:"type"`
-	State     int    `db:"state"`
-	NextState *int   `db:"next_state"`
-	Title     string `db:"title"`
-	Text      string `db:"text"`
+	BotUUID    string  `db:"bot_uuid"`
+	Type       string  `db:"type"`
+	State      int     `db:"state"`
+	NextState  *int    `db:"next_state"`
+	Title      string  `db:"title"`
+	Text       string  `db:"text"`
+	Unique     bool    `db:"is_unique"`
+	UniqueText *string `db:"unique_text"`
+
+	AttachmentType   *string `db:"attachment_type"`
+	AttachmentFileID *string `db:"attachment_file_id"`
+
+	Format       *string `db:"format"`
+	Translations *string `db:"translations"`
+
+	Fallback      *string `db:"fallback"`
+	FallbackState *int    `db:"fallback_state"`
+	FallbackText  *string `db:"fallback_text"`
+}
+
+type translationJSON struct {
+	Title string `json:"title,omitempty"`
+	Text  string `json:"text"`
 }
 
 func nilOnZero(i int) *int {
@@ -513,6 +915,49 @@ func nilOnZero(i int) *int {
 	return &i
 }
 
+func nilOnEmpty(s string) *string {
+	if s == "" {
+		return nil
+	}
+	return &s
+}
+
+func emptyOnNil(s *string) string {
+	if s == nil {
+		return ""
+	}
+	return *s
+}
+
+func nilOnZeroTime(t time.Time) *time.Time {
+	if t.IsZero() {
+		return nil
+	}
+	t = t.UTC()
+	return &t
+}
+
+func zeroTimeOnNil(t *time.Time) time.Time {
+	if t == nil {
+		return time.Time{}
+	}
+	return t.Local()
+}
+
+func nilOnZero64(i int64) *int64 {
+	if i == 0 {
+		return nil
+	}
+	return &i
+}
+
+func zeroOnNil64(i *int64) int64 {
+	if i == nil {
+		return 0
+	}
+	return *i
+}
+
 func zeroOnNil(i *int) int {
 	if i == nil {
 		return 0
@@ -522,13 +967,64 @@ func zeroOnNil(i *int) int {
 
 func convertBlockToDB(botUUID string, b bots.Block) blockRow {
 	return blockRow{
-		BotUUID:   botUUID,
-		Type:      b.Type.String(),
-		State:     b.State,
-		NextState: nilOnZero(b.NextState),
-		Title:     b.Title,
-		Text:      b.Text,
+		BotUUID:    botUUID,
+		Type:       b.Type.String(),
+		State:      b.State,
+		NextState:  nilOnZero(b.NextState),
+		Title:      b.Title,
+		Text:       b.Text,
+		Unique:     b.Unique,
+		UniqueText: nilOnEmpty(b.UniqueText),
+
+		AttachmentType:   nilOnEmpty(b.Attachment.Type.String()),
+		AttachmentFileID: nilOnEmpty(b.Attachment.FileID),
+
+		Format:       nilOnEmpty(b.Format.String()),
+		Translations: nilOnEmptyJSON(convertTranslationsToDB(b.Translations)),
+
+		Fallback:      nilOnEmpty(b.Fallback.String()),
+		FallbackState: nilOnZero(b.FallbackState),
+		FallbackText:  nilOnEmpty(b.FallbackText),
+	}
+}
+
+func convertTranslationsToDB(ts map[string]bots.Translation) map[string]translationJSON {
+	res := make(map[string]translationJSON, len(ts))
+	for locale, t := range ts {
+		res[locale] = translationJSON{Title: t.Title, Text: t.Text}
+	}
+	return res
+}
+
+func nilOnEmptyJSON[T any](m map[string]T) *string {
+	if len(m) == 0 {
+		return nil
+	}
+	b, err := json.Marshal(m)
+	if err != nil {
+		return nil
+	}
+	s := string(b)
+	return &s
+}
+
+func nilOnEmptyJSONArray[T any](a []T) *string {
+	if len(a) == 0 {
+		return nil
+	}
+	b, err := json.Marshal(a)
+	if err != nil {
+		return nil
 	}
+	s := string(b)
+	return &s
+}
+
+func unmarshalJSONOnNotNil(s *string, v any) error {
+	if s == nil {
+		return nil
+	}
+	return json.Unmarshal([]byte(*s), v)
 }
 
 func convertBlocksToDB(botUUID string, bs []bots.Block) []blockRow {
@@ -540,27 +1036,111 @@ func convertBlocksToDB(botUUID string, bs []bots.Block) []blockRow {
 }
 
 func convertBlockToDomain(b blockRow, options []bots.Option) (bots.Block, error) {
-	return bots.UnmarshallBlockFromDB(b.Type, b.State, zeroOnNil(b.NextState), options, b.Title, b.Text)
+	block, err := bots.UnmarshallBlockFromDB(b.Type, b.State, zeroOnNil(b.NextState), options, b.Title, b.Text)
+	if err != nil {
+		return bots.Block{}, err
+	}
+	if b.AttachmentType != nil {
+		a, err := bots.NewAttachment(*b.AttachmentType, emptyOnNil(b.AttachmentFileID))
+		if err != nil {
+			return bots.Block{}, err
+		}
+		block = block.WithAttachment(a)
+	}
+	if b.Format != nil {
+		block, err = block.WithFormat(*b.Format)
+		if err != nil {
+			return bots.Block{}, err
+		}
+	}
+	if b.Fallback != nil {
+		block, err = block.WithFallback(*b.Fallback, zeroOnNil(b.FallbackState), emptyOnNil(b.FallbackText))
+		if err != nil {
+			return bots.Block{}, err
+		}
+	}
+	var ts map[string]translationJSON
+	if err = unmarshalJSONOnNotNil(b.Translations, &ts); err != nil {
+		return bots.Block{}, err
+	}
+	for locale, t := range ts {
+		block, err = block.WithTranslation(locale, bots.Translation{Title: t.Title, Text: t.Text})
+		if err != nil {
+			return bots.Block{}, err
+		}
+	}
+	if b.Unique {
+		return block.WithUnique(emptyOnNil(b.UniqueText))
+	}
+	return block, nil
 }
 
 type botRow struct {
-	UUID      string    `db:"uuid"`
-	OwnerUUID string    `db:"owner_uuid"`
-	Name      string    `db:"name"`
-	Token     string    `db:"token"`
-	Status    string    `db:"status"`
-	CreatedAt time.Time `db:"created_at"`
-	UpdatedAt time.Time `db:"updated_at"`
+	UUID            string     `db:"uuid"`
+	OwnerUUID       string     `db:"owner_uuid"`
+	Name            string     `db:"name"`
+	Token           *string    `db:"token"`
+	TokenCiphertext []byte     `db:"token_ciphertext"`
+	TokenKey        []byte     `db:"token_key"`
+	TokenKeyID      *string    `db:"token_key_id"`
+	TokenMask       *string    `db:"token_mask"`
+	TelegramID      *int64     `db:"telegram_id"`
+	Username        *string    `db:"username"`
+	Status          string     `db:"status"`
+	LastError       *string    `db:"last_error"`
+	FailedAt        *time.Time `db:"failed_at"`
+	CreatedAt       time.Time  `db:"created_at"`
+	UpdatedAt       time.Time  `db:"updated_at"`
 }
 
-func convertBotToDB(b *bots.Bot) botRow {
+func (r *pgBotsRepository) convertBotToDB(b *bots.Bot) (botRow, error) {
+	sealed := b.SealedToken
+	if b.Token != "" {
+		var err error
+		sealed, err = r.cipher.Seal(b.Token)
+		if err != nil {
+			return botRow{}, err
+		}
+	}
+
 	return botRow{
-		UUID:      b.UUID,
-		OwnerUUID: b.OwnerUUID,
-		Name:      b.Name,
-		Token:     b.Token,
-		Status:    b.Status.String(),
-		CreatedAt: b.CreatedAt.UTC(),
-		UpdatedAt: b.UpdatedAt.UTC(),
+		UUID:            b.UUID,
+		OwnerUUID:       b.OwnerUUID,
+		Name:            b.Name,
+		TokenCiphertext: sealed.Ciphertext,
+		TokenKey:        sealed.EncryptedKey,
+		TokenKeyID:      nilOnEmpty(sealed.KeyID),
+		TokenMask:       nilOnEmpty(b.TokenMask),
+		TelegramID:      nilOnZero64(b.TelegramID),
+		Username:        nilOnEmpty(b.Username),
+		Status:          b.Status.String(),
+		LastError:       nilOnEmpty(b.LastError),
+		FailedAt:        nilOnZeroTime(b.FailedAt),
+		CreatedAt:       b.CreatedAt.UTC(),
+		UpdatedAt:       b.UpdatedAt.UTC(),
+	}, nil
+}
+
+func (r *pgBotsRepository) sealedToken(b botRow) (bots.SealedToken, string, error) {
+	if b.Token != nil && len(b.TokenCiphertext) == 0 {
+		sealed, err := r.cipher.Seal(*b.Token)
+		if err != nil {
+			return bots.SealedToken{}, "", err
+		}
+		return sealed, bots.MaskToken(*b.Token), nil
 	}
+
+	return bots.SealedToken{
+		KeyID:        emptyOnNil(b.TokenKeyID),
+		EncryptedKey: b.TokenKey,
+		Ciphertext:   b.TokenCiphertext,
+	}, emptyOnNil(b.TokenMask), nil
+}
+
+func mapBotUniqueViolation(err error) error {
+	var pqErr *pq.Error
+	if errors.As(err, &pqErr) && pqErr.Code == uniqueViolationCode && pqErr.Constraint == botsTelegramIDIndex {
+		return bots.ErrTokenInUse
+	}
+	return err
 }
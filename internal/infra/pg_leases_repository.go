@@ -0,0 +1,131 @@
+package infra
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"time"
+
+	"github.com/jmoiron/sqlx"
+	"github.com/zhikh23/pgutils"
+
+	"github.com/bmstu-itstech/itsreg-bots/internal/common/tracing"
+	"github.com/bmstu-itstech/itsreg-bots/internal/domain/bots"
+)
+
+type pgLeasesRepository struct {
+	db *sqlx.DB
+}
+
+func NewPgLeasesRepository(db *sqlx.DB) bots.LeaseRepository {
+	return &pgLeasesRepository{
+		db: db,
+	}
+}
+
+func (r *pgLeasesRepository) Acquire(ctx context.Context, lease bots.Lease) error {
+	ctx, span := tracing.Start(ctx, "pgLeasesRepository.Acquire")
+	defer span.End()
+
+	res, err := r.db.ExecContext(ctx,
+		`INSERT INTO bot_leases (bot_uuid, instance_id, expires_at)
+		 VALUES ($1, $2, $3)
+		 ON CONFLICT ( bot_uuid )
+			DO UPDATE SET instance_id = EXCLUDED.instance_id,
+			              expires_at = EXCLUDED.expires_at
+			WHERE bot_leases.instance_id = EXCLUDED.instance_id
+			   OR bot_leases.expires_at <= $4`,
+		lease.BotUUID, lease.InstanceID, lease.ExpiresAt.UTC(), time.Now().UTC(),
+	)
+	if err != nil {
+		return err
+	}
+
+	aff, err := res.RowsAffected()
+	if err != nil {
+		return err
+	}
+
+	if aff == 0 {
+		return bots.ErrLeaseHeld
+	}
+
+	return nil
+}
+
+func (r *pgLeasesRepository) Renew(ctx context.Context, instanceID string, expiresAt time.Time) error {
+	ctx, span := tracing.Start(ctx, "pgLeasesRepository.Renew")
+	defer span.End()
+
+	_, err := r.db.ExecContext(ctx,
+		`UPDATE bot_leases
+		 SET    expires_at = $2
+		 WHERE  instance_id = $1 AND expires_at > $3`,
+		instanceID, expiresAt.UTC(), time.Now().UTC(),
+	)
+	return err
+}
+
+func (r *pgLeasesRepository) Release(ctx context.Context, botUUID string, instanceID string) error {
+	ctx, span := tracing.Start(ctx, "pgLeasesRepository.Release")
+	defer span.End()
+
+	_, err := r.db.ExecContext(ctx,
+		`DELETE FROM bot_leases WHERE bot_uuid = $1 AND instance_id = $2`,
+		botUUID, instanceID,
+	)
+	return err
+}
+
+func (r *pgLeasesRepository) Leases(ctx context.Context) ([]bots.Lease, error) {
+	ctx, span := tracing.Start(ctx, "pgLeasesRepository.Leases")
+	defer span.End()
+
+	var rows []leaseRow
+	if err := pgutils.Select(ctx, r.db, &rows,
+		`SELECT bot_uuid, instance_id, expires_at
+		 FROM   bot_leases
+		 WHERE  expires_at > $1`,
+		time.Now().UTC(),
+	); err != nil {
+		return nil, err
+	}
+
+	res := make([]bots.Lease, len(rows))
+	for i, row := range rows {
+		lease, err := bots.NewLease(row.BotUUID, row.InstanceID, row.ExpiresAt.Local())
+		if err != nil {
+			return nil, err
+		}
+		res[i] = lease
+	}
+
+	return res, nil
+}
+
+func (r *pgLeasesRepository) Owner(ctx context.Context, botUUID string) (string, error) {
+	ctx, span := tracing.Start(ctx, "pgLeasesRepository.Owner")
+	defer span.End()
+
+	var instanceID string
+	err := pgutils.Get(ctx, r.db, &instanceID,
+		`SELECT instance_id
+		 FROM   bot_leases
+		 WHERE  bot_uuid = $1 AND expires_at > $2`,
+		botUUID, time.Now().UTC(),
+	)
+	if errors.Is(err, sql.ErrNoRows) {
+		return "", nil
+	}
+	if err != nil {
+		return "", err
+	}
+
+	return instanceID, nil
+}
+
+type leaseRow struct {
+	BotUUID    string    `db:"bot_uuid"`
+	InstanceID string    `db:"instance_id"`
+	ExpiresAt  time.Time `db:"expires_at"`
+}
@@ -4,13 +4,21 @@ import (
 	"context"
 	"database/sql"
 	"errors"
+	"time"
 
 	"github.com/jmoiron/sqlx"
+	"github.com/lib/pq"
 	"github.com/zhikh23/pgutils"
 
+	"github.com/bmstu-itstech/itsreg-bots/internal/common/tracing"
 	"github.com/bmstu-itstech/itsreg-bots/internal/domain/bots"
 )
 
+const (
+	uniqueViolationCode    = "23505"
+	answersUniqueTextIndex = "answers_unique_text_idx"
+)
+
 type pgParticipantsRepository struct {
 	db *sqlx.DB
 }
@@ -21,37 +29,147 @@ func NewPgParticipantsRepository(db *sqlx.DB) bots.ParticipantRepository {
 	}
 }
 
+func (r *pgParticipantsRepository) Participant(
+	ctx context.Context, botUUID string, userID int64,
+) (*bots.Participant, error) {
+	ctx, span := tracing.Start(ctx, "pgParticipantsRepository.Participant")
+	defer span.End()
+
+	prt, err := selectParticipant(ctx, r.db, botUUID, userID)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, bots.ParticipantNotFoundError{BotUUID: botUUID, UserID: userID}
+	} else if err != nil {
+		return nil, err
+	}
+	return prt, nil
+}
+
 func (r *pgParticipantsRepository) ParticipantsOfBot(ctx context.Context, botUUID string) ([]*bots.Participant, error) {
+	ctx, span := tracing.Start(ctx, "pgParticipantsRepository.ParticipantsOfBot")
+	defer span.End()
+
 	return selectParticipants(ctx, r.db, botUUID)
 }
 
+func (r *pgParticipantsRepository) ParticipantsPage(
+	ctx context.Context, botUUID string, offset int, limit int,
+) ([]*bots.Participant, int, error) {
+	ctx, span := tracing.Start(ctx, "pgParticipantsRepository.ParticipantsPage")
+	defer span.End()
+
+	var total int
+	err := pgutils.Get(ctx, r.db, &total,
+		`SELECT COUNT(*)
+		 FROM   participants
+		 WHERE  bot_uuid = $1`, botUUID,
+	)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	var rows []participantRow
+	err = pgutils.Select(ctx, r.db, &rows,
+		`SELECT bot_uuid, user_id, state, entry_key, flow_entry, flow_started_at, locale,
+		        last_activity_at, reminders_sent, paused, blocked
+		 FROM   participants
+		 WHERE  bot_uuid = $1
+		 ORDER  BY user_id
+		 OFFSET $2
+		 LIMIT  $3`,
+		botUUID, offset, limit,
+	)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	prts, err := mapParticipantsFromDB(ctx, r.db, rows)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	return prts, total, nil
+}
+
+func (r *pgParticipantsRepository) StalledParticipants(
+	ctx context.Context, botUUID string, inactiveSince time.Time,
+) ([]*bots.Participant, error) {
+	ctx, span := tracing.Start(ctx, "pgParticipantsRepository.StalledParticipants")
+	defer span.End()
+
+	var rows []participantRow
+	err := pgutils.Select(ctx, r.db, &rows,
+		`SELECT bot_uuid, user_id, state, entry_key, flow_entry, flow_started_at, locale,
+		        last_activity_at, reminders_sent, paused, blocked
+		 FROM   participants
+		 WHERE  bot_uuid = $1
+		   AND  state IS NOT NULL
+		   AND  NOT paused
+		   AND  NOT blocked
+		   AND  last_activity_at <= $2`,
+		botUUID, inactiveSince.UTC(),
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	return mapParticipantsFromDB(ctx, r.db, rows)
+}
+
+func (r *pgParticipantsRepository) ExpiredParticipants(
+	ctx context.Context, botUUID string, startedBefore time.Time,
+) ([]*bots.Participant, error) {
+	ctx, span := tracing.Start(ctx, "pgParticipantsRepository.ExpiredParticipants")
+	defer span.End()
+
+	var rows []participantRow
+	err := pgutils.Select(ctx, r.db, &rows,
+		`SELECT bot_uuid, user_id, state, entry_key, flow_entry, flow_started_at, locale,
+		        last_activity_at, reminders_sent, paused, blocked
+		 FROM   participants
+		 WHERE  bot_uuid = $1
+		   AND  state IS NOT NULL
+		   AND  NOT paused
+		   AND  NOT blocked
+		   AND  flow_started_at <= $2`,
+		botUUID, startedBefore.UTC(),
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	return mapParticipantsFromDB(ctx, r.db, rows)
+}
+
 func (r *pgParticipantsRepository) UpdateOrCreate(
 	ctx context.Context,
 	botUUID string,
 	userID int64,
 	updateFn func(context.Context, *bots.Participant) error,
 ) error {
-	prt, err := selectParticipant(ctx, r.db, botUUID, userID)
-	if errors.Is(err, sql.ErrNoRows) {
-		prt, err = bots.NewParticipant(botUUID, userID)
+	ctx, span := tracing.Start(ctx, "pgParticipantsRepository.UpdateOrCreate")
+	defer span.End()
+
+	return pgutils.RunTx(ctx, r.db, func(tx *sqlx.Tx) error {
+		prt, err := lockParticipant(ctx, tx, botUUID, userID)
 		if err != nil {
 			return err
 		}
-	} else if err != nil {
-		return err
-	}
 
-	err = updateFn(ctx, prt)
-	if err != nil {
-		return err
-	}
+		err = updateFn(ctx, prt)
+		if err != nil {
+			return err
+		}
 
-	return pgutils.RunTx(ctx, r.db, func(tx *sqlx.Tx) error {
 		err = upsertParticipant(ctx, tx, prt)
 		if err != nil {
 			return err
 		}
 
+		err = deleteStaleAnswers(ctx, tx, botUUID, userID, prt.Answers())
+		if err != nil {
+			return err
+		}
+
 		for _, ans := range prt.Answers() {
 			err = upsertAnswer(ctx, tx, botUUID, userID, ans)
 			if err != nil {
@@ -63,12 +181,65 @@ func (r *pgParticipantsRepository) UpdateOrCreate(
 	})
 }
 
+func (r *pgParticipantsRepository) Delete(ctx context.Context, botUUID string, userID int64) error {
+	ctx, span := tracing.Start(ctx, "pgParticipantsRepository.Delete")
+	defer span.End()
+
+	return pgutils.RunTx(ctx, r.db, func(tx *sqlx.Tx) error {
+		res, err := tx.ExecContext(ctx,
+			`DELETE FROM participants
+			 WHERE  bot_uuid = $1 AND user_id = $2`,
+			botUUID, userID,
+		)
+		if err != nil {
+			return err
+		}
+
+		aff, err := res.RowsAffected()
+		if err != nil {
+			return err
+		}
+		if aff == 0 {
+			return bots.ParticipantNotFoundError{BotUUID: botUUID, UserID: userID}
+		}
+
+		_, err = tx.ExecContext(ctx,
+			`DELETE FROM seats
+			 WHERE  bot_uuid = $1 AND user_id = $2`,
+			botUUID, userID,
+		)
+		if err != nil {
+			return err
+		}
+
+		_, err = tx.ExecContext(ctx,
+			`DELETE FROM bot_events
+			 WHERE  bot_uuid = $1 AND user_id = $2`,
+			botUUID, userID,
+		)
+		if err != nil {
+			return err
+		}
+
+		_, err = tx.ExecContext(ctx,
+			`DELETE FROM webhook_deliveries d
+			 USING  webhooks w
+			 WHERE  w.uuid = d.webhook_uuid
+			   AND  w.bot_uuid = $1
+			   AND  (d.payload ->> 'user_id')::BIGINT = $2`,
+			botUUID, userID,
+		)
+		return err
+	})
+}
+
 func selectParticipants(
 	ctx context.Context, q sqlx.QueryerContext, botUUID string,
 ) ([]*bots.Participant, error) {
 	var rows []participantRow
 	err := pgutils.Select(ctx, q, &rows,
-		`SELECT bot_uuid, user_id, state
+		`SELECT bot_uuid, user_id, state, entry_key, flow_entry, flow_started_at, locale,
+		        last_activity_at, reminders_sent, paused, blocked
 		 FROM   participants
 		 WHERE  bot_uuid = $1`, botUUID,
 	)
@@ -76,6 +247,12 @@ func selectParticipants(
 		return nil, err
 	}
 
+	return mapParticipantsFromDB(ctx, q, rows)
+}
+
+func mapParticipantsFromDB(
+	ctx context.Context, q sqlx.QueryerContext, rows []participantRow,
+) ([]*bots.Participant, error) {
 	res := make([]*bots.Participant, len(rows))
 	for i, row := range rows {
 		answers, err := selectAnswers(ctx, q, row.BotUUID, row.UserID)
@@ -97,7 +274,8 @@ func selectParticipant(
 ) (*bots.Participant, error) {
 	var row participantRow
 	err := pgutils.Get(ctx, q, &row,
-		`SELECT bot_uuid, user_id, state
+		`SELECT bot_uuid, user_id, state, entry_key, flow_entry, flow_started_at, locale,
+		        last_activity_at, reminders_sent, paused, blocked
 		 FROM   participants 
 		 WHERE  bot_uuid = $1 AND user_id = $2`,
 		botUUID, userID,
@@ -114,13 +292,61 @@ func selectParticipant(
 	return mapParticipantFromDB(row, answers)
 }
 
+func lockParticipant(
+	ctx context.Context, tx *sqlx.Tx, botUUID string, userID int64,
+) (*bots.Participant, error) {
+	prt, err := bots.NewParticipant(botUUID, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	_, err = sqlx.NamedExecContext(ctx, tx,
+		`INSERT INTO participants 
+			(bot_uuid, user_id, state, entry_key, flow_entry, flow_started_at, locale,
+			 last_activity_at, reminders_sent, paused, blocked)
+		 VALUES (:bot_uuid, :user_id, :state, :entry_key, :flow_entry, :flow_started_at, :locale,
+			 :last_activity_at, :reminders_sent, :paused, :blocked)
+		 ON CONFLICT ( bot_uuid, user_id ) DO NOTHING`,
+		mapParticipantToDB(prt),
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	var row participantRow
+	err = pgutils.Get(ctx, tx, &row,
+		`SELECT bot_uuid, user_id, state, entry_key, flow_entry, flow_started_at, locale,
+		        last_activity_at, reminders_sent, paused, blocked
+		 FROM   participants 
+		 WHERE  bot_uuid = $1 AND user_id = $2
+		 FOR UPDATE`,
+		botUUID, userID,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	answers, err := selectAnswers(ctx, tx, botUUID, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	return mapParticipantFromDB(row, answers)
+}
+
 func upsertParticipant(ctx context.Context, ex sqlx.ExtContext, prt *bots.Participant) error {
 	res, err := sqlx.NamedExecContext(ctx, ex,
 		`INSERT INTO participants 
-			(bot_uuid, user_id, state)
-		 VALUES (:bot_uuid, :user_id, :state)
+			(bot_uuid, user_id, state, entry_key, flow_entry, flow_started_at, locale,
+			 last_activity_at, reminders_sent, paused, blocked)
+		 VALUES (:bot_uuid, :user_id, :state, :entry_key, :flow_entry, :flow_started_at, :locale,
+			 :last_activity_at, :reminders_sent, :paused, :blocked)
 		 ON CONFLICT ( bot_uuid, user_id )
-			DO UPDATE SET state = EXCLUDED.state`,
+			DO UPDATE SET state = EXCLUDED.state, entry_key = EXCLUDED.entry_key,
+			              flow_entry = EXCLUDED.flow_entry, flow_started_at = EXCLUDED.flow_started_at,
+			              locale = EXCLUDED.locale,
+			              last_activity_at = EXCLUDED.last_activity_at, reminders_sent = EXCLUDED.reminders_sent,
+			              paused = EXCLUDED.paused, blocked = EXCLUDED.blocked`,
 		mapParticipantToDB(prt),
 	)
 	if err != nil {
@@ -135,7 +361,8 @@ func selectAnswers(
 ) ([]bots.Answer, error) {
 	var rows []answerRow
 	err := sqlx.SelectContext(ctx, q, &rows,
-		`SELECT bot_uuid, user_id, state, text
+		`SELECT bot_uuid, user_id, state, text,
+		        upload_type, telegram_file_id, file_id, file_name, mime_type, file_size
 	     FROM   answers
 		 WHERE  bot_uuid = $1 AND user_id = $2`,
 		botUUID, userID,
@@ -147,15 +374,44 @@ func selectAnswers(
 	return mapAnswersFromDB(rows)
 }
 
+func deleteStaleAnswers(
+	ctx context.Context, ex sqlx.ExecerContext, botUUID string, userID int64, answers []bots.Answer,
+) error {
+	states := make([]int64, 0, len(answers))
+	for _, ans := range answers {
+		states = append(states, int64(ans.State))
+	}
+
+	_, err := ex.ExecContext(ctx,
+		`DELETE FROM answers
+		 WHERE  bot_uuid = $1 AND user_id = $2 AND NOT ( state = ANY($3) )`,
+		botUUID, userID, pq.Array(states),
+	)
+	return err
+}
+
 func upsertAnswer(ctx context.Context, ex sqlx.ExtContext, botUUID string, userID int64, ans bots.Answer) error {
 	res, err := sqlx.NamedExecContext(ctx, ex,
 		`INSERT INTO answers 
-			(bot_uuid, user_id, state, text)
-		 VALUES (:bot_uuid, :user_id, :state, :text)
+			(bot_uuid, user_id, state, text, is_unique,
+			 upload_type, telegram_file_id, file_id, file_name, mime_type, file_size)
+		 VALUES (:bot_uuid, :user_id, :state, :text, COALESCE((
+			SELECT is_unique
+			FROM   blocks
+			WHERE  bot_uuid = :bot_uuid AND state = :state
+		 ), FALSE),
+			 :upload_type, :telegram_file_id, :file_id, :file_name, :mime_type, :file_size)
 		 ON CONFLICT ( bot_uuid, user_id, state )
-			DO UPDATE SET text = EXCLUDED.text`,
+			DO UPDATE SET text = EXCLUDED.text, is_unique = EXCLUDED.is_unique,
+			              upload_type = EXCLUDED.upload_type, telegram_file_id = EXCLUDED.telegram_file_id,
+			              file_id = EXCLUDED.file_id, file_name = EXCLUDED.file_name,
+			              mime_type = EXCLUDED.mime_type, file_size = EXCLUDED.file_size`,
 		mapAnswerToDB(botUUID, userID, ans),
 	)
+	var pqErr *pq.Error
+	if errors.As(err, &pqErr) && pqErr.Code == uniqueViolationCode && pqErr.Constraint == answersUniqueTextIndex {
+		return bots.AnswerNotUniqueError{State: ans.State, Text: ans.Text}
+	}
 	if err != nil {
 		return err
 	}
@@ -166,7 +422,7 @@ func upsertAnswer(ctx context.Context, ex sqlx.ExtContext, botUUID string, userI
 func mapAnswersFromDB(rows []answerRow) ([]bots.Answer, error) {
 	res := make([]bots.Answer, len(rows))
 	for i, row := range rows {
-		a, err := bots.NewAnswer(row.State, row.Text)
+		a, err := mapAnswerFromDB(row)
 		if err != nil {
 			return nil, err
 		}
@@ -190,9 +446,18 @@ func checkInsertResult(res sql.Result) error {
 
 func mapParticipantToDB(prt *bots.Participant) participantRow {
 	return participantRow{
-		BotUUID: prt.BotUUID,
-		UserID:  prt.UserID,
-		State:   nilOnZero(prt.State),
+		BotUUID:   prt.BotUUID,
+		UserID:    prt.UserID,
+		State:     nilOnZero(prt.State),
+		EntryKey:  nilOnEmpty(prt.EntryKey),
+		FlowEntry: nilOnEmpty(prt.FlowEntry),
+		Locale:    nilOnEmpty(prt.Locale),
+		Paused:    prt.IsPaused(),
+		Blocked:   prt.IsBlocked(),
+
+		FlowStartedAt:  nilOnZeroTime(prt.FlowStartedAt),
+		LastActivityAt: nilOnZeroTime(prt.LastActivityAt),
+		RemindersSent:  prt.RemindersSent,
 	}
 }
 
@@ -201,23 +466,74 @@ func mapParticipantFromDB(row participantRow, as []bots.Answer) (*bots.Participa
 		row.BotUUID,
 		row.UserID,
 		zeroOnNil(row.State),
+		emptyOnNil(row.EntryKey),
+		emptyOnNil(row.FlowEntry),
+		zeroTimeOnNil(row.FlowStartedAt),
+		emptyOnNil(row.Locale),
+		zeroTimeOnNil(row.LastActivityAt),
+		row.RemindersSent,
+		row.Paused,
+		row.Blocked,
 		as,
 	)
 }
 
 type participantRow struct {
-	BotUUID string `db:"bot_uuid"`
-	UserID  int64  `db:"user_id"`
-	State   *int   `db:"state"`
+	BotUUID   string  `db:"bot_uuid"`
+	UserID    int64   `db:"user_id"`
+	State     *int    `db:"state"`
+	EntryKey  *string `db:"entry_key"`
+	FlowEntry *string `db:"flow_entry"`
+	Locale    *string `db:"locale"`
+	Paused    bool    `db:"paused"`
+	Blocked   bool    `db:"blocked"`
+
+	FlowStartedAt  *time.Time `db:"flow_started_at"`
+	LastActivityAt *time.Time `db:"last_activity_at"`
+	RemindersSent  int        `db:"reminders_sent"`
 }
 
 func mapAnswerToDB(botUUID string, userID int64, a bots.Answer) answerRow {
-	return answerRow{
+	row := answerRow{
 		BotUUID: botUUID,
 		UserID:  userID,
 		State:   a.State,
 		Text:    a.Text,
 	}
+	if a.IsUpload() {
+		row.UploadType = nilOnEmpty(a.Upload.Type.String())
+		row.TelegramFileID = nilOnEmpty(a.Upload.TelegramFileID)
+		row.FileID = nilOnEmpty(a.Upload.FileID)
+		row.FileName = nilOnEmpty(a.Upload.Name)
+		row.MimeType = nilOnEmpty(a.Upload.MimeType)
+		row.FileSize = &a.Upload.Size
+	}
+	return row
+}
+
+func mapAnswerFromDB(row answerRow) (bots.Answer, error) {
+	if row.UploadType == nil {
+		return bots.NewAnswer(row.State, row.Text)
+	}
+
+	var size int64
+	if row.FileSize != nil {
+		size = *row.FileSize
+	}
+
+	upload, err := bots.NewUpload(
+		*row.UploadType,
+		emptyOnNil(row.TelegramFileID),
+		emptyOnNil(row.FileID),
+		emptyOnNil(row.FileName),
+		emptyOnNil(row.MimeType),
+		size,
+	)
+	if err != nil {
+		return bots.Answer{}, err
+	}
+
+	return bots.NewUploadAnswer(row.State, upload)
 }
 
 type answerRow struct {
@@ -225,4 +541,11 @@ type answerRow struct {
 	UserID  int64  `db:"user_id"`
 	State   int    `db:"state"`
 	Text    string `db:"text"`
+
+	UploadType     *string `db:"upload_type"`
+	TelegramFileID *string `db:"telegram_file_id"`
+	FileID         *string `db:"file_id"`
+	FileName       *string `db:"file_name"`
+	MimeType       *string `db:"mime_type"`
+	FileSize       *int64  `db:"file_size"`
 }
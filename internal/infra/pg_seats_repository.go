@@ -0,0 +1,174 @@
+package infra
+
+import (
+	"context"
+
+	"github.com/jmoiron/sqlx"
+	"github.com/zhikh23/pgutils"
+
+	"github.com/bmstu-itstech/itsreg-bots/internal/common/tracing"
+	"github.com/bmstu-itstech/itsreg-bots/internal/domain/bots"
+)
+
+type pgSeatsRepository struct {
+	db *sqlx.DB
+}
+
+func NewPgSeatsRepository(db *sqlx.DB) bots.SeatsRepository {
+	return &pgSeatsRepository{
+		db: db,
+	}
+}
+
+func (r *pgSeatsRepository) Seats(ctx context.Context, botUUID string, entryKey string) (*bots.Seats, error) {
+	ctx, span := tracing.Start(ctx, "pgSeatsRepository.Seats")
+	defer span.End()
+
+	rows, err := selectSeats(ctx, r.db, botUUID, entryKey)
+	if err != nil {
+		return nil, err
+	}
+	return convertSeatsFromDB(botUUID, entryKey, rows)
+}
+
+func (r *pgSeatsRepository) UserEntries(ctx context.Context, botUUID string, userID int64) ([]string, error) {
+	ctx, span := tracing.Start(ctx, "pgSeatsRepository.UserEntries")
+	defer span.End()
+
+	var keys []string
+	err := pgutils.Select(ctx, r.db, &keys,
+		`SELECT entry_key
+		 FROM   seats
+		 WHERE  bot_uuid = $1 AND user_id = $2
+		 ORDER  BY entry_key`,
+		botUUID, userID,
+	)
+	if err != nil {
+		return nil, err
+	}
+	return keys, nil
+}
+
+func (r *pgSeatsRepository) UpdateSeats(
+	ctx context.Context,
+	botUUID string,
+	entryKey string,
+	updateFn func(innerCtx context.Context, seats *bots.Seats) error,
+) error {
+	ctx, span := tracing.Start(ctx, "pgSeatsRepository.UpdateSeats")
+	defer span.End()
+
+	return pgutils.RunTx(ctx, r.db, func(tx *sqlx.Tx) error {
+		_, err := tx.ExecContext(ctx,
+			`SELECT pg_advisory_xact_lock(hashtext($1), hashtext($2))`,
+			botUUID, entryKey,
+		)
+		if err != nil {
+			return err
+		}
+
+		rows, err := selectSeats(ctx, tx, botUUID, entryKey)
+		if err != nil {
+			return err
+		}
+
+		seats, err := convertSeatsFromDB(botUUID, entryKey, rows)
+		if err != nil {
+			return err
+		}
+
+		err = updateFn(ctx, seats)
+		if err != nil {
+			return err
+		}
+
+		_, err = tx.ExecContext(ctx,
+			`DELETE FROM seats
+			 WHERE  bot_uuid = $1 AND entry_key = $2`,
+			botUUID, entryKey,
+		)
+		if err != nil {
+			return err
+		}
+
+		rows = convertSeatsToDB(seats)
+		if len(rows) == 0 {
+			return nil
+		}
+
+		_, err = tx.NamedExecContext(ctx,
+			`INSERT INTO seats
+				(bot_uuid, entry_key, user_id, status, position)
+			 VALUES (:bot_uuid, :entry_key, :user_id, :status, :position)`,
+			rows,
+		)
+		return err
+	})
+}
+
+func selectSeats(ctx context.Context, q sqlx.QueryerContext, botUUID string, entryKey string) ([]seatRow, error) {
+	var rows []seatRow
+	err := pgutils.Select(ctx, q, &rows,
+		`SELECT bot_uuid, entry_key, user_id, status, position
+		 FROM   seats
+		 WHERE  bot_uuid = $1 AND entry_key = $2
+		 ORDER  BY position`,
+		botUUID, entryKey,
+	)
+	if err != nil {
+		return nil, err
+	}
+	return rows, nil
+}
+
+type seatRow struct {
+	BotUUID  string `db:"bot_uuid"`
+	EntryKey string `db:"entry_key"`
+	UserID   int64  `db:"user_id"`
+	Status   string `db:"status"`
+	Position int    `db:"position"`
+}
+
+func convertSeatsToDB(seats *bots.Seats) []seatRow {
+	registered := seats.Registered()
+	waitlisted := seats.Waitlisted()
+
+	rows := make([]seatRow, 0, len(registered)+len(waitlisted))
+	for _, userID := range registered {
+		rows = append(rows, seatRow{
+			BotUUID:  seats.BotUUID,
+			EntryKey: seats.EntryKey,
+			UserID:   userID,
+			Status:   bots.SeatRegistered.String(),
+			Position: len(rows),
+		})
+	}
+	for _, userID := range waitlisted {
+		rows = append(rows, seatRow{
+			BotUUID:  seats.BotUUID,
+			EntryKey: seats.EntryKey,
+			UserID:   userID,
+			Status:   bots.SeatWaitlisted.String(),
+			Position: len(rows),
+		})
+	}
+	return rows
+}
+
+func convertSeatsFromDB(botUUID string, entryKey string, rows []seatRow) (*bots.Seats, error) {
+	registered := make([]int64, 0, len(rows))
+	waitlisted := make([]int64, 0)
+	for _, row := range rows {
+		status, err := bots.NewSeatStatusFromString(row.Status)
+		if err != nil {
+			return nil, err
+		}
+		switch status {
+		case bots.SeatRegistered:
+			registered = append(registered, row.UserID)
+		case bots.SeatWaitlisted:
+			waitlisted = append(waitlisted, row.UserID)
+		}
+	}
+	return bots.UnmarshallSeatsFromDB(botUUID, entryKey, registered, waitlisted)
+}
@@ -0,0 +1,145 @@
+package infra
+
+import (
+	"context"
+	"time"
+
+	"github.com/jmoiron/sqlx"
+	"github.com/zhikh23/pgutils"
+
+	"github.com/bmstu-itstech/itsreg-bots/internal/common/tracing"
+	"github.com/bmstu-itstech/itsreg-bots/internal/domain/bots"
+)
+
+type pgThreadsRepository struct {
+	db *sqlx.DB
+}
+
+func NewPgThreadsRepository(db *sqlx.DB) bots.ThreadRepository {
+	return &pgThreadsRepository{
+		db: db,
+	}
+}
+
+func (r *pgThreadsRepository) AddMessage(ctx context.Context, msg bots.ThreadMessage) error {
+	ctx, span := tracing.Start(ctx, "pgThreadsRepository.AddMessage")
+	defer span.End()
+
+	res, err := sqlx.NamedExecContext(ctx, r.db,
+		`INSERT INTO thread_messages
+			(bot_uuid, user_id, direction, text, author_uuid, created_at)
+		 VALUES (:bot_uuid, :user_id, :direction, :text, :author_uuid, :created_at)`,
+		convertThreadMessageToDB(msg),
+	)
+	if err != nil {
+		return err
+	}
+
+	return checkInsertResult(res)
+}
+
+func (r *pgThreadsRepository) Threads(ctx context.Context, botUUID string) ([]bots.Thread, error) {
+	ctx, span := tracing.Start(ctx, "pgThreadsRepository.Threads")
+	defer span.End()
+
+	var rows []threadRow
+	err := pgutils.Select(ctx, r.db, &rows,
+		`SELECT DISTINCT ON ( m.user_id )
+		        m.bot_uuid, m.user_id, m.direction, m.text, m.author_uuid, m.created_at, p.paused
+		 FROM   thread_messages m
+		 JOIN   participants p ON p.bot_uuid = m.bot_uuid AND p.user_id = m.user_id
+		 WHERE  m.bot_uuid = $1
+		 ORDER  BY m.user_id, m.id DESC`,
+		botUUID,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	res := make([]bots.Thread, len(rows))
+	for i, row := range rows {
+		msg, err := convertThreadMessageFromDB(row.threadMessageRow)
+		if err != nil {
+			return nil, err
+		}
+		res[i] = bots.Thread{
+			BotUUID:     row.BotUUID,
+			UserID:      row.UserID,
+			Paused:      row.Paused,
+			LastMessage: msg,
+		}
+	}
+
+	return res, nil
+}
+
+func (r *pgThreadsRepository) Messages(ctx context.Context, botUUID string, userID int64) ([]bots.ThreadMessage, error) {
+	ctx, span := tracing.Start(ctx, "pgThreadsRepository.Messages")
+	defer span.End()
+
+	var rows []threadMessageRow
+	err := pgutils.Select(ctx, r.db, &rows,
+		`SELECT bot_uuid, user_id, direction, text, author_uuid, created_at
+		 FROM   thread_messages
+		 WHERE  bot_uuid = $1 AND user_id = $2
+		 ORDER  BY id`,
+		botUUID, userID,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	res := make([]bots.ThreadMessage, len(rows))
+	for i, row := range rows {
+		res[i], err = convertThreadMessageFromDB(row)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return res, nil
+}
+
+type threadMessageRow struct {
+	BotUUID    string    `db:"bot_uuid"`
+	UserID     int64     `db:"user_id"`
+	Direction  string    `db:"direction"`
+	Text       string    `db:"text"`
+	AuthorUUID *string   `db:"author_uuid"`
+	CreatedAt  time.Time `db:"created_at"`
+}
+
+type threadRow struct {
+	threadMessageRow
+	Paused bool `db:"paused"`
+}
+
+func convertThreadMessageToDB(msg bots.ThreadMessage) threadMessageRow {
+	row := threadMessageRow{
+		BotUUID:   msg.BotUUID,
+		UserID:    msg.UserID,
+		Direction: msg.Direction.String(),
+		Text:      msg.Text,
+		CreatedAt: msg.CreatedAt.UTC(),
+	}
+	if msg.AuthorUUID != "" {
+		row.AuthorUUID = &msg.AuthorUUID
+	}
+	return row
+}
+
+func convertThreadMessageFromDB(row threadMessageRow) (bots.ThreadMessage, error) {
+	var authorUUID string
+	if row.AuthorUUID != nil {
+		authorUUID = *row.AuthorUUID
+	}
+
+	return bots.UnmarshallThreadMessageFromDB(
+		row.BotUUID,
+		row.UserID,
+		row.Direction,
+		row.Text,
+		authorUUID,
+		row.CreatedAt.Local(),
+	)
+}
@@ -0,0 +1,136 @@
+package infra
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"time"
+
+	"github.com/jmoiron/sqlx"
+	"github.com/zhikh23/pgutils"
+
+	"github.com/bmstu-itstech/itsreg-bots/internal/common/tracing"
+	"github.com/bmstu-itstech/itsreg-bots/internal/domain/bots"
+)
+
+type pgTicketsRepository struct {
+	db *sqlx.DB
+}
+
+func NewPgTicketsRepository(db *sqlx.DB) bots.TicketRepository {
+	return &pgTicketsRepository{
+		db: db,
+	}
+}
+
+func (r *pgTicketsRepository) Tickets(ctx context.Context, botUUID string) ([]*bots.Ticket, error) {
+	ctx, span := tracing.Start(ctx, "pgTicketsRepository.Tickets")
+	defer span.End()
+
+	var rows []ticketRow
+	err := pgutils.Select(ctx, r.db, &rows,
+		`SELECT a.bot_uuid, a.user_id, a.text AS code, c.attended_at
+		 FROM   answers a
+		 JOIN   blocks b ON b.bot_uuid = a.bot_uuid AND b.state = a.state
+		 LEFT   JOIN check_ins c ON c.bot_uuid = a.bot_uuid AND c.code = a.text
+		 WHERE  a.bot_uuid = $1 AND b.type = 'ticket'`,
+		botUUID,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	res := make([]*bots.Ticket, len(rows))
+	for i, row := range rows {
+		res[i], err = convertTicketFromDB(row)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return res, nil
+}
+
+func (r *pgTicketsRepository) UpdateTicket(
+	ctx context.Context,
+	botUUID string,
+	code string,
+	updateFn func(innerCtx context.Context, ticket *bots.Ticket) error,
+) error {
+	ctx, span := tracing.Start(ctx, "pgTicketsRepository.UpdateTicket")
+	defer span.End()
+
+	return pgutils.RunTx(ctx, r.db, func(tx *sqlx.Tx) error {
+		var row ticketRow
+		err := pgutils.Get(ctx, tx, &row,
+			`SELECT a.bot_uuid, a.user_id, a.text AS code, c.attended_at
+			 FROM   answers a
+			 JOIN   blocks b ON b.bot_uuid = a.bot_uuid AND b.state = a.state
+			 LEFT   JOIN check_ins c ON c.bot_uuid = a.bot_uuid AND c.code = a.text
+			 WHERE  a.bot_uuid = $1 AND a.text = $2 AND b.type = 'ticket'`,
+			botUUID, code,
+		)
+		if errors.Is(err, sql.ErrNoRows) {
+			return bots.TicketNotFoundError{Code: code}
+		} else if err != nil {
+			return err
+		}
+
+		ticket, err := convertTicketFromDB(row)
+		if err != nil {
+			return err
+		}
+
+		err = updateFn(ctx, ticket)
+		if err != nil {
+			return err
+		}
+
+		if !ticket.IsAttended() || row.AttendedAt != nil {
+			return nil
+		}
+
+		res, err := tx.ExecContext(ctx,
+			`INSERT INTO check_ins
+				(bot_uuid, code, user_id, attended_at)
+			 VALUES ($1, $2, $3, $4)
+			 ON CONFLICT ( bot_uuid, code ) DO NOTHING`,
+			ticket.BotUUID, ticket.Code, ticket.UserID, ticket.AttendedAt.UTC(),
+		)
+		if err != nil {
+			return err
+		}
+
+		aff, err := res.RowsAffected()
+		if err != nil {
+			return err
+		}
+		if aff > 0 {
+			return nil
+		}
+
+		var attendedAt time.Time
+		err = pgutils.Get(ctx, tx, &attendedAt,
+			`SELECT attended_at
+			 FROM   check_ins
+			 WHERE  bot_uuid = $1 AND code = $2`,
+			botUUID, code,
+		)
+		if err != nil {
+			return err
+		}
+
+		return bots.TicketAlreadyUsedError{Code: code, AttendedAt: attendedAt.Local()}
+	})
+}
+
+type ticketRow struct {
+	BotUUID    string     `db:"bot_uuid"`
+	UserID     int64      `db:"user_id"`
+	Code       string     `db:"code"`
+	AttendedAt *time.Time `db:"attended_at"`
+}
+
+func convertTicketFromDB(row ticketRow) (*bots.Ticket, error) {
+	return bots.UnmarshallTicketFromDB(row.BotUUID, row.UserID, row.Code, zeroTimeOnNil(row.AttendedAt))
+}
@@ -0,0 +1,141 @@
+package infra
+
+import (
+	"context"
+
+	"github.com/jmoiron/sqlx"
+	"github.com/zhikh23/pgutils"
+
+	"github.com/bmstu-itstech/itsreg-bots/internal/common/tracing"
+	"github.com/bmstu-itstech/itsreg-bots/internal/domain/bots"
+)
+
+type botTokenRow struct {
+	UUID            string  `db:"uuid"`
+	Token           *string `db:"token"`
+	TokenCiphertext []byte  `db:"token_ciphertext"`
+	TokenKey        []byte  `db:"token_key"`
+	TokenKeyID      *string `db:"token_key_id"`
+	TokenMask       *string `db:"token_mask"`
+}
+
+// ReencryptBotTokens seals tokens stored in plaintext and rewraps data keys of
+// tokens sealed with inactive keys. It returns the number of updated bots.
+func ReencryptBotTokens(ctx context.Context, db *sqlx.DB, cipher bots.TokenCipher) (int, error) {
+	ctx, span := tracing.Start(ctx, "ReencryptBotTokens")
+	defer span.End()
+
+	updated := 0
+	err := pgutils.RunTx(ctx, db, func(tx *sqlx.Tx) error {
+		var rows []botTokenRow
+		if err := pgutils.Select(ctx, tx, &rows,
+			`SELECT uuid, token, token_ciphertext, token_key, token_key_id, token_mask
+			 FROM   bots
+			 FOR UPDATE`,
+		); err != nil {
+			return err
+		}
+
+		for _, row := range rows {
+			var sealed bots.SealedToken
+			mask := emptyOnNil(row.TokenMask)
+
+			if row.Token != nil && len(row.TokenCiphertext) == 0 {
+				var err error
+				sealed, err = cipher.Seal(*row.Token)
+				if err != nil {
+					return err
+				}
+				mask = bots.MaskToken(*row.Token)
+			} else {
+				current := bots.SealedToken{
+					KeyID:        emptyOnNil(row.TokenKeyID),
+					EncryptedKey: row.TokenKey,
+					Ciphertext:   row.TokenCiphertext,
+				}
+
+				var err error
+				sealed, err = cipher.Rotate(current)
+				if err != nil {
+					return err
+				}
+
+				if sealed.KeyID == current.KeyID {
+					continue
+				}
+			}
+
+			if _, err := tx.ExecContext(ctx,
+				`UPDATE bots
+				 SET    token = NULL,
+				        token_ciphertext = $2,
+				        token_key = $3,
+				        token_key_id = $4,
+				        token_mask = $5
+				 WHERE  uuid = $1`,
+				row.UUID, sealed.Ciphertext, sealed.EncryptedKey, sealed.KeyID, mask,
+			); err != nil {
+				return err
+			}
+			updated++
+		}
+
+		return nil
+	})
+	if err != nil {
+		return 0, err
+	}
+
+	return updated, nil
+}
+
+// DecryptBotTokens writes plaintext tokens back to the legacy token column, so
+// migration 026 can be rolled back. It returns the number of updated bots.
+func DecryptBotTokens(ctx context.Context, db *sqlx.DB, cipher bots.TokenCipher) (int, error) {
+	ctx, span := tracing.Start(ctx, "DecryptBotTokens")
+	defer span.End()
+
+	updated := 0
+	err := pgutils.RunTx(ctx, db, func(tx *sqlx.Tx) error {
+		var rows []botTokenRow
+		if err := pgutils.Select(ctx, tx, &rows,
+			`SELECT uuid, token, token_ciphertext, token_key, token_key_id, token_mask
+			 FROM   bots
+			 WHERE  token_ciphertext IS NOT NULL
+			 FOR UPDATE`,
+		); err != nil {
+			return err
+		}
+
+		for _, row := range rows {
+			token, err := cipher.Open(bots.SealedToken{
+				KeyID:        emptyOnNil(row.TokenKeyID),
+				EncryptedKey: row.TokenKey,
+				Ciphertext:   row.TokenCiphertext,
+			})
+			if err != nil {
+				return err
+			}
+
+			if _, err = tx.ExecContext(ctx,
+				`UPDATE bots
+				 SET    token = $2,
+				        token_ciphertext = NULL,
+				        token_key = NULL,
+				        token_key_id = NULL
+				 WHERE  uuid = $1`,
+				row.UUID, token,
+			); err != nil {
+				return err
+			}
+			updated++
+		}
+
+		return nil
+	})
+	if err != nil {
+		return 0, err
+	}
+
+	return updated, nil
+}
@@ -0,0 +1,108 @@
+package infra
+
+import (
+	"context"
+	"encoding/json"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jmoiron/sqlx"
+
+	"github.com/bmstu-itstech/itsreg-bots/internal/domain/bots"
+)
+
+type pgWebhookEventPublisher struct {
+	db *sqlx.DB
+}
+
+func NewPgWebhookEventPublisher(db *sqlx.DB) bots.EventPublisher {
+	return &pgWebhookEventPublisher{
+		db: db,
+	}
+}
+
+func (p *pgWebhookEventPublisher) Publish(ctx context.Context, event bots.Event) error {
+	webhooks, err := selectWebhooks(ctx, p.db,
+		`SELECT w.uuid, w.bot_uuid, w.url, w.secret, w.created_at
+		 FROM   webhooks w
+		 JOIN   webhook_events e ON e.webhook_uuid = w.uuid
+		 WHERE  w.bot_uuid = $1 AND e.event = $2`,
+		event.BotUUID, event.Type.String(),
+	)
+	if err != nil {
+		return err
+	}
+
+	if len(webhooks) == 0 {
+		return nil
+	}
+
+	payload, err := json.Marshal(mapEventToWebhookPayload(event))
+	if err != nil {
+		return err
+	}
+
+	now := time.Now().UTC()
+	rows := make([]webhookDeliveryInsertRow, len(webhooks))
+	for i, w := range webhooks {
+		rows[i] = webhookDeliveryInsertRow{
+			UUID:          uuid.NewString(),
+			WebhookUUID:   w.UUID,
+			Event:         event.Type.String(),
+			Payload:       string(payload),
+			Status:        bots.DeliveryPending.String(),
+			NextAttemptAt: now,
+			CreatedAt:     now,
+			UpdatedAt:     now,
+		}
+	}
+
+	res, err := p.db.NamedExecContext(ctx,
+		`INSERT INTO webhook_deliveries
+			(uuid, webhook_uuid, event, payload, status, next_attempt_at, created_at, updated_at)
+		 VALUES (:uuid, :webhook_uuid, :event, :payload, :status, :next_attempt_at, :created_at, :updated_at)`,
+		rows,
+	)
+	if err != nil {
+		return err
+	}
+
+	return checkInsertResult(res)
+}
+
+type webhookDeliveryInsertRow struct {
+	UUID          string    `db:"uuid"`
+	WebhookUUID   string    `db:"webhook_uuid"`
+	Event         string    `db:"event"`
+	Payload       string    `db:"payload"`
+	Status        string    `db:"status"`
+	NextAttemptAt time.Time `db:"next_attempt_at"`
+	CreatedAt     time.Time `db:"created_at"`
+	UpdatedAt     time.Time `db:"updated_at"`
+}
+
+type webhookPayload struct {
+	Type       string    `json:"type"`
+	BotUUID    string    `json:"bot_uuid"`
+	UserID     int64     `json:"user_id,omitempty"`
+	EntryKey   string    `json:"entry_key,omitempty"`
+	State      int       `json:"state,omitempty"`
+	Answer     string    `json:"answer,omitempty"`
+	Recipients int       `json:"recipients,omitempty"`
+	Reason     string    `json:"reason,omitempty"`
+	OccurredAt time.Time `json:"occurred_at"`
+}
+
+func mapEventToWebhookPayload(e bots.Event) webhookPayload {
+	return webhookPayload{
+		Type:       e.Type.String(),
+		BotUUID:    e.BotUUID,
+		UserID:     e.UserID,
+		EntryKey:   e.EntryKey,
+		State:      e.Answer.State,
+		Answer:     e.Answer.Text,
+		Recipients: e.Recipients,
+		Reason:     e.Reason,
+		OccurredAt: e.OccurredAt.UTC(),
+	}
+}
@@ -0,0 +1,215 @@
+package infra
+
+import (
+	"context"
+	"time"
+
+	"github.com/jmoiron/sqlx"
+	"github.com/zhikh23/pgutils"
+
+	"github.com/bmstu-itstech/itsreg-bots/internal/common/tracing"
+	"github.com/bmstu-itstech/itsreg-bots/internal/domain/bots"
+)
+
+type pgWebhooksRepository struct {
+	db *sqlx.DB
+}
+
+func NewPgWebhooksRepository(db *sqlx.DB) bots.WebhookRepository {
+	return &pgWebhooksRepository{
+		db: db,
+	}
+}
+
+func (r *pgWebhooksRepository) Create(ctx context.Context, webhook bots.Webhook) error {
+	ctx, span := tracing.Start(ctx, "pgWebhooksRepository.Create")
+	defer span.End()
+
+	return pgutils.RunTx(ctx, r.db, func(tx *sqlx.Tx) error {
+		res, err := tx.NamedExecContext(ctx,
+			`INSERT INTO webhooks
+				(uuid, bot_uuid, url, secret, created_at)
+			 VALUES (:uuid, :bot_uuid, :url, :secret, :created_at)`,
+			convertWebhookToDB(webhook),
+		)
+		if err != nil {
+			return err
+		}
+		if err = checkInsertResult(res); err != nil {
+			return err
+		}
+
+		res, err = tx.NamedExecContext(ctx,
+			`INSERT INTO webhook_events
+				(webhook_uuid, event)
+			 VALUES (:webhook_uuid, :event)`,
+			convertWebhookEventsToDB(webhook),
+		)
+		if err != nil {
+			return err
+		}
+
+		return checkInsertResult(res)
+	})
+}
+
+func (r *pgWebhooksRepository) Delete(ctx context.Context, botUUID string, uuid string) error {
+	ctx, span := tracing.Start(ctx, "pgWebhooksRepository.Delete")
+	defer span.End()
+
+	res, err := r.db.ExecContext(ctx,
+		`DELETE FROM webhooks WHERE bot_uuid = $1 AND uuid = $2`, botUUID, uuid,
+	)
+	if err != nil {
+		return err
+	}
+
+	aff, err := res.RowsAffected()
+	if err != nil {
+		return err
+	}
+
+	if aff == 0 {
+		return bots.WebhookNotFoundError{UUID: uuid}
+	}
+
+	return nil
+}
+
+func (r *pgWebhooksRepository) BotWebhooks(ctx context.Context, botUUID string) ([]bots.Webhook, error) {
+	ctx, span := tracing.Start(ctx, "pgWebhooksRepository.BotWebhooks")
+	defer span.End()
+
+	return selectWebhooks(ctx, r.db,
+		`SELECT uuid, bot_uuid, url, secret, created_at
+		 FROM   webhooks
+		 WHERE  bot_uuid = $1
+		 ORDER  BY created_at`, botUUID,
+	)
+}
+
+func (r *pgWebhooksRepository) Deliveries(
+	ctx context.Context,
+	botUUID string,
+	webhookUUID string,
+) ([]bots.WebhookDelivery, error) {
+	ctx, span := tracing.Start(ctx, "pgWebhooksRepository.Deliveries")
+	defer span.End()
+
+	var exists bool
+	if err := pgutils.Get(ctx, r.db, &exists,
+		`SELECT EXISTS(SELECT 1 FROM webhooks WHERE bot_uuid = $1 AND uuid = $2)`,
+		botUUID, webhookUUID,
+	); err != nil {
+		return nil, err
+	}
+	if !exists {
+		return nil, bots.WebhookNotFoundError{UUID: webhookUUID}
+	}
+
+	var rows []webhookDeliveryRow
+	if err := pgutils.Select(ctx, r.db, &rows,
+		`SELECT uuid, webhook_uuid, event, status, attempts, response_code, last_error, created_at, updated_at
+		 FROM   webhook_deliveries
+		 WHERE  webhook_uuid = $1
+		 ORDER  BY created_at DESC
+		 LIMIT  100`, webhookUUID,
+	); err != nil {
+		return nil, err
+	}
+
+	res := make([]bots.WebhookDelivery, len(rows))
+	for i, row := range rows {
+		d, err := convertWebhookDeliveryToDomain(row)
+		if err != nil {
+			return nil, err
+		}
+		res[i] = d
+	}
+	return res, nil
+}
+
+func selectWebhooks(ctx context.Context, q sqlx.QueryerContext, query string, args ...any) ([]bots.Webhook, error) {
+	var rows []webhookRow
+	if err := pgutils.Select(ctx, q, &rows, query, args...); err != nil {
+		return nil, err
+	}
+
+	res := make([]bots.Webhook, len(rows))
+	for i, row := range rows {
+		var events []string
+		if err := pgutils.Select(ctx, q, &events,
+			`SELECT event FROM webhook_events WHERE webhook_uuid = $1`, row.UUID,
+		); err != nil {
+			return nil, err
+		}
+
+		w, err := bots.UnmarshallWebhookFromDB(
+			row.UUID, row.BotUUID, row.URL, row.Secret, events, row.CreatedAt.Local(),
+		)
+		if err != nil {
+			return nil, err
+		}
+		res[i] = w
+	}
+	return res, nil
+}
+
+type webhookRow struct {
+	UUID      string    `db:"uuid"`
+	BotUUID   string    `db:"bot_uuid"`
+	URL       string    `db:"url"`
+	Secret    string    `db:"secret"`
+	CreatedAt time.Time `db:"created_at"`
+}
+
+func convertWebhookToDB(w bots.Webhook) webhookRow {
+	return webhookRow{
+		UUID:      w.UUID,
+		BotUUID:   w.BotUUID,
+		URL:       w.URL,
+		Secret:    w.Secret,
+		CreatedAt: w.CreatedAt.UTC(),
+	}
+}
+
+type webhookEventRow struct {
+	WebhookUUID string `db:"webhook_uuid"`
+	Event       string `db:"event"`
+}
+
+func convertWebhookEventsToDB(w bots.Webhook) []webhookEventRow {
+	res := make([]webhookEventRow, len(w.Events))
+	for i, e := range w.Events {
+		res[i] = webhookEventRow{
+			WebhookUUID: w.UUID,
+			Event:       e.String(),
+		}
+	}
+	return res
+}
+
+type webhookDeliveryRow struct {
+	UUID         string    `db:"uuid"`
+	WebhookUUID  string    `db:"webhook_uuid"`
+	Event        string    `db:"event"`
+	Status       string    `db:"status"`
+	Attempts     int       `db:"attempts"`
+	ResponseCode *int      `db:"response_code"`
+	LastError    *string   `db:"last_error"`
+	CreatedAt    time.Time `db:"created_at"`
+	UpdatedAt    time.Time `db:"updated_at"`
+}
+
+func convertWebhookDeliveryToDomain(row webhookDeliveryRow) (bots.WebhookDelivery, error) {
+	lastError := ""
+	if row.LastError != nil {
+		lastError = *row.LastError
+	}
+
+	return bots.UnmarshallWebhookDeliveryFromDB(
+		row.UUID, row.WebhookUUID, row.Event, row.Status,
+		row.Attempts, zeroOnNil(row.ResponseCode), lastError,
+		row.CreatedAt.Local(), row.UpdatedAt.Local(),
+	)
+}
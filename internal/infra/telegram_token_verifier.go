@@ -0,0 +1,82 @@
+package infra
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/bmstu-itstech/itsreg-bots/internal/common/tracing"
+	"github.com/bmstu-itstech/itsreg-bots/internal/domain/bots"
+)
+
+const (
+	defaultTelegramAPIURL = "https://api.telegram.org"
+	verifyTimeout         = 10 * time.Second
+)
+
+type telegramTokenVerifier struct {
+	apiURL string
+	client *http.Client
+}
+
+func NewTelegramTokenVerifier() bots.TokenVerifier {
+	apiURL := os.Getenv("TELEGRAM_API_URL")
+	if apiURL == "" {
+		apiURL = defaultTelegramAPIURL
+	}
+	return NewTelegramTokenVerifierAt(apiURL)
+}
+
+func NewTelegramTokenVerifierAt(apiURL string) bots.TokenVerifier {
+	return &telegramTokenVerifier{
+		apiURL: strings.TrimRight(apiURL, "/"),
+		client: &http.Client{Timeout: verifyTimeout},
+	}
+}
+
+type getMeResponse struct {
+	OK     bool `json:"ok"`
+	Result struct {
+		ID       int64  `json:"id"`
+		Username string `json:"username"`
+	} `json:"result"`
+	ErrorCode   int    `json:"error_code"`
+	Description string `json:"description"`
+}
+
+func (v *telegramTokenVerifier) Verify(ctx context.Context, token string) (_ bots.TelegramIdentity, err error) {
+	ctx, span := tracing.Start(ctx, "telegramTokenVerifier.Verify")
+	defer func() { tracing.End(span, err) }()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, fmt.Sprintf("%s/bot%s/getMe", v.apiURL, token), nil)
+	if err != nil {
+		return bots.TelegramIdentity{}, err
+	}
+
+	res, err := v.client.Do(req)
+	if err != nil {
+		return bots.TelegramIdentity{}, fmt.Errorf("failed to verify token: %w", bots.RedactToken(err, token))
+	}
+	defer res.Body.Close()
+
+	var body getMeResponse
+	if err = json.NewDecoder(res.Body).Decode(&body); err != nil {
+		return bots.TelegramIdentity{}, fmt.Errorf("failed to decode getMe response: %w", err)
+	}
+
+	if !body.OK {
+		if body.ErrorCode == http.StatusUnauthorized || body.ErrorCode == http.StatusNotFound {
+			return bots.TelegramIdentity{}, bots.ErrInvalidToken
+		}
+		return bots.TelegramIdentity{}, fmt.Errorf("failed to verify token: %d %s", body.ErrorCode, body.Description)
+	}
+
+	return bots.TelegramIdentity{
+		ID:       body.Result.ID,
+		Username: body.Result.Username,
+	}, nil
+}
@@ -0,0 +1,51 @@
+package infra_test
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/bmstu-itstech/itsreg-bots/internal/domain/bots"
+	"github.com/bmstu-itstech/itsreg-bots/internal/infra"
+)
+
+func TestTelegramTokenVerifier(t *testing.T) {
+	const token = "1234567890:AAHdqTcvCH1vGWJxfSeofSAs0K5PALDsaw1"
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch r.URL.Path {
+		case "/bot" + token + "/getMe":
+			_, _ = w.Write([]byte(`{"ok":true,"result":{"id":1234567890,"is_bot":true,"username":"itsreg_bot"}}`))
+		case "/botbroken/getMe":
+			w.WriteHeader(http.StatusInternalServerError)
+			_, _ = w.Write([]byte(`{"ok":false,"error_code":500,"description":"Internal Server Error"}`))
+		default:
+			w.WriteHeader(http.StatusUnauthorized)
+			_, _ = w.Write([]byte(`{"ok":false,"error_code":401,"description":"Unauthorized"}`))
+		}
+	}))
+	t.Cleanup(server.Close)
+
+	verifier := infra.NewTelegramTokenVerifierAt(server.URL)
+
+	t.Run("should return bot identity", func(t *testing.T) {
+		identity, err := verifier.Verify(context.Background(), token)
+		require.NoError(t, err)
+		require.Equal(t, bots.TelegramIdentity{ID: 1234567890, Username: "itsreg_bot"}, identity)
+	})
+
+	t.Run("should return invalid token error if telegram rejects token", func(t *testing.T) {
+		_, err := verifier.Verify(context.Background(), "1234567890:revoked")
+		require.ErrorIs(t, err, bots.ErrInvalidToken)
+	})
+
+	t.Run("should return error if telegram is unavailable", func(t *testing.T) {
+		_, err := verifier.Verify(context.Background(), "broken")
+		require.Error(t, err)
+		require.NotErrorIs(t, err, bots.ErrInvalidToken)
+	})
+}
@@ -0,0 +1,240 @@
+package infra
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"log/slog"
+	"net"
+	"net/http"
+	"net/netip"
+	"strconv"
+	"syscall"
+	"time"
+
+	"github.com/jmoiron/sqlx"
+	"github.com/zhikh23/pgutils"
+
+	"github.com/bmstu-itstech/itsreg-bots/internal/domain/bots"
+)
+
+const (
+	webhookDispatchInterval = 5 * time.Second
+	webhookDispatchBatch    = 20
+	webhookRequestTimeout   = 10 * time.Second
+	webhookClaimTTL         = webhookDispatchBatch*webhookRequestTimeout + time.Minute
+	webhookMaxAttempts      = 8
+	webhookBaseBackoff      = 30 * time.Second
+	webhookMaxBackoff       = 6 * time.Hour
+
+	webhookEventHeader     = "X-Itsreg-Event"
+	webhookDeliveryHeader  = "X-Itsreg-Delivery"
+	webhookTimestampHeader = "X-Itsreg-Timestamp"
+	webhookSignatureHeader = "X-Itsreg-Signature"
+)
+
+type WebhookDispatcher struct {
+	db     *sqlx.DB
+	client *http.Client
+	log    *slog.Logger
+}
+
+func NewWebhookDispatcher(db *sqlx.DB, log *slog.Logger) *WebhookDispatcher {
+	return &WebhookDispatcher{
+		db:     db,
+		client: newWebhookClient(),
+		log:    log,
+	}
+}
+
+func (d *WebhookDispatcher) Run(ctx context.Context) {
+	ticker := time.NewTicker(webhookDispatchInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			if err := d.dispatch(ctx); err != nil {
+				d.log.Error("failed to dispatch webhooks", "error", err.Error())
+			}
+		}
+	}
+}
+
+func (d *WebhookDispatcher) dispatch(ctx context.Context) error {
+	rows, err := d.claim(ctx)
+	if err != nil {
+		return err
+	}
+
+	for _, row := range rows {
+		code, err := d.deliver(ctx, row)
+		if err = d.saveAttempt(ctx, row, code, err); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (d *WebhookDispatcher) claim(ctx context.Context) ([]pendingDeliveryRow, error) {
+	now := time.Now().UTC()
+
+	var rows []pendingDeliveryRow
+	err := pgutils.Select(ctx, d.db, &rows,
+		`WITH claimed AS (
+			SELECT uuid
+			FROM   webhook_deliveries
+			WHERE  status = 'pending' AND next_attempt_at <= $1
+			  AND  ( locked_until IS NULL OR locked_until <= $1 )
+			ORDER  BY next_attempt_at
+			LIMIT  $2
+			FOR UPDATE SKIP LOCKED
+		 )
+		 UPDATE webhook_deliveries d
+		 SET    locked_until = $3
+		 FROM   claimed c, webhooks w
+		 WHERE  d.uuid = c.uuid AND w.uuid = d.webhook_uuid
+		 RETURNING d.uuid, d.event, d.payload, d.attempts, w.url, w.secret`,
+		now, webhookDispatchBatch, now.Add(webhookClaimTTL),
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	return rows, nil
+}
+
+func (d *WebhookDispatcher) deliver(ctx context.Context, row pendingDeliveryRow) (int, error) {
+	timestamp := strconv.FormatInt(time.Now().Unix(), 10)
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, row.URL, bytes.NewReader(row.Payload))
+	if err != nil {
+		return 0, err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set(webhookEventHeader, row.Event)
+	req.Header.Set(webhookDeliveryHeader, row.UUID)
+	req.Header.Set(webhookTimestampHeader, timestamp)
+	req.Header.Set(webhookSignatureHeader, "sha256="+signWebhookPayload(row.Secret, timestamp, row.Payload))
+
+	resp, err := d.client.Do(req)
+	if err != nil {
+		return 0, err
+	}
+	defer resp.Body.Close()
+	_, _ = io.Copy(io.Discard, io.LimitReader(resp.Body, 1<<16))
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return resp.StatusCode, fmt.Errorf("unexpected response status %d", resp.StatusCode)
+	}
+
+	return resp.StatusCode, nil
+}
+
+func (d *WebhookDispatcher) saveAttempt(
+	ctx context.Context,
+	row pendingDeliveryRow,
+	code int,
+	deliveryErr error,
+) error {
+	now := time.Now().UTC()
+	attempts := row.Attempts + 1
+
+	status := bots.DeliveryDelivered
+	var lastError *string
+	nextAttemptAt := now
+
+	if deliveryErr != nil {
+		msg := deliveryErr.Error()
+		lastError = &msg
+
+		if attempts >= webhookMaxAttempts {
+			status = bots.DeliveryFailed
+		} else {
+			status = bots.DeliveryPending
+			nextAttemptAt = now.Add(webhookBackoff(attempts))
+		}
+
+		d.log.Warn(
+			"failed to deliver webhook",
+			"delivery_uuid", row.UUID,
+			"attempt", attempts,
+			"error", msg,
+		)
+	}
+
+	_, err := d.db.ExecContext(ctx,
+		`UPDATE webhook_deliveries
+		 SET    status = $1, attempts = $2, response_code = $3, last_error = $4,
+		        next_attempt_at = $5, updated_at = $6, locked_until = NULL
+		 WHERE  uuid = $7`,
+		status.String(), attempts, nilOnZero(code), lastError, nextAttemptAt, now, row.UUID,
+	)
+	return err
+}
+
+func newWebhookClient() *http.Client {
+	dialer := &net.Dialer{
+		Timeout: webhookRequestTimeout,
+		Control: controlWebhookDial,
+	}
+
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	transport.Proxy = nil
+	transport.DialContext = dialer.DialContext
+
+	return &http.Client{
+		Timeout:   webhookRequestTimeout,
+		Transport: transport,
+	}
+}
+
+func controlWebhookDial(_ string, address string, _ syscall.RawConn) error {
+	host, _, err := net.SplitHostPort(address)
+	if err != nil {
+		return err
+	}
+
+	addr, err := netip.ParseAddr(host)
+	if err != nil {
+		return err
+	}
+
+	if !bots.IsPublicWebhookAddr(addr) {
+		return fmt.Errorf("webhook address %s is not allowed", addr)
+	}
+
+	return nil
+}
+
+func webhookBackoff(attempts int) time.Duration {
+	backoff := webhookBaseBackoff << (attempts - 1)
+	if backoff <= 0 || backoff > webhookMaxBackoff {
+		return webhookMaxBackoff
+	}
+	return backoff
+}
+
+func signWebhookPayload(secret string, timestamp string, payload []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(timestamp))
+	mac.Write([]byte("."))
+	mac.Write(payload)
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+type pendingDeliveryRow struct {
+	UUID     string `db:"uuid"`
+	Event    string `db:"event"`
+	Payload  []byte `db:"payload"`
+	Attempts int    `db:"attempts"`
+	URL      string `db:"url"`
+	Secret   string `db:"secret"`
+}
@@ -1,12 +1,19 @@
 package httpport
 
 import (
+	"context"
 	"encoding/csv"
+	"encoding/json"
 	"errors"
 	"fmt"
+	"io"
+	"mime"
 	"net/http"
+	"strconv"
+	"time"
 
 	"github.com/go-chi/render"
+	googleuuid "github.com/google/uuid"
 
 	"github.com/bmstu-itstech/itsreg-bots/internal/app"
 	"github.com/bmstu-itstech/itsreg-bots/internal/app/command"
@@ -17,6 +24,11 @@ import (
 	"github.com/bmstu-itstech/itsreg-bots/internal/domain/bots"
 )
 
+const (
+	sseHeartbeatInterval = 15 * time.Second
+	maxUploadSize        = 20 << 20
+)
+
 type Server struct {
 	app *app.Application
 }
@@ -165,6 +177,10 @@ func (s Server) StartBot(w http.ResponseWriter, r *http.Request, uuid string) {
 		httpError(w, r, err, http.StatusForbidden)
 		return
 	}
+	if errors.As(err, &commonerrs.InvalidInputError{}) {
+		httpError(w, r, err, http.StatusBadRequest)
+		return
+	}
 	if err != nil {
 		httpError(w, r, err, http.StatusInternalServerError)
 		return
@@ -196,6 +212,35 @@ func (s Server) StopBot(w http.ResponseWriter, r *http.Request, uuid string) {
 	}
 }
 
+func (s Server) RestartBot(w http.ResponseWriter, r *http.Request, uuid string) {
+	userUUID, err := jwtauth.UserUUIDFromContext(r.Context())
+	if err != nil {
+		httpError(w, r, err, http.StatusUnauthorized)
+		return
+	}
+
+	err = s.app.Commands.RestartBot.Handle(r.Context(), command.RestartBot{
+		AuthorUUID: userUUID,
+		BotUUID:    uuid,
+	})
+	if errors.As(err, &commonerrs.InvalidInputError{}) {
+		httpError(w, r, err, http.StatusBadRequest)
+		return
+	}
+	if errors.As(err, &bots.BotNotFoundError{}) {
+		httpError(w, r, err, http.StatusNotFound)
+		return
+	}
+	if errors.Is(err, bots.ErrPermissionDenied) {
+		httpError(w, r, err, http.StatusForbidden)
+		return
+	}
+	if err != nil {
+		httpError(w, r, err, http.StatusInternalServerError)
+		return
+	}
+}
+
 func (s Server) StartMailing(w http.ResponseWriter, r *http.Request, uuid string, entryKey string) {
 	userUUID, err := jwtauth.UserUUIDFromContext(r.Context())
 	if err != nil {
@@ -280,154 +325,1181 @@ func (s Server) GetAnswers(w http.ResponseWriter, r *http.Request, uuid string)
 	}
 }
 
-func httpError(w http.ResponseWriter, r *http.Request, err error, code int) {
-	render.Status(r, code)
-	render.JSON(w, r, Error{Message: err.Error()})
-}
-
-func convertOptionToAPI(option types.Option) Option {
-	return Option{
-		Next: option.Next,
-		Text: option.Text,
+func (s Server) StreamAnswers(w http.ResponseWriter, r *http.Request, uuid string, params StreamAnswersParams) {
+	userUUID, err := jwtauth.UserUUIDFromContext(r.Context())
+	if err != nil {
+		httpError(w, r, err, http.StatusUnauthorized)
+		return
 	}
-}
 
-func convertOptionFromAPI(option Option) types.Option {
-	return types.Option{
-		Text: option.Text,
-		Next: option.Next,
+	var lastEventID int64
+	if params.LastEventID != nil {
+		lastEventID = *params.LastEventID
 	}
-}
 
-func convertOptionsToAPI(options []types.Option) *[]Option {
-	res := make([]Option, len(options))
-	for i, option := range options {
-		res[i] = convertOptionToAPI(option)
+	events, err := s.app.Queries.AnswersStream.Handle(r.Context(), query.StreamAnswers{
+		UserUUID:    userUUID,
+		BotUUID:     uuid,
+		LastEventID: lastEventID,
+	})
+	if errors.As(err, &bots.BotNotFoundError{}) {
+		httpError(w, r, err, http.StatusNotFound)
+		return
 	}
-	return &res
-}
-
-func convertOptionsFromAPI(options *[]Option) []types.Option {
-	if options == nil {
-		return nil
+	if errors.Is(err, bots.ErrPermissionDenied) {
+		httpError(w, r, err, http.StatusForbidden)
+		return
 	}
-	res := make([]types.Option, len(*options))
-	for i, option := range *options {
-		res[i] = convertOptionFromAPI(option)
+	if err != nil {
+		httpError(w, r, err, http.StatusInternalServerError)
+		return
 	}
-	return res
+
+	_ = renderSSEAnswers(r.Context(), w, events)
 }
 
-func convertEntryPointToAPI(entry types.EntryPoint) EntryPoint {
-	return EntryPoint{
-		Key:   entry.Key,
-		State: entry.State,
+func (s Server) GetBotMembers(w http.ResponseWriter, r *http.Request, uuid string) {
+	userUUID, err := jwtauth.UserUUIDFromContext(r.Context())
+	if err != nil {
+		httpError(w, r, err, http.StatusUnauthorized)
+		return
 	}
-}
 
-func convertEntryPointFromAPI(entry EntryPoint) types.EntryPoint {
-	return types.EntryPoint{
-		Key:   entry.Key,
-		State: entry.State,
+	members, err := s.app.Queries.BotMembers.Handle(r.Context(), query.GetBotMembers{
+		UserUUID: userUUID,
+		BotUUID:  uuid,
+	})
+	if errors.As(err, &bots.BotNotFoundError{}) {
+		httpError(w, r, err, http.StatusNotFound)
+		return
+	}
+	if errors.Is(err, bots.ErrPermissionDenied) {
+		httpError(w, r, err, http.StatusForbidden)
+		return
+	}
+	if err != nil {
+		httpError(w, r, err, http.StatusInternalServerError)
+		return
 	}
+
+	render.JSON(w, r, convertMembersToAPI(members))
 }
 
-func convertEntryPointsToAPI(entries []types.EntryPoint) []EntryPoint {
-	res := make([]EntryPoint, len(entries))
-	for i, entry := range entries {
-		res[i] = convertEntryPointToAPI(entry)
+func (s Server) SetBotMember(w http.ResponseWriter, r *http.Request, uuid string) {
+	userUUID, err := jwtauth.UserUUIDFromContext(r.Context())
+	if err != nil {
+		httpError(w, r, err, http.StatusUnauthorized)
+		return
 	}
-	return res
-}
 
-func convertEntryPointsFromAPI(entries []EntryPoint) []types.EntryPoint {
-	res := make([]types.EntryPoint, len(entries))
-	for i, entry := range entries {
-		res[i] = convertEntryPointFromAPI(entry)
+	member := Member{}
+	if err := render.Decode(r, &member); err != nil {
+		httpError(w, r, err, http.StatusBadRequest)
+		return
 	}
-	return res
-}
 
-func convertMailingToAPI(mailing types.Mailing) Mailing {
-	return Mailing{
-		EntryKey:      mailing.EntryKey,
-		Name:          mailing.Name,
-		RequiredState: mailing.RequiredState,
+	err = s.app.Commands.SetBotMember.Handle(r.Context(), command.SetBotMember{
+		AuthorUUID: userUUID,
+		BotUUID:    uuid,
+		Member:     convertMemberFromAPI(member),
+	})
+	if errors.As(err, &commonerrs.InvalidInputError{}) {
+		httpError(w, r, err, http.StatusBadRequest)
+		return
+	}
+	if errors.As(err, &bots.BotNotFoundError{}) {
+		httpError(w, r, err, http.StatusNotFound)
+		return
+	}
+	if errors.Is(err, bots.ErrPermissionDenied) {
+		httpError(w, r, err, http.StatusForbidden)
+		return
+	}
+	if err != nil {
+		httpError(w, r, err, http.StatusInternalServerError)
+		return
 	}
 }
 
-func convertOptionalMailingsToAPI(mailings []types.Mailing) *[]Mailing {
-	res := make([]Mailing, len(mailings))
-	for i, mailing := range mailings {
-		res[i] = convertMailingToAPI(mailing)
+func (s Server) DeleteBotMember(w http.ResponseWriter, r *http.Request, uuid string, memberUUID string) {
+	userUUID, err := jwtauth.UserUUIDFromContext(r.Context())
+	if err != nil {
+		httpError(w, r, err, http.StatusUnauthorized)
+		return
 	}
-	return &res
-}
 
-func convertMailingFromAPI(mailing Mailing) types.Mailing {
-	return types.Mailing{
-		Name:          mailing.Name,
-		EntryKey:      mailing.EntryKey,
-		RequiredState: mailing.RequiredState,
+	err = s.app.Commands.DeleteBotMember.Handle(r.Context(), command.DeleteBotMember{
+		AuthorUUID: userUUID,
+		BotUUID:    uuid,
+		UserUUID:   memberUUID,
+	})
+	if errors.As(err, &commonerrs.InvalidInputError{}) {
+		httpError(w, r, err, http.StatusBadRequest)
+		return
+	}
+	if errors.As(err, &bots.BotNotFoundError{}) || errors.As(err, &bots.MemberNotFoundError{}) {
+		httpError(w, r, err, http.StatusNotFound)
+		return
+	}
+	if errors.Is(err, bots.ErrPermissionDenied) {
+		httpError(w, r, err, http.StatusForbidden)
+		return
+	}
+	if err != nil {
+		httpError(w, r, err, http.StatusInternalServerError)
+		return
 	}
 }
 
-func convertMailingsFromAPI(mailings []Mailing) []types.Mailing {
-	res := make([]types.Mailing, len(mailings))
-	for i, mailing := range mailings {
-		res[i] = convertMailingFromAPI(mailing)
+func (s Server) GetWebhooks(w http.ResponseWriter, r *http.Request, uuid string) {
+	userUUID, err := jwtauth.UserUUIDFromContext(r.Context())
+	if err != nil {
+		httpError(w, r, err, http.StatusUnauthorized)
+		return
 	}
-	return res
-}
 
-func convertOptionalMailingsFromAPI(mailings *[]Mailing) []types.Mailing {
-	if mailings == nil {
-		return make([]types.Mailing, 0)
+	webhooks, err := s.app.Queries.Webhooks.Handle(r.Context(), query.GetWebhooks{
+		UserUUID: userUUID,
+		BotUUID:  uuid,
+	})
+	if errors.As(err, &bots.BotNotFoundError{}) {
+		httpError(w, r, err, http.StatusNotFound)
+		return
+	}
+	if errors.Is(err, bots.ErrPermissionDenied) {
+		httpError(w, r, err, http.StatusForbidden)
+		return
+	}
+	if err != nil {
+		httpError(w, r, err, http.StatusInternalServerError)
+		return
 	}
 
-	return convertMailingsFromAPI(*mailings)
+	render.JSON(w, r, convertWebhooksToAPI(webhooks))
 }
 
-func convertBlockToAPI(block types.Block) Block {
-	return Block{
-		Type:      BlockType(block.Type),
-		NextState: block.NextState,
-		Options:   convertOptionsToAPI(block.Options),
-		State:     block.State,
-		Text:      block.Text,
-		Title:     block.Title,
+func (s Server) CreateWebhook(w http.ResponseWriter, r *http.Request, uuid string) {
+	userUUID, err := jwtauth.UserUUIDFromContext(r.Context())
+	if err != nil {
+		httpError(w, r, err, http.StatusUnauthorized)
+		return
 	}
-}
 
-func convertBlockFromAPI(block Block) types.Block {
-	return types.Block{
-		Type:      string(block.Type),
-		State:     block.State,
-		NextState: block.NextState,
-		Options:   convertOptionsFromAPI(block.Options),
-		Title:     block.Title,
-		Text:      block.Text,
+	postWebhook := PostWebhook{}
+	if err := render.Decode(r, &postWebhook); err != nil {
+		httpError(w, r, err, http.StatusBadRequest)
+		return
 	}
-}
 
-func convertBlocksToAPI(blocks []types.Block) []Block {
-	res := make([]Block, len(blocks))
-	for i, block := range blocks {
-		res[i] = convertBlockToAPI(block)
+	webhookUUID := googleuuid.NewString()
+	events := convertEventTypesFromAPI(postWebhook.Events)
+
+	err = s.app.Commands.CreateWebhook.Handle(r.Context(), command.CreateWebhook{
+		AuthorUUID:  userUUID,
+		BotUUID:     uuid,
+		WebhookUUID: webhookUUID,
+		URL:         postWebhook.Url,
+		Secret:      postWebhook.Secret,
+		Events:      events,
+	})
+	if errors.As(err, &commonerrs.InvalidInputError{}) {
+		httpError(w, r, err, http.StatusBadRequest)
+		return
 	}
-	return res
+	if errors.As(err, &bots.BotNotFoundError{}) {
+		httpError(w, r, err, http.StatusNotFound)
+		return
+	}
+	if errors.Is(err, bots.ErrPermissionDenied) {
+		httpError(w, r, err, http.StatusForbidden)
+		return
+	}
+	if err != nil {
+		httpError(w, r, err, http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("content-location", fmt.Sprintf("/bots/%s/webhooks/%s", uuid, webhookUUID))
+	render.Status(r, http.StatusCreated)
+	render.JSON(w, r, Webhook{
+		WebhookUUID: webhookUUID,
+		Url:         postWebhook.Url,
+		Events:      postWebhook.Events,
+		CreatedAt:   time.Now(),
+	})
 }
 
-func convertBlocksFromAPI(blocks []Block) []types.Block {
-	res := make([]types.Block, len(blocks))
-	for i, block := range blocks {
-		res[i] = convertBlockFromAPI(block)
+func (s Server) DeleteWebhook(w http.ResponseWriter, r *http.Request, uuid string, webhookUUID string) {
+	userUUID, err := jwtauth.UserUUIDFromContext(r.Context())
+	if err != nil {
+		httpError(w, r, err, http.StatusUnauthorized)
+		return
 	}
-	return res
-}
+
+	err = s.app.Commands.DeleteWebhook.Handle(r.Context(), command.DeleteWebhook{
+		AuthorUUID:  userUUID,
+		BotUUID:     uuid,
+		WebhookUUID: webhookUUID,
+	})
+	if errors.As(err, &bots.BotNotFoundError{}) || errors.As(err, &bots.WebhookNotFoundError{}) {
+		httpError(w, r, err, http.StatusNotFound)
+		return
+	}
+	if errors.Is(err, bots.ErrPermissionDenied) {
+		httpError(w, r, err, http.StatusForbidden)
+		return
+	}
+	if err != nil {
+		httpError(w, r, err, http.StatusInternalServerError)
+		return
+	}
+}
+
+func (s Server) GetWebhookDeliveries(w http.ResponseWriter, r *http.Request, uuid string, webhookUUID string) {
+	userUUID, err := jwtauth.UserUUIDFromContext(r.Context())
+	if err != nil {
+		httpError(w, r, err, http.StatusUnauthorized)
+		return
+	}
+
+	deliveries, err := s.app.Queries.WebhookDeliveries.Handle(r.Context(), query.GetWebhookDeliveries{
+		UserUUID:    userUUID,
+		BotUUID:     uuid,
+		WebhookUUID: webhookUUID,
+	})
+	if errors.As(err, &bots.BotNotFoundError{}) || errors.As(err, &bots.WebhookNotFoundError{}) {
+		httpError(w, r, err, http.StatusNotFound)
+		return
+	}
+	if errors.Is(err, bots.ErrPermissionDenied) {
+		httpError(w, r, err, http.StatusForbidden)
+		return
+	}
+	if err != nil {
+		httpError(w, r, err, http.StatusInternalServerError)
+		return
+	}
+
+	render.JSON(w, r, convertWebhookDeliveriesToAPI(deliveries))
+}
+
+func (s Server) GetThreads(w http.ResponseWriter, r *http.Request, uuid string) {
+	userUUID, err := jwtauth.UserUUIDFromContext(r.Context())
+	if err != nil {
+		httpError(w, r, err, http.StatusUnauthorized)
+		return
+	}
+
+	threads, err := s.app.Queries.Threads.Handle(r.Context(), query.GetThreads{
+		UserUUID: userUUID,
+		BotUUID:  uuid,
+	})
+	if errors.As(err, &bots.BotNotFoundError{}) {
+		httpError(w, r, err, http.StatusNotFound)
+		return
+	}
+	if errors.Is(err, bots.ErrPermissionDenied) {
+		httpError(w, r, err, http.StatusForbidden)
+		return
+	}
+	if err != nil {
+		httpError(w, r, err, http.StatusInternalServerError)
+		return
+	}
+
+	render.JSON(w, r, convertThreadsToAPI(threads))
+}
+
+func (s Server) GetThreadMessages(w http.ResponseWriter, r *http.Request, uuid string, userID int64) {
+	userUUID, err := jwtauth.UserUUIDFromContext(r.Context())
+	if err != nil {
+		httpError(w, r, err, http.StatusUnauthorized)
+		return
+	}
+
+	msgs, err := s.app.Queries.ThreadMessages.Handle(r.Context(), query.GetThreadMessages{
+		UserUUID: userUUID,
+		BotUUID:  uuid,
+		UserID:   userID,
+	})
+	if errors.As(err, &bots.BotNotFoundError{}) || errors.As(err, &bots.ParticipantNotFoundError{}) {
+		httpError(w, r, err, http.StatusNotFound)
+		return
+	}
+	if errors.Is(err, bots.ErrPermissionDenied) {
+		httpError(w, r, err, http.StatusForbidden)
+		return
+	}
+	if err != nil {
+		httpError(w, r, err, http.StatusInternalServerError)
+		return
+	}
+
+	render.JSON(w, r, convertThreadMessagesToAPI(msgs))
+}
+
+func (s Server) SendOperatorMessage(w http.ResponseWriter, r *http.Request, uuid string, userID int64) {
+	userUUID, err := jwtauth.UserUUIDFromContext(r.Context())
+	if err != nil {
+		httpError(w, r, err, http.StatusUnauthorized)
+		return
+	}
+
+	postMessage := PostOperatorMessage{}
+	if err := render.Decode(r, &postMessage); err != nil {
+		httpError(w, r, err, http.StatusBadRequest)
+		return
+	}
+
+	err = s.app.Commands.SendOperatorMessage.Handle(r.Context(), command.SendOperatorMessage{
+		AuthorUUID: userUUID,
+		BotUUID:    uuid,
+		UserID:     userID,
+		Text:       postMessage.Text,
+	})
+	if errors.As(err, &commonerrs.InvalidInputError{}) {
+		httpError(w, r, err, http.StatusBadRequest)
+		return
+	}
+	if errors.As(err, &bots.BotNotFoundError{}) || errors.As(err, &bots.ParticipantNotFoundError{}) {
+		httpError(w, r, err, http.StatusNotFound)
+		return
+	}
+	if errors.Is(err, bots.ErrPermissionDenied) {
+		httpError(w, r, err, http.StatusForbidden)
+		return
+	}
+	if err != nil {
+		httpError(w, r, err, http.StatusInternalServerError)
+		return
+	}
+
+	w.WriteHeader(http.StatusCreated)
+}
+
+func (s Server) PauseParticipant(w http.ResponseWriter, r *http.Request, uuid string, userID int64) {
+	userUUID, err := jwtauth.UserUUIDFromContext(r.Context())
+	if err != nil {
+		httpError(w, r, err, http.StatusUnauthorized)
+		return
+	}
+
+	err = s.app.Commands.PauseParticipant.Handle(r.Context(), command.PauseParticipant{
+		AuthorUUID: userUUID,
+		BotUUID:    uuid,
+		UserID:     userID,
+	})
+	if errors.As(err, &bots.BotNotFoundError{}) || errors.As(err, &bots.ParticipantNotFoundError{}) {
+		httpError(w, r, err, http.StatusNotFound)
+		return
+	}
+	if errors.Is(err, bots.ErrPermissionDenied) {
+		httpError(w, r, err, http.StatusForbidden)
+		return
+	}
+	if err != nil {
+		httpError(w, r, err, http.StatusInternalServerError)
+		return
+	}
+}
+
+func (s Server) ResumeParticipant(w http.ResponseWriter, r *http.Request, uuid string, userID int64) {
+	userUUID, err := jwtauth.UserUUIDFromContext(r.Context())
+	if err != nil {
+		httpError(w, r, err, http.StatusUnauthorized)
+		return
+	}
+
+	err = s.app.Commands.ResumeParticipant.Handle(r.Context(), command.ResumeParticipant{
+		AuthorUUID: userUUID,
+		BotUUID:    uuid,
+		UserID:     userID,
+	})
+	if errors.As(err, &bots.BotNotFoundError{}) || errors.As(err, &bots.ParticipantNotFoundError{}) {
+		httpError(w, r, err, http.StatusNotFound)
+		return
+	}
+	if errors.Is(err, bots.ErrPermissionDenied) {
+		httpError(w, r, err, http.StatusForbidden)
+		return
+	}
+	if err != nil {
+		httpError(w, r, err, http.StatusInternalServerError)
+		return
+	}
+}
+
+func (s Server) GetParticipants(w http.ResponseWriter, r *http.Request, uuid string, params GetParticipantsParams) {
+	userUUID, err := jwtauth.UserUUIDFromContext(r.Context())
+	if err != nil {
+		httpError(w, r, err, http.StatusUnauthorized)
+		return
+	}
+
+	var offset, limit int
+	if params.Offset != nil {
+		offset = *params.Offset
+	}
+	if params.Limit != nil {
+		limit = *params.Limit
+	}
+
+	page, err := s.app.Queries.Participants.Handle(r.Context(), query.GetParticipants{
+		UserUUID: userUUID,
+		BotUUID:  uuid,
+		Offset:   offset,
+		Limit:    limit,
+	})
+	if errors.As(err, &commonerrs.InvalidInputError{}) {
+		httpError(w, r, err, http.StatusBadRequest)
+		return
+	}
+	if errors.As(err, &bots.BotNotFoundError{}) {
+		httpError(w, r, err, http.StatusNotFound)
+		return
+	}
+	if errors.Is(err, bots.ErrPermissionDenied) {
+		httpError(w, r, err, http.StatusForbidden)
+		return
+	}
+	if err != nil {
+		httpError(w, r, err, http.StatusInternalServerError)
+		return
+	}
+
+	render.JSON(w, r, ParticipantsPage{
+		Participants: convertParticipantsToAPI(page.Participants),
+		Total:        page.Total,
+	})
+}
+
+func (s Server) GetParticipant(w http.ResponseWriter, r *http.Request, uuid string, userID int64) {
+	userUUID, err := jwtauth.UserUUIDFromContext(r.Context())
+	if err != nil {
+		httpError(w, r, err, http.StatusUnauthorized)
+		return
+	}
+
+	prt, err := s.app.Queries.Participant.Handle(r.Context(), query.GetParticipant{
+		UserUUID: userUUID,
+		BotUUID:  uuid,
+		UserID:   userID,
+	})
+	if errors.As(err, &bots.BotNotFoundError{}) || errors.As(err, &bots.ParticipantNotFoundError{}) {
+		httpError(w, r, err, http.StatusNotFound)
+		return
+	}
+	if errors.Is(err, bots.ErrPermissionDenied) {
+		httpError(w, r, err, http.StatusForbidden)
+		return
+	}
+	if err != nil {
+		httpError(w, r, err, http.StatusInternalServerError)
+		return
+	}
+
+	render.JSON(w, r, convertParticipantToAPI(prt))
+}
+
+func (s Server) DeleteParticipant(w http.ResponseWriter, r *http.Request, uuid string, userID int64) {
+	userUUID, err := jwtauth.UserUUIDFromContext(r.Context())
+	if err != nil {
+		httpError(w, r, err, http.StatusUnauthorized)
+		return
+	}
+
+	err = s.app.Commands.DeleteParticipant.Handle(r.Context(), command.DeleteParticipant{
+		AuthorUUID: userUUID,
+		BotUUID:    uuid,
+		UserID:     userID,
+	})
+	if errors.As(err, &bots.BotNotFoundError{}) || errors.As(err, &bots.ParticipantNotFoundError{}) {
+		httpError(w, r, err, http.StatusNotFound)
+		return
+	}
+	if errors.Is(err, bots.ErrPermissionDenied) {
+		httpError(w, r, err, http.StatusForbidden)
+		return
+	}
+	if err != nil {
+		httpError(w, r, err, http.StatusInternalServerError)
+		return
+	}
+}
+
+func (s Server) ResetParticipant(w http.ResponseWriter, r *http.Request, uuid string, userID int64) {
+	userUUID, err := jwtauth.UserUUIDFromContext(r.Context())
+	if err != nil {
+		httpError(w, r, err, http.StatusUnauthorized)
+		return
+	}
+
+	postReset := PostResetParticipant{}
+	if err := render.Decode(r, &postReset); err != nil {
+		httpError(w, r, err, http.StatusBadRequest)
+		return
+	}
+
+	err = s.app.Commands.ResetParticipant.Handle(r.Context(), command.ResetParticipant{
+		AuthorUUID: userUUID,
+		BotUUID:    uuid,
+		UserID:     userID,
+		EntryKey:   postReset.EntryKey,
+	})
+	if errors.As(err, &bots.EntryNotFoundError{}) {
+		httpError(w, r, err, http.StatusBadRequest)
+		return
+	}
+	if errors.As(err, &bots.BotNotFoundError{}) || errors.As(err, &bots.ParticipantNotFoundError{}) {
+		httpError(w, r, err, http.StatusNotFound)
+		return
+	}
+	if errors.Is(err, bots.ErrPermissionDenied) {
+		httpError(w, r, err, http.StatusForbidden)
+		return
+	}
+	if err != nil {
+		httpError(w, r, err, http.StatusInternalServerError)
+		return
+	}
+}
+
+func (s Server) BlockParticipant(w http.ResponseWriter, r *http.Request, uuid string, userID int64) {
+	userUUID, err := jwtauth.UserUUIDFromContext(r.Context())
+	if err != nil {
+		httpError(w, r, err, http.StatusUnauthorized)
+		return
+	}
+
+	err = s.app.Commands.BlockParticipant.Handle(r.Context(), command.BlockParticipant{
+		AuthorUUID: userUUID,
+		BotUUID:    uuid,
+		UserID:     userID,
+	})
+	if errors.As(err, &bots.BotNotFoundError{}) || errors.As(err, &bots.ParticipantNotFoundError{}) {
+		httpError(w, r, err, http.StatusNotFound)
+		return
+	}
+	if errors.Is(err, bots.ErrPermissionDenied) {
+		httpError(w, r, err, http.StatusForbidden)
+		return
+	}
+	if err != nil {
+		httpError(w, r, err, http.StatusInternalServerError)
+		return
+	}
+}
+
+func (s Server) UnblockParticipant(w http.ResponseWriter, r *http.Request, uuid string, userID int64) {
+	userUUID, err := jwtauth.UserUUIDFromContext(r.Context())
+	if err != nil {
+		httpError(w, r, err, http.StatusUnauthorized)
+		return
+	}
+
+	err = s.app.Commands.UnblockParticipant.Handle(r.Context(), command.UnblockParticipant{
+		AuthorUUID: userUUID,
+		BotUUID:    uuid,
+		UserID:     userID,
+	})
+	if errors.As(err, &bots.BotNotFoundError{}) || errors.As(err, &bots.ParticipantNotFoundError{}) {
+		httpError(w, r, err, http.StatusNotFound)
+		return
+	}
+	if errors.Is(err, bots.ErrPermissionDenied) {
+		httpError(w, r, err, http.StatusForbidden)
+		return
+	}
+	if err != nil {
+		httpError(w, r, err, http.StatusInternalServerError)
+		return
+	}
+}
+
+func (s Server) GetSeats(w http.ResponseWriter, r *http.Request, uuid string, key string) {
+	userUUID, err := jwtauth.UserUUIDFromContext(r.Context())
+	if err != nil {
+		httpError(w, r, err, http.StatusUnauthorized)
+		return
+	}
+
+	seats, err := s.app.Queries.Seats.Handle(r.Context(), query.GetSeats{
+		UserUUID: userUUID,
+		BotUUID:  uuid,
+		EntryKey: key,
+	})
+	if errors.As(err, &bots.BotNotFoundError{}) || errors.As(err, &bots.EntryNotFoundError{}) {
+		httpError(w, r, err, http.StatusNotFound)
+		return
+	}
+	if errors.Is(err, bots.ErrPermissionDenied) {
+		httpError(w, r, err, http.StatusForbidden)
+		return
+	}
+	if err != nil {
+		httpError(w, r, err, http.StatusInternalServerError)
+		return
+	}
+
+	render.JSON(w, r, convertSeatsToAPI(seats))
+}
+
+func (s Server) ReleaseSeat(w http.ResponseWriter, r *http.Request, uuid string, key string, userID int64) {
+	userUUID, err := jwtauth.UserUUIDFromContext(r.Context())
+	if err != nil {
+		httpError(w, r, err, http.StatusUnauthorized)
+		return
+	}
+
+	err = s.app.Commands.ReleaseSeat.Handle(r.Context(), command.ReleaseSeat{
+		AuthorUUID: userUUID,
+		BotUUID:    uuid,
+		EntryKey:   key,
+		UserID:     userID,
+	})
+	if errors.As(err, &bots.BotNotFoundError{}) || errors.As(err, &bots.EntryNotFoundError{}) ||
+		errors.As(err, &bots.SeatNotFoundError{}) {
+		httpError(w, r, err, http.StatusNotFound)
+		return
+	}
+	if errors.Is(err, bots.ErrPermissionDenied) {
+		httpError(w, r, err, http.StatusForbidden)
+		return
+	}
+	if err != nil {
+		httpError(w, r, err, http.StatusInternalServerError)
+		return
+	}
+}
+
+func (s Server) CheckIn(w http.ResponseWriter, r *http.Request, uuid string) {
+	userUUID, err := jwtauth.UserUUIDFromContext(r.Context())
+	if err != nil {
+		httpError(w, r, err, http.StatusUnauthorized)
+		return
+	}
+
+	var req PostCheckIn
+	if err = render.Decode(r, &req); err != nil {
+		httpError(w, r, err, http.StatusBadRequest)
+		return
+	}
+	err = s.app.Commands.CheckIn.Handle(r.Context(), command.CheckIn{
+		AuthorUUID: userUUID,
+		BotUUID:    uuid,
+		Code:       req.Code,
+	})
+	if errors.As(err, &commonerrs.InvalidInputError{}) {
+		httpError(w, r, err, http.StatusBadRequest)
+		return
+	}
+	if errors.As(err, &bots.TicketAlreadyUsedError{}) {
+		httpError(w, r, err, http.StatusConflict)
+		return
+	}
+	if errors.As(err, &bots.BotNotFoundError{}) || errors.As(err, &bots.TicketNotFoundError{}) {
+		httpError(w, r, err, http.StatusNotFound)
+		return
+	}
+	if errors.Is(err, bots.ErrPermissionDenied) {
+		httpError(w, r, err, http.StatusForbidden)
+		return
+	}
+	if err != nil {
+		httpError(w, r, err, http.StatusInternalServerError)
+		return
+	}
+}
+
+func (s Server) UploadFile(w http.ResponseWriter, r *http.Request, uuid string) {
+	userUUID, err := jwtauth.UserUUIDFromContext(r.Context())
+	if err != nil {
+		httpError(w, r, err, http.StatusUnauthorized)
+		return
+	}
+
+	r.Body = http.MaxBytesReader(w, r.Body, maxUploadSize)
+	content, header, err := r.FormFile("file")
+	if err != nil {
+		httpError(w, r, err, http.StatusBadRequest)
+		return
+	}
+	defer content.Close()
+
+	fileID := googleuuid.NewString()
+	mimeType := header.Header.Get("Content-Type")
+	err = s.app.Commands.UploadFile.Handle(r.Context(), command.UploadFile{
+		AuthorUUID: userUUID,
+		BotUUID:    uuid,
+		FileID:     fileID,
+		Name:       header.Filename,
+		MimeType:   mimeType,
+		Content:    content,
+	})
+	if errors.As(err, &commonerrs.InvalidInputError{}) {
+		httpError(w, r, err, http.StatusBadRequest)
+		return
+	}
+	if errors.As(err, &bots.BotNotFoundError{}) {
+		httpError(w, r, err, http.StatusNotFound)
+		return
+	}
+	if errors.Is(err, bots.ErrPermissionDenied) {
+		httpError(w, r, err, http.StatusForbidden)
+		return
+	}
+	if err != nil {
+		httpError(w, r, err, http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("content-location", fmt.Sprintf("/bots/%s/files/%s", uuid, fileID))
+	render.Status(r, http.StatusCreated)
+	render.JSON(w, r, File{
+		Id:       fileID,
+		Name:     header.Filename,
+		MimeType: mimeType,
+		Size:     header.Size,
+	})
+}
+
+func (s Server) GetFile(w http.ResponseWriter, r *http.Request, uuid string, fileId string) {
+	userUUID, err := jwtauth.UserUUIDFromContext(r.Context())
+	if err != nil {
+		httpError(w, r, err, http.StatusUnauthorized)
+		return
+	}
+
+	file, err := s.app.Queries.File.Handle(r.Context(), query.GetFile{
+		UserUUID: userUUID,
+		BotUUID:  uuid,
+		FileID:   fileId,
+	})
+	if errors.As(err, &bots.BotNotFoundError{}) || errors.As(err, &bots.FileNotFoundError{}) {
+		httpError(w, r, err, http.StatusNotFound)
+		return
+	}
+	if errors.Is(err, bots.ErrPermissionDenied) {
+		httpError(w, r, err, http.StatusForbidden)
+		return
+	}
+	if err != nil {
+		httpError(w, r, err, http.StatusInternalServerError)
+		return
+	}
+	defer file.Content.Close()
+
+	w.Header().Set("Content-Type", file.MimeType)
+	w.Header().Set("Content-Length", strconv.FormatInt(file.Size, 10))
+	w.Header().Set("Content-Disposition", mime.FormatMediaType("attachment", map[string]string{"filename": file.Name}))
+	_, _ = io.Copy(w, file.Content)
+}
+
+func httpError(w http.ResponseWriter, r *http.Request, err error, code int) {
+	render.Status(r, code)
+	render.JSON(w, r, Error{Message: err.Error()})
+}
+
+func convertOptionToAPI(option types.Option) Option {
+	res := Option{
+		Next: option.Next,
+		Text: option.Text,
+	}
+	if option.Value != "" {
+		res.Value = &option.Value
+	}
+	if option.ID != "" {
+		res.Id = &option.ID
+	}
+	if len(option.Aliases) > 0 {
+		res.Aliases = &option.Aliases
+	}
+	if option.Locale != "" {
+		res.Locale = &option.Locale
+	}
+	if len(option.Translations) > 0 {
+		res.Translations = &option.Translations
+	}
+	return res
+}
+
+func convertOptionFromAPI(option Option) types.Option {
+	res := types.Option{
+		Text: option.Text,
+		Next: option.Next,
+	}
+	if option.Value != nil {
+		res.Value = *option.Value
+	}
+	if option.Id != nil {
+		res.ID = *option.Id
+	}
+	if option.Aliases != nil {
+		res.Aliases = *option.Aliases
+	}
+	if option.Locale != nil {
+		res.Locale = *option.Locale
+	}
+	if option.Translations != nil {
+		res.Translations = *option.Translations
+	}
+	return res
+}
+
+func convertTranslationsToAPI(ts map[string]types.Translation) *map[string]Translation {
+	if len(ts) == 0 {
+		return nil
+	}
+	res := make(map[string]Translation, len(ts))
+	for locale, t := range ts {
+		tr := Translation{Text: t.Text}
+		if t.Title != "" {
+			tr.Title = &t.Title
+		}
+		res[locale] = tr
+	}
+	return &res
+}
+
+func convertTranslationsFromAPI(ts *map[string]Translation) map[string]types.Translation {
+	if ts == nil {
+		return nil
+	}
+	res := make(map[string]types.Translation, len(*ts))
+	for locale, t := range *ts {
+		tr := types.Translation{Text: t.Text}
+		if t.Title != nil {
+			tr.Title = *t.Title
+		}
+		res[locale] = tr
+	}
+	return res
+}
+
+func convertOptionsToAPI(options []types.Option) *[]Option {
+	res := make([]Option, len(options))
+	for i, option := range options {
+		res[i] = convertOptionToAPI(option)
+	}
+	return &res
+}
+
+func convertOptionsFromAPI(options *[]Option) []types.Option {
+	if options == nil {
+		return nil
+	}
+	res := make([]types.Option, len(*options))
+	for i, option := range *options {
+		res[i] = convertOptionFromAPI(option)
+	}
+	return res
+}
+
+func convertCapacityToAPI(capacity types.Capacity) *Capacity {
+	if capacity.Limit == 0 {
+		return nil
+	}
+	res := &Capacity{
+		Limit:     capacity.Limit,
+		FullState: capacity.FullState,
+	}
+	if capacity.WaitlistState != 0 {
+		res.WaitlistState = &capacity.WaitlistState
+	}
+	if capacity.PromotedState != 0 {
+		res.PromotedState = &capacity.PromotedState
+	}
+	return res
+}
+
+func convertCapacityFromAPI(capacity *Capacity) types.Capacity {
+	if capacity == nil {
+		return types.Capacity{}
+	}
+	res := types.Capacity{
+		Limit:     capacity.Limit,
+		FullState: capacity.FullState,
+	}
+	if capacity.WaitlistState != nil {
+		res.WaitlistState = *capacity.WaitlistState
+	}
+	if capacity.PromotedState != nil {
+		res.PromotedState = *capacity.PromotedState
+	}
+	return res
+}
+
+func convertWindowToAPI(window types.Window) *Window {
+	if window.OpensAt.IsZero() && window.ClosesAt.IsZero() {
+		return nil
+	}
+	res := &Window{
+		Timezone:    &window.Timezone,
+		NotOpenText: &window.NotOpenText,
+		ClosedText:  &window.ClosedText,
+	}
+	if !window.OpensAt.IsZero() {
+		res.OpensAt = &window.OpensAt
+	}
+	if !window.ClosesAt.IsZero() {
+		res.ClosesAt = &window.ClosesAt
+	}
+	return res
+}
+
+func convertWindowFromAPI(window *Window) types.Window {
+	if window == nil {
+		return types.Window{}
+	}
+	res := types.Window{}
+	if window.OpensAt != nil {
+		res.OpensAt = *window.OpensAt
+	}
+	if window.ClosesAt != nil {
+		res.ClosesAt = *window.ClosesAt
+	}
+	if window.Timezone != nil {
+		res.Timezone = *window.Timezone
+	}
+	if window.NotOpenText != nil {
+		res.NotOpenText = *window.NotOpenText
+	}
+	if window.ClosedText != nil {
+		res.ClosedText = *window.ClosedText
+	}
+	return res
+}
+
+func convertEntryPointToAPI(entry types.EntryPoint) EntryPoint {
+	windowState := WindowState(entry.WindowState)
+	return EntryPoint{
+		Key:         entry.Key,
+		State:       entry.State,
+		Capacity:    convertCapacityToAPI(entry.Capacity),
+		Window:      convertWindowToAPI(entry.Window),
+		WindowState: &windowState,
+		Reminder:    convertReminderToAPI(entry.Reminder),
+		Expiry:      convertExpiryToAPI(entry.Expiry),
+	}
+}
+
+func convertReminderToAPI(reminder types.Reminder) *Reminder {
+	if reminder.After == 0 {
+		return nil
+	}
+	return &Reminder{
+		AfterMinutes: int(reminder.After.Minutes()),
+		Limit:        &reminder.Limit,
+		Text:         &reminder.Text,
+	}
+}
+
+func convertReminderFromAPI(reminder *Reminder) types.Reminder {
+	if reminder == nil {
+		return types.Reminder{}
+	}
+	res := types.Reminder{
+		After: time.Duration(reminder.AfterMinutes) * time.Minute,
+	}
+	if reminder.Limit != nil {
+		res.Limit = *reminder.Limit
+	}
+	if reminder.Text != nil {
+		res.Text = *reminder.Text
+	}
+	return res
+}
+
+func convertExpiryToAPI(expiry types.Expiry) *Expiry {
+	if expiry.TTL == 0 {
+		return nil
+	}
+	mode := ExpiryMode(expiry.Mode)
+	res := &Expiry{
+		TtlMinutes: int(expiry.TTL.Minutes()),
+		Mode:       &mode,
+	}
+	if expiry.Text != "" {
+		res.Text = &expiry.Text
+	}
+	return res
+}
+
+func convertExpiryFromAPI(expiry *Expiry) types.Expiry {
+	if expiry == nil {
+		return types.Expiry{}
+	}
+	res := types.Expiry{
+		TTL: time.Duration(expiry.TtlMinutes) * time.Minute,
+	}
+	if expiry.Mode != nil {
+		res.Mode = string(*expiry.Mode)
+	}
+	if expiry.Text != nil {
+		res.Text = *expiry.Text
+	}
+	return res
+}
+
+func convertEntryPointFromAPI(entry EntryPoint) types.EntryPoint {
+	return types.EntryPoint{
+		Key:      entry.Key,
+		State:    entry.State,
+		Capacity: convertCapacityFromAPI(entry.Capacity),
+		Window:   convertWindowFromAPI(entry.Window),
+		Reminder: convertReminderFromAPI(entry.Reminder),
+		Expiry:   convertExpiryFromAPI(entry.Expiry),
+	}
+}
+
+func convertEntryPointsToAPI(entries []types.EntryPoint) []EntryPoint {
+	res := make([]EntryPoint, len(entries))
+	for i, entry := range entries {
+		res[i] = convertEntryPointToAPI(entry)
+	}
+	return res
+}
+
+func convertEntryPointsFromAPI(entries []EntryPoint) []types.EntryPoint {
+	res := make([]types.EntryPoint, len(entries))
+	for i, entry := range entries {
+		res[i] = convertEntryPointFromAPI(entry)
+	}
+	return res
+}
+
+func convertMailingToAPI(mailing types.Mailing) Mailing {
+	return Mailing{
+		EntryKey:      mailing.EntryKey,
+		Name:          mailing.Name,
+		RequiredState: mailing.RequiredState,
+	}
+}
+
+func convertOptionalMailingsToAPI(mailings []types.Mailing) *[]Mailing {
+	res := make([]Mailing, len(mailings))
+	for i, mailing := range mailings {
+		res[i] = convertMailingToAPI(mailing)
+	}
+	return &res
+}
+
+func convertMailingFromAPI(mailing Mailing) types.Mailing {
+	return types.Mailing{
+		Name:          mailing.Name,
+		EntryKey:      mailing.EntryKey,
+		RequiredState: mailing.RequiredState,
+	}
+}
+
+func convertMailingsFromAPI(mailings []Mailing) []types.Mailing {
+	res := make([]types.Mailing, len(mailings))
+	for i, mailing := range mailings {
+		res[i] = convertMailingFromAPI(mailing)
+	}
+	return res
+}
+
+func convertOptionalMailingsFromAPI(mailings *[]Mailing) []types.Mailing {
+	if mailings == nil {
+		return make([]types.Mailing, 0)
+	}
+
+	return convertMailingsFromAPI(*mailings)
+}
+
+func convertBlockToAPI(block types.Block) Block {
+	res := Block{
+		Type:      BlockType(block.Type),
+		NextState: block.NextState,
+		Options:   convertOptionsToAPI(block.Options),
+		State:     block.State,
+		Text:      block.Text,
+		Title:     block.Title,
+	}
+	if block.Unique {
+		res.Unique = &block.Unique
+		res.UniqueText = &block.UniqueText
+	}
+	if block.Format != "" {
+		format := BlockFormat(block.Format)
+		res.Format = &format
+	}
+	if block.Fallback != "" {
+		fallback := BlockFallback(block.Fallback)
+		res.Fallback = &fallback
+	}
+	if block.FallbackState != 0 {
+		res.FallbackState = &block.FallbackState
+	}
+	if block.FallbackText != "" {
+		res.FallbackText = &block.FallbackText
+	}
+	if block.Attachment.Type != "" {
+		res.Attachment = &Attachment{
+			Type:   AttachmentType(block.Attachment.Type),
+			FileId: block.Attachment.FileID,
+		}
+	}
+	res.Translations = convertTranslationsToAPI(block.Translations)
+	return res
+}
+
+func convertBlockFromAPI(block Block) types.Block {
+	res := types.Block{
+		Type:      string(block.Type),
+		State:     block.State,
+		NextState: block.NextState,
+		Options:   convertOptionsFromAPI(block.Options),
+		Title:     block.Title,
+		Text:      block.Text,
+	}
+	if block.Unique != nil {
+		res.Unique = *block.Unique
+	}
+	if block.UniqueText != nil {
+		res.UniqueText = *block.UniqueText
+	}
+	if block.Format != nil {
+		res.Format = string(*block.Format)
+	}
+	if block.Fallback != nil {
+		res.Fallback = string(*block.Fallback)
+	}
+	if block.FallbackState != nil {
+		res.FallbackState = *block.FallbackState
+	}
+	if block.FallbackText != nil {
+		res.FallbackText = *block.FallbackText
+	}
+	if block.Attachment != nil {
+		res.Attachment = types.Attachment{
+			Type:   string(block.Attachment.Type),
+			FileID: block.Attachment.FileId,
+		}
+	}
+	res.Translations = convertTranslationsFromAPI(block.Translations)
+	return res
+}
+
+func convertBlocksToAPI(blocks []types.Block) []Block {
+	res := make([]Block, len(blocks))
+	for i, block := range blocks {
+		res[i] = convertBlockToAPI(block)
+	}
+	return res
+}
+
+func convertBlocksFromAPI(blocks []Block) []types.Block {
+	res := make([]types.Block, len(blocks))
+	for i, block := range blocks {
+		res[i] = convertBlockFromAPI(block)
+	}
+	return res
+}
 
 func convertBotToAPI(bot types.Bot) Bot {
-	return Bot{
+	res := Bot{
 		Blocks:    convertBlocksToAPI(bot.Blocks),
 		BotUUID:   bot.UUID,
 		CreatedAt: bot.CreatedAt,
@@ -435,9 +1507,22 @@ func convertBotToAPI(bot types.Bot) Bot {
 		Mailings:  convertOptionalMailingsToAPI(bot.Mailings),
 		Name:      bot.Name,
 		Status:    BotStatus(bot.Status),
-		Token:     bot.Token,
+		Token:     bot.TokenMask,
 		UpdatedAt: bot.UpdatedAt,
 	}
+	if bot.LastError != "" {
+		res.LastError = &bot.LastError
+	}
+	if !bot.FailedAt.IsZero() {
+		res.FailedAt = &bot.FailedAt
+	}
+	if bot.TelegramID != 0 {
+		res.TelegramId = &bot.TelegramID
+	}
+	if bot.Username != "" {
+		res.Username = &bot.Username
+	}
+	return res
 }
 
 func convertBotsToAPI(bs []types.Bot) []Bot {
@@ -448,6 +1533,220 @@ func convertBotsToAPI(bs []types.Bot) []Bot {
 	return res
 }
 
+func convertMemberToAPI(member types.Member) Member {
+	return Member{
+		UserUUID: member.UserUUID,
+		Role:     MemberRole(member.Role),
+	}
+}
+
+func convertMemberFromAPI(member Member) types.Member {
+	return types.Member{
+		UserUUID: member.UserUUID,
+		Role:     string(member.Role),
+	}
+}
+
+func convertMembersToAPI(members []types.Member) []Member {
+	res := make([]Member, len(members))
+	for i, member := range members {
+		res[i] = convertMemberToAPI(member)
+	}
+	return res
+}
+
+func convertEventTypesToAPI(events []string) []EventType {
+	res := make([]EventType, len(events))
+	for i, event := range events {
+		res[i] = EventType(event)
+	}
+	return res
+}
+
+func convertEventTypesFromAPI(events []EventType) []string {
+	res := make([]string, len(events))
+	for i, event := range events {
+		res[i] = string(event)
+	}
+	return res
+}
+
+func convertWebhookToAPI(webhook types.Webhook) Webhook {
+	return Webhook{
+		WebhookUUID: webhook.UUID,
+		Url:         webhook.URL,
+		Events:      convertEventTypesToAPI(webhook.Events),
+		CreatedAt:   webhook.CreatedAt,
+	}
+}
+
+func convertWebhooksToAPI(webhooks []types.Webhook) []Webhook {
+	res := make([]Webhook, len(webhooks))
+	for i, webhook := range webhooks {
+		res[i] = convertWebhookToAPI(webhook)
+	}
+	return res
+}
+
+func convertWebhookDeliveryToAPI(delivery types.WebhookDelivery) WebhookDelivery {
+	res := WebhookDelivery{
+		DeliveryUUID: delivery.UUID,
+		Event:        EventType(delivery.Event),
+		Status:       WebhookDeliveryStatus(delivery.Status),
+		Attempts:     delivery.Attempts,
+		CreatedAt:    delivery.CreatedAt,
+		UpdatedAt:    delivery.UpdatedAt,
+	}
+	if delivery.ResponseCode != 0 {
+		res.ResponseCode = &delivery.ResponseCode
+	}
+	if delivery.LastError != "" {
+		res.LastError = &delivery.LastError
+	}
+	return res
+}
+
+func convertWebhookDeliveriesToAPI(deliveries []types.WebhookDelivery) []WebhookDelivery {
+	res := make([]WebhookDelivery, len(deliveries))
+	for i, delivery := range deliveries {
+		res[i] = convertWebhookDeliveryToAPI(delivery)
+	}
+	return res
+}
+
+func convertAnswersToAPI(answers []types.Answer) []Answer {
+	res := make([]Answer, len(answers))
+	for i, answer := range answers {
+		res[i] = Answer{
+			State: answer.State,
+			Text:  answer.Text,
+		}
+	}
+	return res
+}
+
+func convertParticipantToAPI(prt types.Participant) Participant {
+	res := Participant{
+		UserID:  prt.UserID,
+		State:   prt.State,
+		Paused:  prt.Paused,
+		Blocked: prt.Blocked,
+		Answers: convertAnswersToAPI(prt.Answers),
+	}
+	if prt.Locale != "" {
+		res.Locale = &prt.Locale
+	}
+	return res
+}
+
+func convertParticipantsToAPI(prts []types.Participant) []Participant {
+	res := make([]Participant, len(prts))
+	for i, prt := range prts {
+		res[i] = convertParticipantToAPI(prt)
+	}
+	return res
+}
+
+func convertSeatsToAPI(seats types.Seats) Seats {
+	return Seats{
+		EntryKey:   seats.EntryKey,
+		Capacity:   convertCapacityToAPI(seats.Capacity),
+		Registered: seats.Registered,
+		Waitlisted: seats.Waitlisted,
+	}
+}
+
+func convertThreadMessageToAPI(msg types.ThreadMessage) ThreadMessage {
+	res := ThreadMessage{
+		Direction: ThreadMessageDirection(msg.Direction),
+		Text:      msg.Text,
+		CreatedAt: msg.CreatedAt,
+	}
+	if msg.AuthorUUID != "" {
+		res.AuthorUUID = &msg.AuthorUUID
+	}
+	return res
+}
+
+func convertThreadMessagesToAPI(msgs []types.ThreadMessage) []ThreadMessage {
+	res := make([]ThreadMessage, len(msgs))
+	for i, msg := range msgs {
+		res[i] = convertThreadMessageToAPI(msg)
+	}
+	return res
+}
+
+func convertThreadsToAPI(threads []types.Thread) []Thread {
+	res := make([]Thread, len(threads))
+	for i, thread := range threads {
+		res[i] = Thread{
+			UserID:      thread.UserID,
+			Paused:      thread.Paused,
+			LastMessage: convertThreadMessageToAPI(thread.LastMessage),
+		}
+	}
+	return res
+}
+
+func convertAnswerEventToAPI(event types.AnswerEvent) AnswerEvent {
+	res := AnswerEvent{
+		EventID:    event.ID,
+		Type:       AnswerEventType(event.Type),
+		UserID:     event.UserID,
+		OccurredAt: event.OccurredAt,
+	}
+	if event.EntryKey != "" {
+		res.EntryKey = &event.EntryKey
+	}
+	if event.State != 0 {
+		res.State = &event.State
+	}
+	if event.Answer != "" {
+		res.Answer = &event.Answer
+	}
+	return res
+}
+
+func renderSSEAnswers(ctx context.Context, w http.ResponseWriter, events <-chan types.AnswerEvent) error {
+	rc := http.NewResponseController(w)
+
+	w.Header().Set("Content-Type", "text/event-stream")
+	w.Header().Set("Connection", "keep-alive")
+	w.Header().Set("X-Accel-Buffering", "no")
+	w.WriteHeader(http.StatusOK)
+	if err := rc.Flush(); err != nil {
+		return err
+	}
+
+	heartbeat := time.NewTicker(sseHeartbeatInterval)
+	defer heartbeat.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-heartbeat.C:
+			if _, err := fmt.Fprint(w, ": heartbeat\n\n"); err != nil {
+				return err
+			}
+		case event, ok := <-events:
+			if !ok {
+				return nil
+			}
+			b, err := json.Marshal(convertAnswerEventToAPI(event))
+			if err != nil {
+				return err
+			}
+			if _, err = fmt.Fprintf(w, "id: %d\nevent: %s\ndata: %s\n\n", event.ID, event.Type, b); err != nil {
+				return err
+			}
+		}
+		if err := rc.Flush(); err != nil {
+			return err
+		}
+	}
+}
+
 func renderCSVAnswers(w http.ResponseWriter, answers types.AnswersTable) error {
 	csvWriter := csv.NewWriter(w)
 	w.Header().Set("Content-Type", "text/csv")
@@ -30,85 +30,1115 @@ type ServerInterface interface {
 	// (GET /bots/{uuid}/answers)
 	GetAnswers(w http.ResponseWriter, r *http.Request, uuid string)
 
+	// (GET /bots/{uuid}/answers/stream)
+	StreamAnswers(w http.ResponseWriter, r *http.Request, uuid string, params StreamAnswersParams)
+
+	// (POST /bots/{uuid}/checkin)
+	CheckIn(w http.ResponseWriter, r *http.Request, uuid string)
+
+	// (GET /bots/{uuid}/entries/{key}/seats)
+	GetSeats(w http.ResponseWriter, r *http.Request, uuid string, key string)
+
+	// (DELETE /bots/{uuid}/entries/{key}/seats/{userID})
+	ReleaseSeat(w http.ResponseWriter, r *http.Request, uuid string, key string, userID int64)
+
+	// (POST /bots/{uuid}/files)
+	UploadFile(w http.ResponseWriter, r *http.Request, uuid string)
+
+	// (GET /bots/{uuid}/files/{fileId})
+	GetFile(w http.ResponseWriter, r *http.Request, uuid string, fileId string)
+
 	// (POST /bots/{uuid}/mailings)
 	CreateMailing(w http.ResponseWriter, r *http.Request, uuid string)
 
-	// (POST /bots/{uuid}/mailings/{entryKey}/start)
-	StartMailing(w http.ResponseWriter, r *http.Request, uuid string, entryKey string)
+	// (POST /bots/{uuid}/mailings/{entryKey}/start)
+	StartMailing(w http.ResponseWriter, r *http.Request, uuid string, entryKey string)
+
+	// (GET /bots/{uuid}/members)
+	GetBotMembers(w http.ResponseWriter, r *http.Request, uuid string)
+
+	// (PUT /bots/{uuid}/members)
+	SetBotMember(w http.ResponseWriter, r *http.Request, uuid string)
+
+	// (DELETE /bots/{uuid}/members/{userUUID})
+	DeleteBotMember(w http.ResponseWriter, r *http.Request, uuid string, userUUID string)
+
+	// (GET /bots/{uuid}/participants)
+	GetParticipants(w http.ResponseWriter, r *http.Request, uuid string, params GetParticipantsParams)
+
+	// (DELETE /bots/{uuid}/participants/{userID})
+	DeleteParticipant(w http.ResponseWriter, r *http.Request, uuid string, userID int64)
+
+	// (GET /bots/{uuid}/participants/{userID})
+	GetParticipant(w http.ResponseWriter, r *http.Request, uuid string, userID int64)
+
+	// (POST /bots/{uuid}/participants/{userID}/block)
+	BlockParticipant(w http.ResponseWriter, r *http.Request, uuid string, userID int64)
+
+	// (GET /bots/{uuid}/participants/{userID}/messages)
+	GetThreadMessages(w http.ResponseWriter, r *http.Request, uuid string, userID int64)
+
+	// (POST /bots/{uuid}/participants/{userID}/messages)
+	SendOperatorMessage(w http.ResponseWriter, r *http.Request, uuid string, userID int64)
+
+	// (POST /bots/{uuid}/participants/{userID}/pause)
+	PauseParticipant(w http.ResponseWriter, r *http.Request, uuid string, userID int64)
+
+	// (POST /bots/{uuid}/participants/{userID}/reset)
+	ResetParticipant(w http.ResponseWriter, r *http.Request, uuid string, userID int64)
+
+	// (POST /bots/{uuid}/participants/{userID}/resume)
+	ResumeParticipant(w http.ResponseWriter, r *http.Request, uuid string, userID int64)
+
+	// (POST /bots/{uuid}/participants/{userID}/unblock)
+	UnblockParticipant(w http.ResponseWriter, r *http.Request, uuid string, userID int64)
+
+	// (POST /bots/{uuid}/restart)
+	RestartBot(w http.ResponseWriter, r *http.Request, uuid string)
+
+	// (POST /bots/{uuid}/start)
+	StartBot(w http.ResponseWriter, r *http.Request, uuid string)
+
+	// (POST /bots/{uuid}/stop)
+	StopBot(w http.ResponseWriter, r *http.Request, uuid string)
+
+	// (GET /bots/{uuid}/threads)
+	GetThreads(w http.ResponseWriter, r *http.Request, uuid string)
+
+	// (GET /bots/{uuid}/webhooks)
+	GetWebhooks(w http.ResponseWriter, r *http.Request, uuid string)
+
+	// (POST /bots/{uuid}/webhooks)
+	CreateWebhook(w http.ResponseWriter, r *http.Request, uuid string)
+
+	// (DELETE /bots/{uuid}/webhooks/{webhookUUID})
+	DeleteWebhook(w http.ResponseWriter, r *http.Request, uuid string, webhookUUID string)
+
+	// (GET /bots/{uuid}/webhooks/{webhookUUID}/deliveries)
+	GetWebhookDeliveries(w http.ResponseWriter, r *http.Request, uuid string, webhookUUID string)
+}
+
+// Unimplemented server implementation that returns http.StatusNotImplemented for each endpoint.
+
+type Unimplemented struct{}
+
+// (GET /bots)
+func (_ Unimplemented) GetBots(w http.ResponseWriter, r *http.Request) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// (PUT /bots)
+func (_ Unimplemented) CreateBot(w http.ResponseWriter, r *http.Request) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// (DELETE /bots/{uuid})
+func (_ Unimplemented) DeleteBot(w http.ResponseWriter, r *http.Request, uuid string) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// (GET /bots/{uuid})
+func (_ Unimplemented) GetBot(w http.ResponseWriter, r *http.Request, uuid string) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// (GET /bots/{uuid}/answers)
+func (_ Unimplemented) GetAnswers(w http.ResponseWriter, r *http.Request, uuid string) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// (GET /bots/{uuid}/answers/stream)
+func (_ Unimplemented) StreamAnswers(w http.ResponseWriter, r *http.Request, uuid string, params StreamAnswersParams) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// (POST /bots/{uuid}/checkin)
+func (_ Unimplemented) CheckIn(w http.ResponseWriter, r *http.Request, uuid string) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// (GET /bots/{uuid}/entries/{key}/seats)
+func (_ Unimplemented) GetSeats(w http.ResponseWriter, r *http.Request, uuid string, key string) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// (DELETE /bots/{uuid}/entries/{key}/seats/{userID})
+func (_ Unimplemented) ReleaseSeat(w http.ResponseWriter, r *http.Request, uuid string, key string, userID int64) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// (POST /bots/{uuid}/files)
+func (_ Unimplemented) UploadFile(w http.ResponseWriter, r *http.Request, uuid string) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// (GET /bots/{uuid}/files/{fileId})
+func (_ Unimplemented) GetFile(w http.ResponseWriter, r *http.Request, uuid string, fileId string) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// (POST /bots/{uuid}/mailings)
+func (_ Unimplemented) CreateMailing(w http.ResponseWriter, r *http.Request, uuid string) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// (POST /bots/{uuid}/mailings/{entryKey}/start)
+func (_ Unimplemented) StartMailing(w http.ResponseWriter, r *http.Request, uuid string, entryKey string) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// (GET /bots/{uuid}/members)
+func (_ Unimplemented) GetBotMembers(w http.ResponseWriter, r *http.Request, uuid string) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// (PUT /bots/{uuid}/members)
+func (_ Unimplemented) SetBotMember(w http.ResponseWriter, r *http.Request, uuid string) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// (DELETE /bots/{uuid}/members/{userUUID})
+func (_ Unimplemented) DeleteBotMember(w http.ResponseWriter, r *http.Request, uuid string, userUUID string) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// (GET /bots/{uuid}/participants)
+func (_ Unimplemented) GetParticipants(w http.ResponseWriter, r *http.Request, uuid string, params GetParticipantsParams) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// (DELETE /bots/{uuid}/participants/{userID})
+func (_ Unimplemented) DeleteParticipant(w http.ResponseWriter, r *http.Request, uuid string, userID int64) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// (GET /bots/{uuid}/participants/{userID})
+func (_ Unimplemented) GetParticipant(w http.ResponseWriter, r *http.Request, uuid string, userID int64) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// (POST /bots/{uuid}/participants/{userID}/block)
+func (_ Unimplemented) BlockParticipant(w http.ResponseWriter, r *http.Request, uuid string, userID int64) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// (GET /bots/{uuid}/participants/{userID}/messages)
+func (_ Unimplemented) GetThreadMessages(w http.ResponseWriter, r *http.Request, uuid string, userID int64) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// (POST /bots/{uuid}/participants/{userID}/messages)
+func (_ Unimplemented) SendOperatorMessage(w http.ResponseWriter, r *http.Request, uuid string, userID int64) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// (POST /bots/{uuid}/participants/{userID}/pause)
+func (_ Unimplemented) PauseParticipant(w http.ResponseWriter, r *http.Request, uuid string, userID int64) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// (POST /bots/{uuid}/participants/{userID}/reset)
+func (_ Unimplemented) ResetParticipant(w http.ResponseWriter, r *http.Request, uuid string, userID int64) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// (POST /bots/{uuid}/participants/{userID}/resume)
+func (_ Unimplemented) ResumeParticipant(w http.ResponseWriter, r *http.Request, uuid string, userID int64) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// (POST /bots/{uuid}/participants/{userID}/unblock)
+func (_ Unimplemented) UnblockParticipant(w http.ResponseWriter, r *http.Request, uuid string, userID int64) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// (POST /bots/{uuid}/restart)
+func (_ Unimplemented) RestartBot(w http.ResponseWriter, r *http.Request, uuid string) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// (POST /bots/{uuid}/start)
+func (_ Unimplemented) StartBot(w http.ResponseWriter, r *http.Request, uuid string) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// (POST /bots/{uuid}/stop)
+func (_ Unimplemented) StopBot(w http.ResponseWriter, r *http.Request, uuid string) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// (GET /bots/{uuid}/threads)
+func (_ Unimplemented) GetThreads(w http.ResponseWriter, r *http.Request, uuid string) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// (GET /bots/{uuid}/webhooks)
+func (_ Unimplemented) GetWebhooks(w http.ResponseWriter, r *http.Request, uuid string) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// (POST /bots/{uuid}/webhooks)
+func (_ Unimplemented) CreateWebhook(w http.ResponseWriter, r *http.Request, uuid string) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// (DELETE /bots/{uuid}/webhooks/{webhookUUID})
+func (_ Unimplemented) DeleteWebhook(w http.ResponseWriter, r *http.Request, uuid string, webhookUUID string) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// (GET /bots/{uuid}/webhooks/{webhookUUID}/deliveries)
+func (_ Unimplemented) GetWebhookDeliveries(w http.ResponseWriter, r *http.Request, uuid string, webhookUUID string) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// ServerInterfaceWrapper converts contexts to parameters.
+type ServerInterfaceWrapper struct {
+	Handler            ServerInterface
+	HandlerMiddlewares []MiddlewareFunc
+	ErrorHandlerFunc   func(w http.ResponseWriter, r *http.Request, err error)
+}
+
+type MiddlewareFunc func(http.Handler) http.Handler
+
+// GetBots operation middleware
+func (siw *ServerInterfaceWrapper) GetBots(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{})
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.GetBots(w, r)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r.WithContext(ctx))
+}
+
+// CreateBot operation middleware
+func (siw *ServerInterfaceWrapper) CreateBot(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{})
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.CreateBot(w, r)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r.WithContext(ctx))
+}
+
+// DeleteBot operation middleware
+func (siw *ServerInterfaceWrapper) DeleteBot(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	var err error
+
+	// ------------- Path parameter "uuid" -------------
+	var uuid string
+
+	err = runtime.BindStyledParameterWithOptions("simple", "uuid", chi.URLParam(r, "uuid"), &uuid, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "uuid", Err: err})
+		return
+	}
+
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{})
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.DeleteBot(w, r, uuid)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r.WithContext(ctx))
+}
+
+// GetBot operation middleware
+func (siw *ServerInterfaceWrapper) GetBot(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	var err error
+
+	// ------------- Path parameter "uuid" -------------
+	var uuid string
+
+	err = runtime.BindStyledParameterWithOptions("simple", "uuid", chi.URLParam(r, "uuid"), &uuid, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "uuid", Err: err})
+		return
+	}
+
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{})
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.GetBot(w, r, uuid)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r.WithContext(ctx))
+}
+
+// GetAnswers operation middleware
+func (siw *ServerInterfaceWrapper) GetAnswers(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	var err error
+
+	// ------------- Path parameter "uuid" -------------
+	var uuid string
+
+	err = runtime.BindStyledParameterWithOptions("simple", "uuid", chi.URLParam(r, "uuid"), &uuid, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "uuid", Err: err})
+		return
+	}
+
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{})
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.GetAnswers(w, r, uuid)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r.WithContext(ctx))
+}
+
+// StreamAnswers operation middleware
+func (siw *ServerInterfaceWrapper) StreamAnswers(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	var err error
+
+	// ------------- Path parameter "uuid" -------------
+	var uuid string
+
+	err = runtime.BindStyledParameterWithOptions("simple", "uuid", chi.URLParam(r, "uuid"), &uuid, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "uuid", Err: err})
+		return
+	}
+
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{})
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params StreamAnswersParams
+
+	headers := r.Header
+
+	// ------------- Optional header parameter "Last-Event-ID" -------------
+	if valueList, found := headers[http.CanonicalHeaderKey("Last-Event-ID")]; found {
+		var LastEventID int64
+		n := len(valueList)
+		if n != 1 {
+			siw.ErrorHandlerFunc(w, r, &TooManyValuesForParamError{ParamName: "Last-Event-ID", Count: n})
+			return
+		}
+
+		err = runtime.BindStyledParameterWithOptions("simple", "Last-Event-ID", valueList[0], &LastEventID, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationHeader, Explode: false, Required: false})
+		if err != nil {
+			siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "Last-Event-ID", Err: err})
+			return
+		}
+
+		params.LastEventID = &LastEventID
+
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.StreamAnswers(w, r, uuid, params)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r.WithContext(ctx))
+}
+
+// CheckIn operation middleware
+func (siw *ServerInterfaceWrapper) CheckIn(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	var err error
+
+	// ------------- Path parameter "uuid" -------------
+	var uuid string
+
+	err = runtime.BindStyledParameterWithOptions("simple", "uuid", chi.URLParam(r, "uuid"), &uuid, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "uuid", Err: err})
+		return
+	}
+
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{})
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.CheckIn(w, r, uuid)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r.WithContext(ctx))
+}
+
+// GetSeats operation middleware
+func (siw *ServerInterfaceWrapper) GetSeats(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	var err error
+
+	// ------------- Path parameter "uuid" -------------
+	var uuid string
+
+	err = runtime.BindStyledParameterWithOptions("simple", "uuid", chi.URLParam(r, "uuid"), &uuid, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "uuid", Err: err})
+		return
+	}
+
+	// ------------- Path parameter "key" -------------
+	var key string
+
+	err = runtime.BindStyledParameterWithOptions("simple", "key", chi.URLParam(r, "key"), &key, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "key", Err: err})
+		return
+	}
+
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{})
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.GetSeats(w, r, uuid, key)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r.WithContext(ctx))
+}
+
+// ReleaseSeat operation middleware
+func (siw *ServerInterfaceWrapper) ReleaseSeat(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	var err error
+
+	// ------------- Path parameter "uuid" -------------
+	var uuid string
+
+	err = runtime.BindStyledParameterWithOptions("simple", "uuid", chi.URLParam(r, "uuid"), &uuid, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "uuid", Err: err})
+		return
+	}
+
+	// ------------- Path parameter "key" -------------
+	var key string
+
+	err = runtime.BindStyledParameterWithOptions("simple", "key", chi.URLParam(r, "key"), &key, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "key", Err: err})
+		return
+	}
+
+	// ------------- Path parameter "userID" -------------
+	var userID int64
+
+	err = runtime.BindStyledParameterWithOptions("simple", "userID", chi.URLParam(r, "userID"), &userID, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "userID", Err: err})
+		return
+	}
+
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{})
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.ReleaseSeat(w, r, uuid, key, userID)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r.WithContext(ctx))
+}
+
+// UploadFile operation middleware
+func (siw *ServerInterfaceWrapper) UploadFile(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	var err error
+
+	// ------------- Path parameter "uuid" -------------
+	var uuid string
+
+	err = runtime.BindStyledParameterWithOptions("simple", "uuid", chi.URLParam(r, "uuid"), &uuid, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "uuid", Err: err})
+		return
+	}
+
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{})
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.UploadFile(w, r, uuid)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r.WithContext(ctx))
+}
+
+// GetFile operation middleware
+func (siw *ServerInterfaceWrapper) GetFile(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	var err error
+
+	// ------------- Path parameter "uuid" -------------
+	var uuid string
+
+	err = runtime.BindStyledParameterWithOptions("simple", "uuid", chi.URLParam(r, "uuid"), &uuid, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "uuid", Err: err})
+		return
+	}
+
+	// ------------- Path parameter "fileId" -------------
+	var fileId string
+
+	err = runtime.BindStyledParameterWithOptions("simple", "fileId", chi.URLParam(r, "fileId"), &fileId, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "fileId", Err: err})
+		return
+	}
+
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{})
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.GetFile(w, r, uuid, fileId)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r.WithContext(ctx))
+}
+
+// CreateMailing operation middleware
+func (siw *ServerInterfaceWrapper) CreateMailing(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	var err error
+
+	// ------------- Path parameter "uuid" -------------
+	var uuid string
+
+	err = runtime.BindStyledParameterWithOptions("simple", "uuid", chi.URLParam(r, "uuid"), &uuid, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "uuid", Err: err})
+		return
+	}
+
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{})
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.CreateMailing(w, r, uuid)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r.WithContext(ctx))
+}
+
+// StartMailing operation middleware
+func (siw *ServerInterfaceWrapper) StartMailing(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	var err error
+
+	// ------------- Path parameter "uuid" -------------
+	var uuid string
+
+	err = runtime.BindStyledParameterWithOptions("simple", "uuid", chi.URLParam(r, "uuid"), &uuid, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "uuid", Err: err})
+		return
+	}
+
+	// ------------- Path parameter "entryKey" -------------
+	var entryKey string
+
+	err = runtime.BindStyledParameterWithOptions("simple", "entryKey", chi.URLParam(r, "entryKey"), &entryKey, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "entryKey", Err: err})
+		return
+	}
+
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{})
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.StartMailing(w, r, uuid, entryKey)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r.WithContext(ctx))
+}
+
+// GetBotMembers operation middleware
+func (siw *ServerInterfaceWrapper) GetBotMembers(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	var err error
+
+	// ------------- Path parameter "uuid" -------------
+	var uuid string
+
+	err = runtime.BindStyledParameterWithOptions("simple", "uuid", chi.URLParam(r, "uuid"), &uuid, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "uuid", Err: err})
+		return
+	}
+
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{})
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.GetBotMembers(w, r, uuid)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r.WithContext(ctx))
+}
+
+// SetBotMember operation middleware
+func (siw *ServerInterfaceWrapper) SetBotMember(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	var err error
+
+	// ------------- Path parameter "uuid" -------------
+	var uuid string
+
+	err = runtime.BindStyledParameterWithOptions("simple", "uuid", chi.URLParam(r, "uuid"), &uuid, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "uuid", Err: err})
+		return
+	}
+
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{})
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.SetBotMember(w, r, uuid)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r.WithContext(ctx))
+}
+
+// DeleteBotMember operation middleware
+func (siw *ServerInterfaceWrapper) DeleteBotMember(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	var err error
+
+	// ------------- Path parameter "uuid" -------------
+	var uuid string
+
+	err = runtime.BindStyledParameterWithOptions("simple", "uuid", chi.URLParam(r, "uuid"), &uuid, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "uuid", Err: err})
+		return
+	}
+
+	// ------------- Path parameter "userUUID" -------------
+	var userUUID string
+
+	err = runtime.BindStyledParameterWithOptions("simple", "userUUID", chi.URLParam(r, "userUUID"), &userUUID, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "userUUID", Err: err})
+		return
+	}
+
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{})
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.DeleteBotMember(w, r, uuid, userUUID)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r.WithContext(ctx))
+}
+
+// GetParticipants operation middleware
+func (siw *ServerInterfaceWrapper) GetParticipants(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	var err error
+
+	// ------------- Path parameter "uuid" -------------
+	var uuid string
+
+	err = runtime.BindStyledParameterWithOptions("simple", "uuid", chi.URLParam(r, "uuid"), &uuid, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "uuid", Err: err})
+		return
+	}
+
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{})
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params GetParticipantsParams
+
+	// ------------- Optional query parameter "offset" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "offset", r.URL.Query(), &params.Offset)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "offset", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "limit" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "limit", r.URL.Query(), &params.Limit)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "limit", Err: err})
+		return
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.GetParticipants(w, r, uuid, params)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r.WithContext(ctx))
+}
+
+// DeleteParticipant operation middleware
+func (siw *ServerInterfaceWrapper) DeleteParticipant(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	var err error
+
+	// ------------- Path parameter "uuid" -------------
+	var uuid string
+
+	err = runtime.BindStyledParameterWithOptions("simple", "uuid", chi.URLParam(r, "uuid"), &uuid, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "uuid", Err: err})
+		return
+	}
+
+	// ------------- Path parameter "userID" -------------
+	var userID int64
+
+	err = runtime.BindStyledParameterWithOptions("simple", "userID", chi.URLParam(r, "userID"), &userID, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "userID", Err: err})
+		return
+	}
+
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{})
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.DeleteParticipant(w, r, uuid, userID)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r.WithContext(ctx))
+}
+
+// GetParticipant operation middleware
+func (siw *ServerInterfaceWrapper) GetParticipant(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	var err error
+
+	// ------------- Path parameter "uuid" -------------
+	var uuid string
+
+	err = runtime.BindStyledParameterWithOptions("simple", "uuid", chi.URLParam(r, "uuid"), &uuid, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "uuid", Err: err})
+		return
+	}
+
+	// ------------- Path parameter "userID" -------------
+	var userID int64
+
+	err = runtime.BindStyledParameterWithOptions("simple", "userID", chi.URLParam(r, "userID"), &userID, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "userID", Err: err})
+		return
+	}
+
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{})
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.GetParticipant(w, r, uuid, userID)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r.WithContext(ctx))
+}
+
+// BlockParticipant operation middleware
+func (siw *ServerInterfaceWrapper) BlockParticipant(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	var err error
+
+	// ------------- Path parameter "uuid" -------------
+	var uuid string
+
+	err = runtime.BindStyledParameterWithOptions("simple", "uuid", chi.URLParam(r, "uuid"), &uuid, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "uuid", Err: err})
+		return
+	}
+
+	// ------------- Path parameter "userID" -------------
+	var userID int64
+
+	err = runtime.BindStyledParameterWithOptions("simple", "userID", chi.URLParam(r, "userID"), &userID, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "userID", Err: err})
+		return
+	}
+
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{})
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.BlockParticipant(w, r, uuid, userID)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r.WithContext(ctx))
+}
+
+// GetThreadMessages operation middleware
+func (siw *ServerInterfaceWrapper) GetThreadMessages(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	var err error
+
+	// ------------- Path parameter "uuid" -------------
+	var uuid string
+
+	err = runtime.BindStyledParameterWithOptions("simple", "uuid", chi.URLParam(r, "uuid"), &uuid, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "uuid", Err: err})
+		return
+	}
+
+	// ------------- Path parameter "userID" -------------
+	var userID int64
+
+	err = runtime.BindStyledParameterWithOptions("simple", "userID", chi.URLParam(r, "userID"), &userID, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "userID", Err: err})
+		return
+	}
+
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{})
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.GetThreadMessages(w, r, uuid, userID)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r.WithContext(ctx))
+}
+
+// SendOperatorMessage operation middleware
+func (siw *ServerInterfaceWrapper) SendOperatorMessage(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	var err error
+
+	// ------------- Path parameter "uuid" -------------
+	var uuid string
+
+	err = runtime.BindStyledParameterWithOptions("simple", "uuid", chi.URLParam(r, "uuid"), &uuid, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "uuid", Err: err})
+		return
+	}
+
+	// ------------- Path parameter "userID" -------------
+	var userID int64
+
+	err = runtime.BindStyledParameterWithOptions("simple", "userID", chi.URLParam(r, "userID"), &userID, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "userID", Err: err})
+		return
+	}
+
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{})
 
-	// (POST /bots/{uuid}/start)
-	StartBot(w http.ResponseWriter, r *http.Request, uuid string)
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.SendOperatorMessage(w, r, uuid, userID)
+	}))
 
-	// (POST /bots/{uuid}/stop)
-	StopBot(w http.ResponseWriter, r *http.Request, uuid string)
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r.WithContext(ctx))
 }
 
-// Unimplemented server implementation that returns http.StatusNotImplemented for each endpoint.
+// PauseParticipant operation middleware
+func (siw *ServerInterfaceWrapper) PauseParticipant(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
 
-type Unimplemented struct{}
+	var err error
 
-// (GET /bots)
-func (_ Unimplemented) GetBots(w http.ResponseWriter, r *http.Request) {
-	w.WriteHeader(http.StatusNotImplemented)
-}
+	// ------------- Path parameter "uuid" -------------
+	var uuid string
 
-// (PUT /bots)
-func (_ Unimplemented) CreateBot(w http.ResponseWriter, r *http.Request) {
-	w.WriteHeader(http.StatusNotImplemented)
-}
+	err = runtime.BindStyledParameterWithOptions("simple", "uuid", chi.URLParam(r, "uuid"), &uuid, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "uuid", Err: err})
+		return
+	}
 
-// (DELETE /bots/{uuid})
-func (_ Unimplemented) DeleteBot(w http.ResponseWriter, r *http.Request, uuid string) {
-	w.WriteHeader(http.StatusNotImplemented)
-}
+	// ------------- Path parameter "userID" -------------
+	var userID int64
 
-// (GET /bots/{uuid})
-func (_ Unimplemented) GetBot(w http.ResponseWriter, r *http.Request, uuid string) {
-	w.WriteHeader(http.StatusNotImplemented)
-}
+	err = runtime.BindStyledParameterWithOptions("simple", "userID", chi.URLParam(r, "userID"), &userID, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "userID", Err: err})
+		return
+	}
 
-// (GET /bots/{uuid}/answers)
-func (_ Unimplemented) GetAnswers(w http.ResponseWriter, r *http.Request, uuid string) {
-	w.WriteHeader(http.StatusNotImplemented)
-}
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{})
 
-// (POST /bots/{uuid}/mailings)
-func (_ Unimplemented) CreateMailing(w http.ResponseWriter, r *http.Request, uuid string) {
-	w.WriteHeader(http.StatusNotImplemented)
-}
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.PauseParticipant(w, r, uuid, userID)
+	}))
 
-// (POST /bots/{uuid}/mailings/{entryKey}/start)
-func (_ Unimplemented) StartMailing(w http.ResponseWriter, r *http.Request, uuid string, entryKey string) {
-	w.WriteHeader(http.StatusNotImplemented)
-}
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
 
-// (POST /bots/{uuid}/start)
-func (_ Unimplemented) StartBot(w http.ResponseWriter, r *http.Request, uuid string) {
-	w.WriteHeader(http.StatusNotImplemented)
+	handler.ServeHTTP(w, r.WithContext(ctx))
 }
 
-// (POST /bots/{uuid}/stop)
-func (_ Unimplemented) StopBot(w http.ResponseWriter, r *http.Request, uuid string) {
-	w.WriteHeader(http.StatusNotImplemented)
-}
+// ResetParticipant operation middleware
+func (siw *ServerInterfaceWrapper) ResetParticipant(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
 
-// ServerInterfaceWrapper converts contexts to parameters.
-type ServerInterfaceWrapper struct {
-	Handler            ServerInterface
-	HandlerMiddlewares []MiddlewareFunc
-	ErrorHandlerFunc   func(w http.ResponseWriter, r *http.Request, err error)
-}
+	var err error
 
-type MiddlewareFunc func(http.Handler) http.Handler
+	// ------------- Path parameter "uuid" -------------
+	var uuid string
 
-// GetBots operation middleware
-func (siw *ServerInterfaceWrapper) GetBots(w http.ResponseWriter, r *http.Request) {
+	err = runtime.BindStyledParameterWithOptions("simple", "uuid", chi.URLParam(r, "uuid"), &uuid, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "uuid", Err: err})
+		return
+	}
+
+	// ------------- Path parameter "userID" -------------
+	var userID int64
+
+	err = runtime.BindStyledParameterWithOptions("simple", "userID", chi.URLParam(r, "userID"), &userID, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "userID", Err: err})
+		return
+	}
+
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{})
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.ResetParticipant(w, r, uuid, userID)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r.WithContext(ctx))
+}
+
+// ResumeParticipant operation middleware
+func (siw *ServerInterfaceWrapper) ResumeParticipant(w http.ResponseWriter, r *http.Request) {
 	ctx := r.Context()
 
+	var err error
+
+	// ------------- Path parameter "uuid" -------------
+	var uuid string
+
+	err = runtime.BindStyledParameterWithOptions("simple", "uuid", chi.URLParam(r, "uuid"), &uuid, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "uuid", Err: err})
+		return
+	}
+
+	// ------------- Path parameter "userID" -------------
+	var userID int64
+
+	err = runtime.BindStyledParameterWithOptions("simple", "userID", chi.URLParam(r, "userID"), &userID, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "userID", Err: err})
+		return
+	}
+
 	ctx = context.WithValue(ctx, BearerAuthScopes, []string{})
 
 	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		siw.Handler.GetBots(w, r)
+		siw.Handler.ResumeParticipant(w, r, uuid, userID)
 	}))
 
 	for _, middleware := range siw.HandlerMiddlewares {
@@ -118,14 +1148,34 @@ func (siw *ServerInterfaceWrapper) GetBots(w http.ResponseWriter, r *http.Reques
 	handler.ServeHTTP(w, r.WithContext(ctx))
 }
 
-// CreateBot operation middleware
-func (siw *ServerInterfaceWrapper) CreateBot(w http.ResponseWriter, r *http.Request) {
+// UnblockParticipant operation middleware
+func (siw *ServerInterfaceWrapper) UnblockParticipant(w http.ResponseWriter, r *http.Request) {
 	ctx := r.Context()
 
+	var err error
+
+	// ------------- Path parameter "uuid" -------------
+	var uuid string
+
+	err = runtime.BindStyledParameterWithOptions("simple", "uuid", chi.URLParam(r, "uuid"), &uuid, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "uuid", Err: err})
+		return
+	}
+
+	// ------------- Path parameter "userID" -------------
+	var userID int64
+
+	err = runtime.BindStyledParameterWithOptions("simple", "userID", chi.URLParam(r, "userID"), &userID, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "userID", Err: err})
+		return
+	}
+
 	ctx = context.WithValue(ctx, BearerAuthScopes, []string{})
 
 	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		siw.Handler.CreateBot(w, r)
+		siw.Handler.UnblockParticipant(w, r, uuid, userID)
 	}))
 
 	for _, middleware := range siw.HandlerMiddlewares {
@@ -135,8 +1185,8 @@ func (siw *ServerInterfaceWrapper) CreateBot(w http.ResponseWriter, r *http.Requ
 	handler.ServeHTTP(w, r.WithContext(ctx))
 }
 
-// DeleteBot operation middleware
-func (siw *ServerInterfaceWrapper) DeleteBot(w http.ResponseWriter, r *http.Request) {
+// RestartBot operation middleware
+func (siw *ServerInterfaceWrapper) RestartBot(w http.ResponseWriter, r *http.Request) {
 	ctx := r.Context()
 
 	var err error
@@ -153,7 +1203,7 @@ func (siw *ServerInterfaceWrapper) DeleteBot(w http.ResponseWriter, r *http.Requ
 	ctx = context.WithValue(ctx, BearerAuthScopes, []string{})
 
 	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		siw.Handler.DeleteBot(w, r, uuid)
+		siw.Handler.RestartBot(w, r, uuid)
 	}))
 
 	for _, middleware := range siw.HandlerMiddlewares {
@@ -163,8 +1213,8 @@ func (siw *ServerInterfaceWrapper) DeleteBot(w http.ResponseWriter, r *http.Requ
 	handler.ServeHTTP(w, r.WithContext(ctx))
 }
 
-// GetBot operation middleware
-func (siw *ServerInterfaceWrapper) GetBot(w http.ResponseWriter, r *http.Request) {
+// StartBot operation middleware
+func (siw *ServerInterfaceWrapper) StartBot(w http.ResponseWriter, r *http.Request) {
 	ctx := r.Context()
 
 	var err error
@@ -181,7 +1231,7 @@ func (siw *ServerInterfaceWrapper) GetBot(w http.ResponseWriter, r *http.Request
 	ctx = context.WithValue(ctx, BearerAuthScopes, []string{})
 
 	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		siw.Handler.GetBot(w, r, uuid)
+		siw.Handler.StartBot(w, r, uuid)
 	}))
 
 	for _, middleware := range siw.HandlerMiddlewares {
@@ -191,8 +1241,8 @@ func (siw *ServerInterfaceWrapper) GetBot(w http.ResponseWriter, r *http.Request
 	handler.ServeHTTP(w, r.WithContext(ctx))
 }
 
-// GetAnswers operation middleware
-func (siw *ServerInterfaceWrapper) GetAnswers(w http.ResponseWriter, r *http.Request) {
+// StopBot operation middleware
+func (siw *ServerInterfaceWrapper) StopBot(w http.ResponseWriter, r *http.Request) {
 	ctx := r.Context()
 
 	var err error
@@ -209,7 +1259,7 @@ func (siw *ServerInterfaceWrapper) GetAnswers(w http.ResponseWriter, r *http.Req
 	ctx = context.WithValue(ctx, BearerAuthScopes, []string{})
 
 	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		siw.Handler.GetAnswers(w, r, uuid)
+		siw.Handler.StopBot(w, r, uuid)
 	}))
 
 	for _, middleware := range siw.HandlerMiddlewares {
@@ -219,8 +1269,8 @@ func (siw *ServerInterfaceWrapper) GetAnswers(w http.ResponseWriter, r *http.Req
 	handler.ServeHTTP(w, r.WithContext(ctx))
 }
 
-// CreateMailing operation middleware
-func (siw *ServerInterfaceWrapper) CreateMailing(w http.ResponseWriter, r *http.Request) {
+// GetThreads operation middleware
+func (siw *ServerInterfaceWrapper) GetThreads(w http.ResponseWriter, r *http.Request) {
 	ctx := r.Context()
 
 	var err error
@@ -237,7 +1287,7 @@ func (siw *ServerInterfaceWrapper) CreateMailing(w http.ResponseWriter, r *http.
 	ctx = context.WithValue(ctx, BearerAuthScopes, []string{})
 
 	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		siw.Handler.CreateMailing(w, r, uuid)
+		siw.Handler.GetThreads(w, r, uuid)
 	}))
 
 	for _, middleware := range siw.HandlerMiddlewares {
@@ -247,8 +1297,8 @@ func (siw *ServerInterfaceWrapper) CreateMailing(w http.ResponseWriter, r *http.
 	handler.ServeHTTP(w, r.WithContext(ctx))
 }
 
-// StartMailing operation middleware
-func (siw *ServerInterfaceWrapper) StartMailing(w http.ResponseWriter, r *http.Request) {
+// GetWebhooks operation middleware
+func (siw *ServerInterfaceWrapper) GetWebhooks(w http.ResponseWriter, r *http.Request) {
 	ctx := r.Context()
 
 	var err error
@@ -262,19 +1312,38 @@ func (siw *ServerInterfaceWrapper) StartMailing(w http.ResponseWriter, r *http.R
 		return
 	}
 
-	// ------------- Path parameter "entryKey" -------------
-	var entryKey string
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{})
 
-	err = runtime.BindStyledParameterWithOptions("simple", "entryKey", chi.URLParam(r, "entryKey"), &entryKey, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.GetWebhooks(w, r, uuid)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r.WithContext(ctx))
+}
+
+// CreateWebhook operation middleware
+func (siw *ServerInterfaceWrapper) CreateWebhook(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	var err error
+
+	// ------------- Path parameter "uuid" -------------
+	var uuid string
+
+	err = runtime.BindStyledParameterWithOptions("simple", "uuid", chi.URLParam(r, "uuid"), &uuid, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
 	if err != nil {
-		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "entryKey", Err: err})
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "uuid", Err: err})
 		return
 	}
 
 	ctx = context.WithValue(ctx, BearerAuthScopes, []string{})
 
 	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		siw.Handler.StartMailing(w, r, uuid, entryKey)
+		siw.Handler.CreateWebhook(w, r, uuid)
 	}))
 
 	for _, middleware := range siw.HandlerMiddlewares {
@@ -284,8 +1353,8 @@ func (siw *ServerInterfaceWrapper) StartMailing(w http.ResponseWriter, r *http.R
 	handler.ServeHTTP(w, r.WithContext(ctx))
 }
 
-// StartBot operation middleware
-func (siw *ServerInterfaceWrapper) StartBot(w http.ResponseWriter, r *http.Request) {
+// DeleteWebhook operation middleware
+func (siw *ServerInterfaceWrapper) DeleteWebhook(w http.ResponseWriter, r *http.Request) {
 	ctx := r.Context()
 
 	var err error
@@ -299,10 +1368,19 @@ func (siw *ServerInterfaceWrapper) StartBot(w http.ResponseWriter, r *http.Reque
 		return
 	}
 
+	// ------------- Path parameter "webhookUUID" -------------
+	var webhookUUID string
+
+	err = runtime.BindStyledParameterWithOptions("simple", "webhookUUID", chi.URLParam(r, "webhookUUID"), &webhookUUID, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "webhookUUID", Err: err})
+		return
+	}
+
 	ctx = context.WithValue(ctx, BearerAuthScopes, []string{})
 
 	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		siw.Handler.StartBot(w, r, uuid)
+		siw.Handler.DeleteWebhook(w, r, uuid, webhookUUID)
 	}))
 
 	for _, middleware := range siw.HandlerMiddlewares {
@@ -312,8 +1390,8 @@ func (siw *ServerInterfaceWrapper) StartBot(w http.ResponseWriter, r *http.Reque
 	handler.ServeHTTP(w, r.WithContext(ctx))
 }
 
-// StopBot operation middleware
-func (siw *ServerInterfaceWrapper) StopBot(w http.ResponseWriter, r *http.Request) {
+// GetWebhookDeliveries operation middleware
+func (siw *ServerInterfaceWrapper) GetWebhookDeliveries(w http.ResponseWriter, r *http.Request) {
 	ctx := r.Context()
 
 	var err error
@@ -327,10 +1405,19 @@ func (siw *ServerInterfaceWrapper) StopBot(w http.ResponseWriter, r *http.Reques
 		return
 	}
 
+	// ------------- Path parameter "webhookUUID" -------------
+	var webhookUUID string
+
+	err = runtime.BindStyledParameterWithOptions("simple", "webhookUUID", chi.URLParam(r, "webhookUUID"), &webhookUUID, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "webhookUUID", Err: err})
+		return
+	}
+
 	ctx = context.WithValue(ctx, BearerAuthScopes, []string{})
 
 	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		siw.Handler.StopBot(w, r, uuid)
+		siw.Handler.GetWebhookDeliveries(w, r, uuid, webhookUUID)
 	}))
 
 	for _, middleware := range siw.HandlerMiddlewares {
@@ -468,18 +1555,93 @@ func HandlerWithOptions(si ServerInterface, options ChiServerOptions) http.Handl
 	r.Group(func(r chi.Router) {
 		r.Get(options.BaseURL+"/bots/{uuid}/answers", wrapper.GetAnswers)
 	})
+	r.Group(func(r chi.Router) {
+		r.Get(options.BaseURL+"/bots/{uuid}/answers/stream", wrapper.StreamAnswers)
+	})
+	r.Group(func(r chi.Router) {
+		r.Post(options.BaseURL+"/bots/{uuid}/checkin", wrapper.CheckIn)
+	})
+	r.Group(func(r chi.Router) {
+		r.Get(options.BaseURL+"/bots/{uuid}/entries/{key}/seats", wrapper.GetSeats)
+	})
+	r.Group(func(r chi.Router) {
+		r.Delete(options.BaseURL+"/bots/{uuid}/entries/{key}/seats/{userID}", wrapper.ReleaseSeat)
+	})
+	r.Group(func(r chi.Router) {
+		r.Post(options.BaseURL+"/bots/{uuid}/files", wrapper.UploadFile)
+	})
+	r.Group(func(r chi.Router) {
+		r.Get(options.BaseURL+"/bots/{uuid}/files/{fileId}", wrapper.GetFile)
+	})
 	r.Group(func(r chi.Router) {
 		r.Post(options.BaseURL+"/bots/{uuid}/mailings", wrapper.CreateMailing)
 	})
 	r.Group(func(r chi.Router) {
 		r.Post(options.BaseURL+"/bots/{uuid}/mailings/{entryKey}/start", wrapper.StartMailing)
 	})
+	r.Group(func(r chi.Router) {
+		r.Get(options.BaseURL+"/bots/{uuid}/members", wrapper.GetBotMembers)
+	})
+	r.Group(func(r chi.Router) {
+		r.Put(options.BaseURL+"/bots/{uuid}/members", wrapper.SetBotMember)
+	})
+	r.Group(func(r chi.Router) {
+		r.Delete(options.BaseURL+"/bots/{uuid}/members/{userUUID}", wrapper.DeleteBotMember)
+	})
+	r.Group(func(r chi.Router) {
+		r.Get(options.BaseURL+"/bots/{uuid}/participants", wrapper.GetParticipants)
+	})
+	r.Group(func(r chi.Router) {
+		r.Delete(options.BaseURL+"/bots/{uuid}/participants/{userID}", wrapper.DeleteParticipant)
+	})
+	r.Group(func(r chi.Router) {
+		r.Get(options.BaseURL+"/bots/{uuid}/participants/{userID}", wrapper.GetParticipant)
+	})
+	r.Group(func(r chi.Router) {
+		r.Post(options.BaseURL+"/bots/{uuid}/participants/{userID}/block", wrapper.BlockParticipant)
+	})
+	r.Group(func(r chi.Router) {
+		r.Get(options.BaseURL+"/bots/{uuid}/participants/{userID}/messages", wrapper.GetThreadMessages)
+	})
+	r.Group(func(r chi.Router) {
+		r.Post(options.BaseURL+"/bots/{uuid}/participants/{userID}/messages", wrapper.SendOperatorMessage)
+	})
+	r.Group(func(r chi.Router) {
+		r.Post(options.BaseURL+"/bots/{uuid}/participants/{userID}/pause", wrapper.PauseParticipant)
+	})
+	r.Group(func(r chi.Router) {
+		r.Post(options.BaseURL+"/bots/{uuid}/participants/{userID}/reset", wrapper.ResetParticipant)
+	})
+	r.Group(func(r chi.Router) {
+		r.Post(options.BaseURL+"/bots/{uuid}/participants/{userID}/resume", wrapper.ResumeParticipant)
+	})
+	r.Group(func(r chi.Router) {
+		r.Post(options.BaseURL+"/bots/{uuid}/participants/{userID}/unblock", wrapper.UnblockParticipant)
+	})
+	r.Group(func(r chi.Router) {
+		r.Post(options.BaseURL+"/bots/{uuid}/restart", wrapper.RestartBot)
+	})
 	r.Group(func(r chi.Router) {
 		r.Post(options.BaseURL+"/bots/{uuid}/start", wrapper.StartBot)
 	})
 	r.Group(func(r chi.Router) {
 		r.Post(options.BaseURL+"/bots/{uuid}/stop", wrapper.StopBot)
 	})
+	r.Group(func(r chi.Router) {
+		r.Get(options.BaseURL+"/bots/{uuid}/threads", wrapper.GetThreads)
+	})
+	r.Group(func(r chi.Router) {
+		r.Get(options.BaseURL+"/bots/{uuid}/webhooks", wrapper.GetWebhooks)
+	})
+	r.Group(func(r chi.Router) {
+		r.Post(options.BaseURL+"/bots/{uuid}/webhooks", wrapper.CreateWebhook)
+	})
+	r.Group(func(r chi.Router) {
+		r.Delete(options.BaseURL+"/bots/{uuid}/webhooks/{webhookUUID}", wrapper.DeleteWebhook)
+	})
+	r.Group(func(r chi.Router) {
+		r.Get(options.BaseURL+"/bots/{uuid}/webhooks/{webhookUUID}/deliveries", wrapper.GetWebhookDeliveries)
+	})
 
 	return r
 }
@@ -0,0 +1,56 @@
+package telegram
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/go-chi/render"
+
+	"github.com/bmstu-itstech/itsreg-bots/internal/app/query"
+)
+
+type botsReport struct {
+	Instance   string      `json:"instance"`
+	Running    []botStatus `json:"running"`
+	NotRunning []string    `json:"not_running"`
+}
+
+func (p *Port) AdminRoutes(router chi.Router) {
+	router.Get("/bots", p.handleBotsReport)
+}
+
+func (p *Port) handleBotsReport(w http.ResponseWriter, r *http.Request) {
+	report := botsReport{
+		Instance:   p.instanceID,
+		Running:    p.registry.statuses(time.Now()),
+		NotRunning: make([]string, 0),
+	}
+
+	started, err := p.app.Queries.StartedBots.Handle(r.Context(), query.GetStartedBots{})
+	if err != nil {
+		render.Status(r, http.StatusInternalServerError)
+		render.JSON(w, r, map[string]string{"error": err.Error()})
+		return
+	}
+
+	leases, err := p.app.Queries.Leases.Handle(r.Context(), query.GetLeases{})
+	if err != nil {
+		render.Status(r, http.StatusInternalServerError)
+		render.JSON(w, r, map[string]string{"error": err.Error()})
+		return
+	}
+
+	owned := make(map[string]bool, len(leases))
+	for _, lease := range leases {
+		owned[lease.BotUUID] = true
+	}
+
+	for _, bot := range started {
+		if _, ok := p.bot(bot.UUID); !ok && !owned[bot.UUID] {
+			report.NotRunning = append(report.NotRunning, bot.UUID)
+		}
+	}
+
+	render.JSON(w, r, report)
+}
@@ -0,0 +1,131 @@
+package telegram
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/bmstu-itstech/itsreg-bots/internal/app"
+	"github.com/bmstu-itstech/itsreg-bots/internal/app/query"
+	"github.com/bmstu-itstech/itsreg-bots/internal/app/types"
+)
+
+type stubQueryHandler[Q any, R any] struct {
+	res R
+	err error
+}
+
+func (h stubQueryHandler[Q, R]) Handle(_ context.Context, _ Q) (R, error) {
+	return h.res, h.err
+}
+
+func newTestPort(started []types.Bot, leases []types.Lease, err error) *Port {
+	return &Port{
+		registry: newBotRegistry(),
+		app: &app.Application{
+			Queries: app.Queries{
+				StartedBots: stubQueryHandler[query.GetStartedBots, []types.Bot]{res: started, err: err},
+				Leases:      stubQueryHandler[query.GetLeases, []types.Lease]{res: leases},
+			},
+		},
+		instanceID: "instance-a",
+		log:        slog.New(slog.NewTextHandler(io.Discard, nil)),
+	}
+}
+
+func newTestBot(botUUID string, startedAt time.Time) *telegramBot {
+	return &telegramBot{
+		botUUID: botUUID,
+		stopCh:  make(chan struct{}),
+		doneCh:  make(chan struct{}),
+		health:  newBotHealth(startedAt),
+	}
+}
+
+func TestBotHealth_Status(t *testing.T) {
+	now := time.Now()
+
+	t.Run("should be healthy after recent poll", func(t *testing.T) {
+		h := newBotHealth(now.Add(-time.Hour))
+		h.polled(now.Add(-time.Second), nil)
+		h.updated(now.Add(-2 * time.Second))
+
+		status := h.status("bot", now)
+		require.True(t, status.Healthy)
+		require.NotNil(t, status.LastPollAt)
+		require.NotNil(t, status.LastUpdateAt)
+		require.Empty(t, status.LastError)
+	})
+
+	t.Run("should be unhealthy after failed poll", func(t *testing.T) {
+		h := newBotHealth(now.Add(-time.Minute))
+		h.polled(now, errors.New("bad gateway"))
+
+		status := h.status("bot", now)
+		require.False(t, status.Healthy)
+		require.Equal(t, "bad gateway", status.LastError)
+	})
+
+	t.Run("should be unhealthy if polling stalled", func(t *testing.T) {
+		h := newBotHealth(now.Add(-time.Hour))
+		h.polled(now.Add(-3*pollTimeout*time.Second), nil)
+
+		status := h.status("bot", now)
+		require.False(t, status.Healthy)
+	})
+}
+
+func TestPort_HandleBotsReport(t *testing.T) {
+	t.Run("should report running and not running bots", func(t *testing.T) {
+		started := []types.Bot{{UUID: "bot-1"}, {UUID: "bot-2"}, {UUID: "bot-3"}, {UUID: "bot-4"}}
+		leases := []types.Lease{
+			{BotUUID: "bot-1", InstanceID: "instance-a"},
+			{BotUUID: "bot-2", InstanceID: "instance-a"},
+			{BotUUID: "bot-4", InstanceID: "instance-b"},
+		}
+		p := newTestPort(started, leases, nil)
+
+		now := time.Now()
+		healthy := newTestBot("bot-1", now)
+		healthy.health.polled(now, nil)
+		failing := newTestBot("bot-2", now)
+		failing.health.polled(now, errors.New("bad gateway"))
+		for _, tgBot := range []*telegramBot{healthy, failing} {
+			added, err := p.registry.add(tgBot.botUUID, tgBot)
+			require.NoError(t, err)
+			require.True(t, added)
+		}
+
+		rec := httptest.NewRecorder()
+		p.handleBotsReport(rec, httptest.NewRequest(http.MethodGet, "/bots", nil))
+		require.Equal(t, http.StatusOK, rec.Code)
+
+		var report botsReport
+		require.NoError(t, json.NewDecoder(rec.Body).Decode(&report))
+		require.Equal(t, "instance-a", report.Instance)
+		require.Equal(t, []string{"bot-3"}, report.NotRunning)
+
+		require.Len(t, report.Running, 2)
+		require.Equal(t, "bot-1", report.Running[0].BotUUID)
+		require.True(t, report.Running[0].Healthy)
+		require.Equal(t, "bot-2", report.Running[1].BotUUID)
+		require.False(t, report.Running[1].Healthy)
+		require.Equal(t, "bad gateway", report.Running[1].LastError)
+	})
+
+	t.Run("should return 500 if started bots are unavailable", func(t *testing.T) {
+		p := newTestPort(nil, nil, errors.New("database is down"))
+
+		rec := httptest.NewRecorder()
+		p.handleBotsReport(rec, httptest.NewRequest(http.MethodGet, "/bots", nil))
+		require.Equal(t, http.StatusInternalServerError, rec.Code)
+	})
+}
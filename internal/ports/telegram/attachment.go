@@ -0,0 +1,176 @@
+package telegram
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"unicode/utf8"
+
+	tg "github.com/go-telegram-bot-api/telegram-bot-api"
+	"github.com/google/uuid"
+
+	"github.com/bmstu-itstech/itsreg-bots/internal/app/command"
+	"github.com/bmstu-itstech/itsreg-bots/internal/app/query"
+)
+
+const captionLimit = 1024
+
+func attachmentCaption(msg botMessage) string {
+	if msg.Ticket != "" || utf8.RuneCountInString(msg.Text) > captionLimit {
+		return ""
+	}
+	return msg.Text
+}
+
+func (b *telegramBot) sendAttachment(
+	ctx context.Context,
+	toUserID int64,
+	attachmentType string,
+	fileID string,
+	caption string,
+	parseMode string,
+	markup interface{},
+) error {
+	file, err := b.app.Queries.File.Handle(ctx, query.GetFile{BotUUID: b.botUUID, FileID: fileID})
+	if err != nil {
+		return err
+	}
+	defer file.Content.Close()
+
+	reader := tg.FileReader{Name: file.Name, Reader: file.Content, Size: file.Size}
+
+	var c tg.Chattable
+	switch attachmentType {
+	case "photo":
+		photo := tg.NewPhotoUpload(toUserID, reader)
+		photo.Caption = caption
+		photo.ParseMode = parseMode
+		photo.ReplyMarkup = markup
+		c = photo
+	case "document":
+		doc := tg.NewDocumentUpload(toUserID, reader)
+		doc.Caption = caption
+		doc.ParseMode = parseMode
+		doc.ReplyMarkup = markup
+		c = doc
+	case "voice":
+		voice := tg.NewVoiceUpload(toUserID, reader)
+		voice.Caption = caption
+		voice.ParseMode = parseMode
+		voice.ReplyMarkup = markup
+		c = voice
+	default:
+		return fmt.Errorf("unknown attachment type: %s", attachmentType)
+	}
+
+	_, err = b.api.Send(c)
+	return err
+}
+
+type incomingUpload struct {
+	Type     string
+	FileID   string
+	Name     string
+	MimeType string
+	Size     int64
+}
+
+func uploadFromMessage(msg *tg.Message) (incomingUpload, bool) {
+	switch {
+	case msg.Photo != nil && len(*msg.Photo) > 0:
+		photos := *msg.Photo
+		largest := photos[len(photos)-1]
+		return incomingUpload{
+			Type:     "photo",
+			FileID:   largest.FileID,
+			Name:     "photo.jpg",
+			MimeType: "image/jpeg",
+			Size:     int64(largest.FileSize),
+		}, true
+	case msg.Document != nil:
+		return incomingUpload{
+			Type:     "document",
+			FileID:   msg.Document.FileID,
+			Name:     msg.Document.FileName,
+			MimeType: msg.Document.MimeType,
+			Size:     int64(msg.Document.FileSize),
+		}, true
+	case msg.Voice != nil:
+		return incomingUpload{
+			Type:     "voice",
+			FileID:   msg.Voice.FileID,
+			Name:     "voice.ogg",
+			MimeType: msg.Voice.MimeType,
+			Size:     int64(msg.Voice.FileSize),
+		}, true
+	}
+	return incomingUpload{}, false
+}
+
+func (b *telegramBot) handleUpload(ctx context.Context, userID int64, locale string, upload incomingUpload) error {
+	content := &lazyDownload{open: func() (io.ReadCloser, error) {
+		return b.download(ctx, upload.FileID)
+	}}
+	defer content.Close()
+
+	return b.app.Commands.ProcessUpload.Handle(ctx, command.ProcessUpload{
+		BotUUID:        b.botUUID,
+		UserID:         userID,
+		Locale:         locale,
+		Type:           upload.Type,
+		TelegramFileID: upload.FileID,
+		FileID:         uuid.NewString(),
+		Name:           upload.Name,
+		MimeType:       upload.MimeType,
+		Size:           upload.Size,
+		Content:        content,
+	})
+}
+
+func (b *telegramBot) download(ctx context.Context, fileID string) (io.ReadCloser, error) {
+	url, err := b.api.GetFileDirectURL(fileID)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return nil, err
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		_ = resp.Body.Close()
+		return nil, fmt.Errorf("failed to download file %s: %s", fileID, resp.Status)
+	}
+
+	return resp.Body, nil
+}
+
+type lazyDownload struct {
+	open func() (io.ReadCloser, error)
+	rc   io.ReadCloser
+}
+
+func (d *lazyDownload) Read(p []byte) (int, error) {
+	if d.rc == nil {
+		rc, err := d.open()
+		if err != nil {
+			return 0, err
+		}
+		d.rc = rc
+	}
+	return d.rc.Read(p)
+}
+
+func (d *lazyDownload) Close() error {
+	if d.rc == nil {
+		return nil
+	}
+	return d.rc.Close()
+}
@@ -0,0 +1,115 @@
+package telegram
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"time"
+
+	tg "github.com/go-telegram-bot-api/telegram-bot-api"
+
+	"github.com/bmstu-itstech/itsreg-bots/internal/app/command"
+)
+
+const (
+	startRetryMin      = time.Second
+	startRetryMax      = 5 * time.Minute
+	startRetryAttempts = 10
+	pollRetryMax       = time.Minute
+)
+
+func isPermanentError(err error) bool {
+	var apiErr tg.Error
+	if !errors.As(err, &apiErr) {
+		return false
+	}
+	return strings.Contains(apiErr.Message, "Unauthorized") || strings.Contains(apiErr.Message, "Not Found")
+}
+
+func retryDelay(err error, min time.Duration, max time.Duration, attempt int) time.Duration {
+	var apiErr tg.Error
+	if errors.As(err, &apiErr) && apiErr.RetryAfter > 0 {
+		return time.Duration(apiErr.RetryAfter) * time.Second
+	}
+
+	if attempt > 30 {
+		return max
+	}
+	d := min << attempt
+	if d > max {
+		return max
+	}
+	return d
+}
+
+type startRetry struct {
+	botUUID string
+	cancel  context.CancelFunc
+}
+
+func (p *Port) retryStartBot(botUUID string, cause error) {
+	ctx, cancel := context.WithCancel(context.Background())
+	retry := &startRetry{botUUID: botUUID, cancel: cancel}
+	if !p.registry.addRetry(botUUID, retry) {
+		cancel()
+		return
+	}
+
+	go func() {
+		defer func() {
+			p.registry.removeRetry(botUUID, retry)
+			cancel()
+		}()
+
+		err := cause
+		for attempt := 0; attempt < startRetryAttempts; attempt++ {
+			select {
+			case <-ctx.Done():
+				return
+			case <-time.After(retryDelay(err, startRetryMin, startRetryMax, attempt)):
+			}
+
+			err = p.launchBot(ctx, botUUID)
+			if err == nil || errors.Is(err, errRegistryClosed) {
+				return
+			}
+			if isPermanentError(err) {
+				break
+			}
+			p.log.Warn("failed to start bot, retrying", "bot_uuid", botUUID, "attempt", attempt+1, "error", err.Error())
+		}
+
+		if ctx.Err() != nil {
+			return
+		}
+
+		err = p.failBot(context.Background(), botUUID, err)
+		if err != nil {
+			p.log.Error("failed to mark bot as failed", "bot_uuid", botUUID, "error", err.Error())
+		}
+	}()
+}
+
+func (p *Port) failBot(ctx context.Context, botUUID string, cause error) error {
+	tgBot, ok := p.registry.remove(botUUID)
+	if ok {
+		tgBot.halt()
+		cause = tgBot.redact(cause)
+	}
+
+	p.log.Error("bot failed", "bot_uuid", botUUID, "error", cause.Error())
+	defer p.releaseBot(ctx, botUUID)
+
+	return p.app.Commands.UpdateStatus.Handle(ctx, command.UpdateStatus{
+		BotUUID: botUUID,
+		Status:  "failed",
+		Reason:  cause.Error(),
+	})
+}
+
+func (p *Port) handleBotFailure(botUUID string, cause error) {
+	err := p.failBot(context.Background(), botUUID, cause)
+	if err != nil {
+		p.log.Error("failed to mark bot as failed", "bot_uuid", botUUID, "error", err.Error())
+	}
+}
@@ -0,0 +1,75 @@
+package telegram
+
+import (
+	"sync"
+	"time"
+)
+
+const (
+	pollTimeout    = 60
+	pollRetryDelay = 3 * time.Second
+)
+
+type botHealth struct {
+	mu sync.RWMutex
+
+	startedAt    time.Time
+	lastPollAt   time.Time
+	lastPollErr  error
+	lastUpdateAt time.Time
+}
+
+func newBotHealth(startedAt time.Time) *botHealth {
+	return &botHealth{startedAt: startedAt}
+}
+
+func (h *botHealth) polled(at time.Time, err error) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	h.lastPollErr = err
+	if err == nil {
+		h.lastPollAt = at
+	}
+}
+
+func (h *botHealth) updated(at time.Time) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	h.lastUpdateAt = at
+}
+
+type botStatus struct {
+	BotUUID      string     `json:"bot_uuid"`
+	Healthy      bool       `json:"healthy"`
+	StartedAt    time.Time  `json:"started_at"`
+	LastPollAt   *time.Time `json:"last_poll_at,omitempty"`
+	LastUpdateAt *time.Time `json:"last_update_at,omitempty"`
+	LastError    string     `json:"last_error,omitempty"`
+}
+
+func (h *botHealth) status(botUUID string, now time.Time) botStatus {
+	h.mu.RLock()
+	defer h.mu.RUnlock()
+
+	res := botStatus{
+		BotUUID:   botUUID,
+		StartedAt: h.startedAt,
+	}
+
+	lastSeen := h.startedAt
+	if !h.lastPollAt.IsZero() {
+		res.LastPollAt = &h.lastPollAt
+		lastSeen = h.lastPollAt
+	}
+	if !h.lastUpdateAt.IsZero() {
+		res.LastUpdateAt = &h.lastUpdateAt
+	}
+	if h.lastPollErr != nil {
+		res.LastError = h.lastPollErr.Error()
+	}
+
+	res.Healthy = h.lastPollErr == nil && now.Sub(lastSeen) <= 2*pollTimeout*time.Second
+	return res
+}
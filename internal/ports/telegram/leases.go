@@ -0,0 +1,127 @@
+package telegram
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/bmstu-itstech/itsreg-bots/internal/app/command"
+	"github.com/bmstu-itstech/itsreg-bots/internal/app/query"
+	"github.com/bmstu-itstech/itsreg-bots/internal/domain/bots"
+)
+
+const (
+	leaseRenewInterval = bots.LeaseTTL / 3
+	rebalanceInterval  = bots.LeaseTTL
+)
+
+func (p *Port) claimBot(ctx context.Context, botUUID string) (bool, error) {
+	err := p.app.Commands.ClaimBot.Handle(ctx, command.ClaimBot{
+		BotUUID:    botUUID,
+		InstanceID: p.instanceID,
+	})
+	if errors.Is(err, bots.ErrLeaseHeld) {
+		return false, nil
+	}
+	if err != nil {
+		return false, err
+	}
+	return true, nil
+}
+
+func (p *Port) releaseBot(ctx context.Context, botUUID string) {
+	err := p.app.Commands.ReleaseBot.Handle(ctx, command.ReleaseBot{
+		BotUUID:    botUUID,
+		InstanceID: p.instanceID,
+	})
+	if err != nil {
+		p.log.Error("failed to release bot lease", "bot_uuid", botUUID, "error", err.Error())
+	}
+}
+
+func (p *Port) maintainLeases(ctx context.Context) {
+	renew := time.NewTicker(leaseRenewInterval)
+	defer renew.Stop()
+
+	rebalance := time.NewTicker(rebalanceInterval)
+	defer rebalance.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-renew.C:
+			p.renewLeases(ctx)
+		case <-rebalance.C:
+			p.startUnownedBots(ctx)
+		}
+	}
+}
+
+func (p *Port) renewLeases(ctx context.Context) {
+	err := p.app.Commands.RenewLeases.Handle(ctx, command.RenewLeases{InstanceID: p.instanceID})
+	if err != nil {
+		p.log.Error("failed to renew bot leases", "error", err.Error())
+		return
+	}
+
+	leases, err := p.app.Queries.Leases.Handle(ctx, query.GetLeases{})
+	if err != nil {
+		p.log.Error("failed to get bot leases", "error", err.Error())
+		return
+	}
+
+	owned := make(map[string]bool, len(leases))
+	for _, lease := range leases {
+		if lease.InstanceID == p.instanceID {
+			owned[lease.BotUUID] = true
+		}
+	}
+
+	p.dropLostBots(owned)
+}
+
+func (p *Port) dropLostBots(owned map[string]bool) {
+	tgBots, retries := p.registry.retain(func(botUUID string) bool {
+		return owned[botUUID]
+	})
+
+	for _, tgBot := range tgBots {
+		p.log.Warn("bot lease lost, stopping bot locally", "bot_uuid", tgBot.botUUID)
+		tgBot.halt()
+	}
+
+	for _, retry := range retries {
+		retry.cancel()
+	}
+}
+
+func (p *Port) startUnownedBots(ctx context.Context) {
+	started, err := p.app.Queries.StartedBots.Handle(ctx, query.GetStartedBots{})
+	if err != nil {
+		p.log.Error("failed to get started bots", "error", err.Error())
+		return
+	}
+
+	leases, err := p.app.Queries.Leases.Handle(ctx, query.GetLeases{})
+	if err != nil {
+		p.log.Error("failed to get bot leases", "error", err.Error())
+		return
+	}
+
+	owned := make(map[string]bool, len(leases))
+	for _, lease := range leases {
+		owned[lease.BotUUID] = true
+	}
+
+	for _, bot := range started {
+		if owned[bot.UUID] || p.registry.has(bot.UUID) {
+			continue
+		}
+
+		err = p.startBot(ctx, bot.UUID)
+		if err != nil {
+			p.log.Error("failed to start bot", "bot_uuid", bot.UUID, "error", err.Error())
+		}
+	}
+}
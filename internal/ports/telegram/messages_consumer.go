@@ -4,8 +4,12 @@ import (
 	"context"
 	"encoding/json"
 	"log/slog"
+	"time"
 
 	"github.com/ThreeDotsLabs/watermill/message"
+
+	"github.com/bmstu-itstech/itsreg-bots/internal/common/metrics"
+	"github.com/bmstu-itstech/itsreg-bots/internal/common/tracing"
 )
 
 type botMessageHandler func(ctx context.Context, msg botMessage) error
@@ -30,6 +34,8 @@ func newMessagesConsumer(
 
 func (c *messagesConsumer) Process() {
 	for msg := range c.ch {
+		observeConsumerLag("messages", msg)
+
 		botMsg, err := unmarshalBotMessage(msg)
 		if err != nil {
 			c.log.Error("failed to unmarshal bot message", "error", err.Error())
@@ -37,7 +43,9 @@ func (c *messagesConsumer) Process() {
 			continue
 		}
 
-		err = c.h(msg.Context(), botMsg)
+		ctx, span := tracing.StartConsumer(msg.Context(), "messagesConsumer.Process", msg.Metadata)
+		err = c.h(ctx, botMsg)
+		tracing.End(span, err)
 		if err != nil {
 			c.log.Error("failed to handle bot message", "error", err.Error())
 			msg.Nack()
@@ -52,6 +60,11 @@ type botMessage struct {
 	UserID  int64    `json:"user_id"`
 	Text    string   `json:"text"`
 	Buttons []string `json:"buttons"`
+	Ticket  string   `json:"ticket,omitempty"`
+
+	AttachmentType   string `json:"attachment_type,omitempty"`
+	AttachmentFileID string `json:"attachment_file_id,omitempty"`
+	Format           string `json:"format,omitempty"`
 }
 
 func unmarshalBotMessage(msg *message.Message) (botMessage, error) {
@@ -59,3 +72,11 @@ func unmarshalBotMessage(msg *message.Message) (botMessage, error) {
 	err := json.Unmarshal(msg.Payload, &res)
 	return res, err
 }
+
+func observeConsumerLag(topic string, msg *message.Message) {
+	publishedAt, err := time.Parse(time.RFC3339Nano, msg.Metadata.Get(metrics.PublishedAtMetadata))
+	if err != nil {
+		return
+	}
+	metrics.ObserveConsumerLag(topic, time.Since(publishedAt))
+}
@@ -0,0 +1,174 @@
+package telegram
+
+import (
+	"errors"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/bmstu-itstech/itsreg-bots/internal/common/metrics"
+)
+
+var errRegistryClosed = errors.New("bot registry is closed")
+
+type botRegistry struct {
+	mu      sync.RWMutex
+	bots    map[string]*telegramBot
+	retries map[string]*startRetry
+	closed  bool
+}
+
+func newBotRegistry() *botRegistry {
+	return &botRegistry{
+		bots:    make(map[string]*telegramBot),
+		retries: make(map[string]*startRetry),
+	}
+}
+
+func (r *botRegistry) bot(botUUID string) (*telegramBot, bool) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	tgBot, ok := r.bots[botUUID]
+	return tgBot, ok
+}
+
+func (r *botRegistry) has(botUUID string) bool {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	_, running := r.bots[botUUID]
+	_, retrying := r.retries[botUUID]
+	return running || retrying
+}
+
+func (r *botRegistry) add(botUUID string, tgBot *telegramBot) (bool, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if r.closed {
+		return false, errRegistryClosed
+	}
+	if _, ok := r.bots[botUUID]; ok {
+		return false, nil
+	}
+	r.bots[botUUID] = tgBot
+	metrics.SetRunningBots(len(r.bots))
+
+	return true, nil
+}
+
+func (r *botRegistry) remove(botUUID string) (*telegramBot, bool) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	tgBot, ok := r.bots[botUUID]
+	if !ok {
+		return nil, false
+	}
+	delete(r.bots, botUUID)
+	metrics.SetRunningBots(len(r.bots))
+
+	return tgBot, true
+}
+
+func (r *botRegistry) addRetry(botUUID string, retry *startRetry) bool {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if r.closed {
+		return false
+	}
+	if _, ok := r.retries[botUUID]; ok {
+		return false
+	}
+	r.retries[botUUID] = retry
+
+	return true
+}
+
+func (r *botRegistry) removeRetry(botUUID string, retry *startRetry) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if r.retries[botUUID] == retry {
+		delete(r.retries, botUUID)
+	}
+}
+
+func (r *botRegistry) takeRetry(botUUID string) (*startRetry, bool) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	retry, ok := r.retries[botUUID]
+	if ok {
+		delete(r.retries, botUUID)
+	}
+
+	return retry, ok
+}
+
+func (r *botRegistry) retain(keep func(botUUID string) bool) ([]*telegramBot, []*startRetry) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	var removedBots []*telegramBot
+	for botUUID, tgBot := range r.bots {
+		if !keep(botUUID) {
+			delete(r.bots, botUUID)
+			removedBots = append(removedBots, tgBot)
+		}
+	}
+
+	var removedRetries []*startRetry
+	for botUUID, retry := range r.retries {
+		if !keep(botUUID) {
+			delete(r.retries, botUUID)
+			removedRetries = append(removedRetries, retry)
+		}
+	}
+	metrics.SetRunningBots(len(r.bots))
+
+	return removedBots, removedRetries
+}
+
+func (r *botRegistry) close() ([]*telegramBot, []*startRetry) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.closed = true
+
+	tgBots := make([]*telegramBot, 0, len(r.bots))
+	for _, tgBot := range r.bots {
+		tgBots = append(tgBots, tgBot)
+	}
+
+	retries := make([]*startRetry, 0, len(r.retries))
+	for botUUID, retry := range r.retries {
+		delete(r.retries, botUUID)
+		retries = append(retries, retry)
+	}
+
+	return tgBots, retries
+}
+
+func (r *botRegistry) isClosed() bool {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	return r.closed
+}
+
+func (r *botRegistry) statuses(now time.Time) []botStatus {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	res := make([]botStatus, 0, len(r.bots))
+	for _, tgBot := range r.bots {
+		res = append(res, tgBot.Status(now))
+	}
+	sort.Slice(res, func(i, j int) bool {
+		return res[i].BotUUID < res[j].BotUUID
+	})
+	return res
+}
@@ -0,0 +1,73 @@
+package telegram
+
+import (
+	"fmt"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestBotRegistry_Concurrent(t *testing.T) {
+	r := newBotRegistry()
+
+	wg := sync.WaitGroup{}
+	for i := 0; i < 8; i++ {
+		wg.Add(1)
+		go func(worker int) {
+			defer wg.Done()
+			for j := 0; j < 100; j++ {
+				botUUID := fmt.Sprintf("bot-%d", j%10)
+				switch (worker + j) % 6 {
+				case 0:
+					if _, err := r.add(botUUID, newTestBot(botUUID, time.Now())); err != nil {
+						t.Errorf("add %s: %v", botUUID, err)
+					}
+				case 1:
+					r.remove(botUUID)
+				case 2:
+					if tgBot, ok := r.bot(botUUID); ok && tgBot.botUUID != botUUID {
+						t.Errorf("bot %s: got %s", botUUID, tgBot.botUUID)
+					}
+				case 3:
+					r.has(botUUID)
+				case 4:
+					r.retain(func(string) bool { return j%2 == 0 })
+				case 5:
+					r.statuses(time.Now())
+				}
+			}
+		}(i)
+	}
+	wg.Wait()
+}
+
+func TestBotRegistry_Close(t *testing.T) {
+	r := newBotRegistry()
+
+	running := newTestBot("bot-1", time.Now())
+	added, err := r.add(running.botUUID, running)
+	require.NoError(t, err)
+	require.True(t, added)
+	require.True(t, r.addRetry("bot-2", &startRetry{botUUID: "bot-2", cancel: func() {}}))
+
+	tgBots, retries := r.close()
+	require.Equal(t, []*telegramBot{running}, tgBots)
+	require.Len(t, retries, 1)
+	require.True(t, r.isClosed())
+
+	t.Run("should keep running bots until removed", func(t *testing.T) {
+		tgBot, ok := r.bot("bot-1")
+		require.True(t, ok)
+		require.Same(t, running, tgBot)
+	})
+
+	t.Run("should refuse to add bots", func(t *testing.T) {
+		added, err := r.add("bot-3", newTestBot("bot-3", time.Now()))
+		require.ErrorIs(t, err, errRegistryClosed)
+		require.False(t, added)
+		require.False(t, r.addRetry("bot-3", &startRetry{botUUID: "bot-3", cancel: func() {}}))
+		require.False(t, r.has("bot-3"))
+	})
+}
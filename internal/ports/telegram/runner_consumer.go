@@ -6,6 +6,8 @@ import (
 	"log/slog"
 
 	"github.com/ThreeDotsLabs/watermill/message"
+
+	"github.com/bmstu-itstech/itsreg-bots/internal/common/tracing"
 )
 
 type runnerMessagesHandler func(ctx context.Context, msg runnerMessage) error
@@ -30,6 +32,8 @@ func newRunnerConsumer(
 
 func (c *runnerConsumer) Process() {
 	for msg := range c.ch {
+		observeConsumerLag("runner", msg)
+
 		runnerMsg, err := unmarshalRunnerMessage(msg)
 		if err != nil {
 			c.log.Error("failed to unmarshal bot message", "error", err.Error())
@@ -37,7 +41,9 @@ func (c *runnerConsumer) Process() {
 			continue
 		}
 
-		err = c.h(msg.Context(), runnerMsg)
+		ctx, span := tracing.StartConsumer(msg.Context(), "runnerConsumer.Process", msg.Metadata)
+		err = c.h(ctx, runnerMsg)
+		tracing.End(span, err)
 		if err != nil {
 			c.log.Error("failed to handle bot message", "error", err.Error())
 			msg.Nack()
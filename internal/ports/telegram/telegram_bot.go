@@ -3,12 +3,22 @@ package telegram
 import (
 	"context"
 	"log/slog"
+	"sync"
+	"time"
 
 	tg "github.com/go-telegram-bot-api/telegram-bot-api"
+	"go.opentelemetry.io/otel/trace"
 
 	"github.com/bmstu-itstech/itsreg-bots/internal/app"
 	"github.com/bmstu-itstech/itsreg-bots/internal/app/command"
 	"github.com/bmstu-itstech/itsreg-bots/internal/app/query"
+	"github.com/bmstu-itstech/itsreg-bots/internal/common/tracing"
+	"github.com/bmstu-itstech/itsreg-bots/internal/domain/bots"
+)
+
+const (
+	updateWorkers   = 8
+	workerQueueSize = 16
 )
 
 type telegramBot struct {
@@ -16,44 +26,50 @@ type telegramBot struct {
 	app     *app.Application
 	log     *slog.Logger
 	stopCh  chan struct{}
+	stopped sync.Once
+	doneCh  chan struct{}
 	api     *tg.BotAPI
+	health  *botHealth
+	onFail  func(botUUID string, err error)
 }
 
 func newTelegramBot(
 	ctx context.Context,
 	botUUID string,
 	app *app.Application,
+	tokens bots.TokenCipher,
 	log *slog.Logger,
+	onFail func(botUUID string, err error),
 ) (*telegramBot, error) {
 	appBot, err := app.Queries.GetBot.Handle(ctx, query.GetBot{BotUUID: botUUID})
 	if err != nil {
 		return nil, err
 	}
 
-	api, err := tg.NewBotAPI(appBot.Token)
+	token, err := tokens.Open(appBot.SealedToken)
 	if err != nil {
 		return nil, err
 	}
 
-	stopCh := make(chan struct{})
+	api, err := tg.NewBotAPI(token)
+	if err != nil {
+		return nil, bots.RedactToken(err, token)
+	}
 
 	return &telegramBot{
 		botUUID: botUUID,
 		app:     app,
 		log:     log,
-		stopCh:  stopCh,
+		stopCh:  make(chan struct{}),
+		doneCh:  make(chan struct{}),
 		api:     api,
+		health:  newBotHealth(time.Now()),
+		onFail:  onFail,
 	}, nil
 }
 
 func (b *telegramBot) Start(ctx context.Context) error {
-	conf := tg.NewUpdate(0)
-	updates, err := b.api.GetUpdatesChan(conf)
-	if err != nil {
-		return err
-	}
-
-	err = b.app.Commands.UpdateStatus.Handle(ctx, command.UpdateStatus{
+	err := b.app.Commands.UpdateStatus.Handle(ctx, command.UpdateStatus{
 		BotUUID: b.botUUID,
 		Status:  "started",
 	})
@@ -61,6 +77,8 @@ func (b *telegramBot) Start(ctx context.Context) error {
 		return err
 	}
 
+	updates := make(chan tg.Update, b.api.Buffer)
+	go b.poll(updates)
 	go b.run(updates)
 
 	return nil
@@ -75,21 +93,65 @@ func (b *telegramBot) Stop(ctx context.Context) error {
 		return err
 	}
 
-	b.stopCh <- struct{}{}
+	b.halt()
 
 	return nil
 }
 
-func (b *telegramBot) SendMessage(toUserID int64, text string, buttons []string) error {
-	msg := tg.NewMessage(toUserID, text)
-	if len(buttons) > 0 {
-		keyboard := buildInlineKeyboardMarkup(buttons)
-		msg.ReplyMarkup = keyboard
-	} else {
-		msg.ReplyMarkup = tg.NewRemoveKeyboard(true)
+func (b *telegramBot) halt() {
+	b.stopped.Do(func() {
+		close(b.stopCh)
+	})
+}
+
+func (b *telegramBot) wait(ctx context.Context) error {
+	select {
+	case <-b.doneCh:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+func (b *telegramBot) Status(now time.Time) botStatus {
+	return b.health.status(b.botUUID, now)
+}
+
+func (b *telegramBot) SendMessage(ctx context.Context, toUserID int64, botMsg botMessage) (err error) {
+	ctx, span := tracing.Start(ctx, "telegramBot.SendMessage")
+	defer func() {
+		err = b.redact(err)
+		tracing.End(span, err)
+	}()
+
+	if botMsg.AttachmentType != "" {
+		caption := attachmentCaption(botMsg)
+		err = b.sendAttachment(
+			ctx, toUserID, botMsg.AttachmentType, botMsg.AttachmentFileID,
+			caption, parseMode(botMsg.Format), buildReplyMarkup(botMsg.Buttons),
+		)
+		if err != nil {
+			return err
+		}
+		if caption != "" {
+			return nil
+		}
+	}
+
+	if botMsg.Ticket != "" {
+		photo, err := newTicketPhoto(toUserID, botMsg.Text, botMsg.Ticket, parseMode(botMsg.Format))
+		if err != nil {
+			return err
+		}
+		_, err = b.api.Send(photo)
+		return err
 	}
 
-	_, err := b.api.Send(msg)
+	msg := tg.NewMessage(toUserID, botMsg.Text)
+	msg.ParseMode = parseMode(botMsg.Format)
+	msg.ReplyMarkup = buildReplyMarkup(botMsg.Buttons)
+
+	_, err = b.api.Send(msg)
 	if err != nil {
 		return err
 	}
@@ -97,6 +159,23 @@ func (b *telegramBot) SendMessage(toUserID int64, text string, buttons []string)
 	return nil
 }
 
+func parseMode(format string) string {
+	switch format {
+	case "markdown_v2":
+		return "MarkdownV2"
+	case "html":
+		return tg.ModeHTML
+	}
+	return ""
+}
+
+func buildReplyMarkup(buttons []string) interface{} {
+	if len(buttons) > 0 {
+		return buildInlineKeyboardMarkup(buttons)
+	}
+	return tg.NewRemoveKeyboard(true)
+}
+
 func buildInlineKeyboardMarkup(buttons []string) tg.ReplyKeyboardMarkup {
 	rows := make([][]tg.KeyboardButton, len(buttons))
 	for i, button := range buttons {
@@ -105,22 +184,137 @@ func buildInlineKeyboardMarkup(buttons []string) tg.ReplyKeyboardMarkup {
 	return tg.NewReplyKeyboard(rows...)
 }
 
-func (b *telegramBot) run(updates tg.UpdatesChannel) {
-	run := true
-	for run {
+func (b *telegramBot) poll(updates chan<- tg.Update) {
+	conf := tg.NewUpdate(0)
+	conf.Timeout = pollTimeout
+
+	failures := 0
+	for {
+		select {
+		case <-b.stopCh:
+			return
+		default:
+		}
+
+		received, err := b.api.GetUpdates(conf)
+		err = b.redact(err)
+		select {
+		case <-b.stopCh:
+			return
+		default:
+		}
+
+		b.health.polled(time.Now(), err)
+		if isPermanentError(err) {
+			b.onFail(b.botUUID, err)
+			return
+		}
+		if err != nil {
+			b.log.Error("failed to get updates", "bot_uuid", b.botUUID, "error", err.Error())
+			select {
+			case <-b.stopCh:
+				return
+			case <-time.After(retryDelay(err, pollRetryDelay, pollRetryMax, failures)):
+			}
+			failures++
+			continue
+		}
+		failures = 0
+
+		for _, update := range received {
+			if update.UpdateID < conf.Offset {
+				continue
+			}
+			select {
+			case updates <- update:
+				conf.Offset = update.UpdateID + 1
+			case <-b.stopCh:
+				return
+			}
+		}
+	}
+}
+
+func (b *telegramBot) run(updates <-chan tg.Update) {
+	wg := sync.WaitGroup{}
+	workers := make([]chan tg.Update, updateWorkers)
+	for i := range workers {
+		workers[i] = make(chan tg.Update, workerQueueSize)
+		wg.Add(1)
+		go func(queue <-chan tg.Update) {
+			defer wg.Done()
+			for update := range queue {
+				b.handleUpdate(context.Background(), update)
+			}
+		}(workers[i])
+	}
+
+	offset := 0
+	defer func() {
+		for _, queue := range workers {
+			close(queue)
+		}
+		wg.Wait()
+		b.confirmOffset(offset)
+		close(b.doneCh)
+	}()
+
+	dispatch := func(update tg.Update) {
+		b.health.updated(time.Now())
+		workers[workerIndex(update, len(workers))] <- update
+		if update.UpdateID >= offset {
+			offset = update.UpdateID + 1
+		}
+	}
+
+	for {
 		select {
 		case update := <-updates:
-			b.handleUpdate(context.Background(), update)
+			dispatch(update)
 		case <-b.stopCh:
-			run = false
+			for {
+				select {
+				case update := <-updates:
+					dispatch(update)
+				default:
+					return
+				}
+			}
 		}
 	}
-	close(b.stopCh)
-	b.api.StopReceivingUpdates()
+}
+
+func (b *telegramBot) confirmOffset(offset int) {
+	if offset == 0 {
+		return
+	}
+
+	conf := tg.NewUpdate(offset)
+	conf.Limit = 1
+	_, err := b.api.GetUpdates(conf)
+	if err != nil {
+		b.log.Warn("failed to confirm updates offset", "bot_uuid", b.botUUID, "error", b.redact(err).Error())
+	}
+}
+
+func workerIndex(update tg.Update, workers int) int {
+	if update.Message == nil || update.Message.Chat == nil {
+		return 0
+	}
+	chatID := update.Message.Chat.ID
+	if chatID < 0 {
+		chatID = -chatID
+	}
+	return int(chatID % int64(workers))
 }
 
 func (b *telegramBot) handleUpdate(ctx context.Context, update tg.Update) {
+	ctx, span := tracing.Start(ctx, "telegramBot.handleUpdate", trace.WithSpanKind(trace.SpanKindServer))
 	var err error
+	defer func() {
+		tracing.End(span, err)
+	}()
+
 	if update.Message != nil {
 		if update.Message.IsCommand() {
 			err = b.handleCommand(ctx, update.Message)
@@ -129,11 +323,16 @@ func (b *telegramBot) handleUpdate(ctx context.Context, update tg.Update) {
 		}
 	}
 
+	err = b.redact(err)
 	if err != nil {
 		b.log.Error("failed to handle update", "error", err.Error())
 	}
 }
 
+func (b *telegramBot) redact(err error) error {
+	return bots.RedactToken(err, b.api.Token)
+}
+
 func (b *telegramBot) handleCommand(ctx context.Context, msg *tg.Message) error {
 	switch msg.Command() {
 	case "start":
@@ -141,15 +340,40 @@ func (b *telegramBot) handleCommand(ctx context.Context, msg *tg.Message) error
 			BotUUID: b.botUUID,
 			UserID:  msg.Chat.ID,
 			Key:     "start",
+			Locale:  languageCode(msg),
+		})
+	case "operator":
+		return b.app.Commands.ContactOperator.Handle(ctx, command.ContactOperator{
+			BotUUID: b.botUUID,
+			UserID:  msg.Chat.ID,
+			Text:    msg.Text,
+		})
+	case "cancel":
+		return b.app.Commands.CancelSeat.Handle(ctx, command.CancelSeat{
+			BotUUID:  b.botUUID,
+			UserID:   msg.Chat.ID,
+			EntryKey: msg.CommandArguments(),
 		})
 	}
 	return nil
 }
 
 func (b *telegramBot) handleMessage(ctx context.Context, msg *tg.Message) error {
+	if upload, ok := uploadFromMessage(msg); ok {
+		return b.handleUpload(ctx, msg.Chat.ID, languageCode(msg), upload)
+	}
+
 	return b.app.Commands.Process.Handle(ctx, command.Process{
 		BotUUID: b.botUUID,
 		UserID:  msg.Chat.ID,
 		Text:    msg.Text,
+		Locale:  languageCode(msg),
 	})
 }
+
+func languageCode(msg *tg.Message) string {
+	if msg.From == nil {
+		return ""
+	}
+	return msg.From.LanguageCode
+}
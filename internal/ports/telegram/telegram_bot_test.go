@@ -0,0 +1,212 @@
+package telegram
+
+import (
+	"context"
+	"io"
+	"log/slog"
+	"net/http"
+	"path"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	tg "github.com/go-telegram-bot-api/telegram-bot-api"
+	"github.com/stretchr/testify/require"
+
+	"github.com/bmstu-itstech/itsreg-bots/internal/app"
+	"github.com/bmstu-itstech/itsreg-bots/internal/app/command"
+)
+
+type stubCommandHandler[C any] func(ctx context.Context, cmd C) error
+
+func (h stubCommandHandler[C]) Handle(ctx context.Context, cmd C) error {
+	return h(ctx, cmd)
+}
+
+type fakeTelegram struct {
+	mu      sync.Mutex
+	sent    []string
+	offsets []string
+}
+
+func (f *fakeTelegram) RoundTrip(req *http.Request) (*http.Response, error) {
+	if err := req.ParseForm(); err != nil {
+		return nil, err
+	}
+
+	result := "true"
+	f.mu.Lock()
+	switch path.Base(req.URL.Path) {
+	case "getUpdates":
+		f.offsets = append(f.offsets, req.PostForm.Get("offset"))
+		result = "[]"
+	case "sendMessage":
+		f.sent = append(f.sent, req.PostForm.Get("chat_id")+":"+req.PostForm.Get("text"))
+		result = `{"message_id":1,"date":0,"chat":{"id":` + req.PostForm.Get("chat_id") + `}}`
+	}
+	f.mu.Unlock()
+
+	return &http.Response{
+		StatusCode: http.StatusOK,
+		Header:     http.Header{"Content-Type": []string{"application/json"}},
+		Body:       io.NopCloser(strings.NewReader(`{"ok":true,"result":` + result + `}`)),
+		Request:    req,
+	}, nil
+}
+
+func (f *fakeTelegram) sentMessages() []string {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return append([]string(nil), f.sent...)
+}
+
+func (f *fakeTelegram) confirmedOffsets() []string {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return append([]string(nil), f.offsets...)
+}
+
+func newRunningTestBot(botUUID string, application *app.Application, api *fakeTelegram) *telegramBot {
+	tgBot := newTestBot(botUUID, time.Now())
+	tgBot.app = application
+	tgBot.log = slog.New(slog.NewTextHandler(io.Discard, nil))
+	tgBot.api = &tg.BotAPI{
+		Token:  "123:secret",
+		Client: &http.Client{Transport: api},
+		Buffer: 100,
+	}
+	return tgBot
+}
+
+func textUpdate(updateID int, chatID int64, text string) tg.Update {
+	return tg.Update{
+		UpdateID: updateID,
+		Message: &tg.Message{
+			Chat: &tg.Chat{ID: chatID},
+			Text: text,
+		},
+	}
+}
+
+func TestTelegramBot_Run(t *testing.T) {
+	const (
+		chats        = 3
+		perChat      = 10
+		handleDelay  = 5 * time.Millisecond
+		drainTimeout = 10 * time.Second
+	)
+
+	mu := sync.Mutex{}
+	handled := make(map[int64][]string)
+	inFlight, maxInFlight := 0, 0
+
+	process := stubCommandHandler[command.Process](func(_ context.Context, cmd command.Process) error {
+		mu.Lock()
+		inFlight++
+		maxInFlight = max(maxInFlight, inFlight)
+		mu.Unlock()
+
+		time.Sleep(handleDelay)
+
+		mu.Lock()
+		inFlight--
+		handled[cmd.UserID] = append(handled[cmd.UserID], cmd.Text)
+		mu.Unlock()
+		return nil
+	})
+
+	api := &fakeTelegram{}
+	tgBot := newRunningTestBot("bot", &app.Application{Commands: app.Commands{Process: process}}, api)
+
+	updates := make(chan tg.Update, chats*perChat)
+	updateID := 0
+	for i := 0; i < perChat; i++ {
+		for chatID := int64(1); chatID <= chats; chatID++ {
+			updateID++
+			updates <- textUpdate(updateID, chatID, strconv.Itoa(i))
+		}
+	}
+
+	go tgBot.run(updates)
+	tgBot.halt()
+
+	ctx, cancel := context.WithTimeout(context.Background(), drainTimeout)
+	defer cancel()
+	require.NoError(t, tgBot.wait(ctx))
+
+	t.Run("should handle updates of one chat in order", func(t *testing.T) {
+		expected := make([]string, perChat)
+		for i := range expected {
+			expected[i] = strconv.Itoa(i)
+		}
+		for chatID := int64(1); chatID <= chats; chatID++ {
+			require.Equal(t, expected, handled[chatID])
+		}
+	})
+
+	t.Run("should handle different chats concurrently", func(t *testing.T) {
+		require.Greater(t, maxInFlight, 1)
+	})
+
+	t.Run("should confirm offset after drain", func(t *testing.T) {
+		require.Equal(t, []string{strconv.Itoa(updateID + 1)}, api.confirmedOffsets())
+	})
+}
+
+func TestPort_Shutdown(t *testing.T) {
+	const updates = 5
+
+	api := &fakeTelegram{}
+	p := newTestPort(nil, nil, nil)
+
+	releasedMu := sync.Mutex{}
+	var released []string
+	p.app.Commands.ReleaseBot = stubCommandHandler[command.ReleaseBot](func(_ context.Context, cmd command.ReleaseBot) error {
+		releasedMu.Lock()
+		defer releasedMu.Unlock()
+		released = append(released, cmd.BotUUID)
+		return nil
+	})
+	p.app.Commands.Process = stubCommandHandler[command.Process](func(ctx context.Context, cmd command.Process) error {
+		time.Sleep(10 * time.Millisecond)
+		return p.handleBotMessage(ctx, botMessage{
+			BotUUID: cmd.BotUUID,
+			UserID:  cmd.UserID,
+			Text:    "reply " + cmd.Text,
+		})
+	})
+
+	tgBot := newRunningTestBot("bot", p.app, api)
+	added, err := p.registry.add(tgBot.botUUID, tgBot)
+	require.NoError(t, err)
+	require.True(t, added)
+
+	ch := make(chan tg.Update, updates)
+	for i := 1; i <= updates; i++ {
+		ch <- textUpdate(i, 1, strconv.Itoa(i))
+	}
+	go tgBot.run(ch)
+
+	p.shutdown()
+
+	t.Run("should deliver replies of in-flight updates", func(t *testing.T) {
+		expected := make([]string, updates)
+		for i := range expected {
+			expected[i] = "1:reply " + strconv.Itoa(i+1)
+		}
+		require.Equal(t, expected, api.sentMessages())
+	})
+
+	t.Run("should remove bot and release its lease", func(t *testing.T) {
+		_, ok := p.bot("bot")
+		require.False(t, ok)
+		require.Equal(t, []string{"bot"}, released)
+	})
+
+	t.Run("should refuse to start bots", func(t *testing.T) {
+		require.NoError(t, p.startBot(context.Background(), "other"))
+		require.False(t, p.registry.has("other"))
+	})
+}
@@ -2,74 +2,123 @@ package telegram
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"log/slog"
 	"sync"
+	"time"
 
 	"github.com/ThreeDotsLabs/watermill/message"
 
 	"github.com/bmstu-itstech/itsreg-bots/internal/app"
-	"github.com/bmstu-itstech/itsreg-bots/internal/app/query"
+	"github.com/bmstu-itstech/itsreg-bots/internal/app/command"
+	"github.com/bmstu-itstech/itsreg-bots/internal/common/instance"
 	"github.com/bmstu-itstech/itsreg-bots/internal/common/logs"
+	"github.com/bmstu-itstech/itsreg-bots/internal/common/metrics"
+	"github.com/bmstu-itstech/itsreg-bots/internal/domain/bots"
 )
 
+const shutdownTimeout = 30 * time.Second
+
 type Port struct {
-	bots map[string]*telegramBot
+	registry *botRegistry
+
+	app        *app.Application
+	tokens     bots.TokenCipher
+	instanceID string
+	log        *slog.Logger
+}
 
-	msgCons messagesConsumer
-	runCons runnerConsumer
+func NewPort(app *app.Application, tokens bots.TokenCipher) *Port {
+	if tokens == nil {
+		panic("token cipher is nil")
+	}
 
-	app *app.Application
-	log *slog.Logger
+	return &Port{
+		registry:   newBotRegistry(),
+		app:        app,
+		tokens:     tokens,
+		instanceID: instance.ID(),
+		log:        logs.DefaultLogger(),
+	}
 }
 
-func RunTelegramPort(
-	app *app.Application,
+func (p *Port) Run(
+	ctx context.Context,
 	msgCh <-chan *message.Message,
 	runCh <-chan *message.Message,
 ) {
-	log := logs.DefaultLogger()
-
-	p := Port{
-		bots:    make(map[string]*telegramBot),
-		msgCons: messagesConsumer{},
-		app:     app,
-		log:     log,
-	}
+	msgCon := newMessagesConsumer(msgCh, p.handleBotMessage, p.log)
+	go msgCon.Process()
 
-	wg := sync.WaitGroup{}
+	runCon := newRunnerConsumer(runCh, p.handleRunnerMessage, p.log)
+	go runCon.Process()
 
-	msgCon := newMessagesConsumer(msgCh, p.handleBotMessage, log)
-	wg.Add(1)
+	leasesDone := make(chan struct{})
 	go func() {
-		msgCon.Process()
-		wg.Done()
+		defer close(leasesDone)
+		p.startUnownedBots(ctx)
+		p.maintainLeases(ctx)
 	}()
 
-	runCon := newRunnerConsumer(runCh, p.handleRunnerMessage, log)
-	wg.Add(1)
-	go func() {
-		runCon.Process()
-		wg.Done()
-	}()
+	<-ctx.Done()
+	<-leasesDone
 
-	wg.Add(1)
-	go func() {
-		p.startAlreadyStartedBots()
-		wg.Done()
-	}()
+	p.shutdown()
+}
+
+func (p *Port) shutdown() {
+	p.log.Info("shutting down telegram port, draining bots")
 
+	tgBots, retries := p.registry.close()
+	for _, retry := range retries {
+		retry.cancel()
+	}
+	for _, tgBot := range tgBots {
+		tgBot.halt()
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), shutdownTimeout)
+	defer cancel()
+
+	wg := sync.WaitGroup{}
+	for _, tgBot := range tgBots {
+		wg.Add(1)
+		go func(tgBot *telegramBot) {
+			defer wg.Done()
+			if err := tgBot.wait(ctx); err != nil {
+				p.log.Warn("bot did not drain in time", "bot_uuid", tgBot.botUUID, "error", err.Error())
+			}
+			p.registry.remove(tgBot.botUUID)
+			p.releaseBot(context.WithoutCancel(ctx), tgBot.botUUID)
+		}(tgBot)
+	}
+	for _, retry := range retries {
+		p.releaseBot(ctx, retry.botUUID)
+	}
 	wg.Wait()
+
+	p.log.Info("telegram port stopped")
+}
+
+func (p *Port) bot(botUUID string) (*telegramBot, bool) {
+	return p.registry.bot(botUUID)
 }
 
-func (p *Port) handleBotMessage(_ context.Context, msg botMessage) error {
-	tgBot, ok := p.bots[msg.BotUUID]
+func (p *Port) handleBotMessage(ctx context.Context, msg botMessage) error {
+	tgBot, ok := p.bot(msg.BotUUID)
 	if !ok {
-		err := fmt.Errorf("bot not found: %s", msg.BotUUID)
+		return fmt.Errorf("bot %s is not running on this instance", msg.BotUUID)
+	}
+
+	err := tgBot.SendMessage(ctx, msg.UserID, msg)
+	if err != nil {
+		metrics.IncBotMessages(msg.BotUUID, metrics.MessageFailed)
 		return err
 	}
 
-	return tgBot.SendMessage(msg.UserID, msg.Text, msg.Buttons)
+	metrics.IncBotMessages(msg.BotUUID, metrics.MessageSent)
+	return nil
 }
 
 func (p *Port) handleRunnerMessage(ctx context.Context, msg runnerMessage) error {
@@ -82,50 +131,92 @@ func (p *Port) handleRunnerMessage(ctx context.Context, msg runnerMessage) error
 	return fmt.Errorf("invalid command: %s", msg.Command)
 }
 
-func (p *Port) startAlreadyStartedBots() {
-	ctx := context.Background()
+func (p *Port) startBot(ctx context.Context, botUUID string) error {
+	if p.registry.isClosed() {
+		p.log.Debug("skipping bot start during shutdown", "bot_uuid", botUUID)
+		return nil
+	}
 
-	bots, err := p.app.Queries.StartedBots.Handle(ctx, query.GetStartedBots{})
+	claimed, err := p.claimBot(ctx, botUUID)
 	if err != nil {
-		p.log.Error("failed to get started bots", "err", err.Error())
-		return
+		return err
+	}
+	if !claimed {
+		p.log.Debug("bot is owned by another instance", "bot_uuid", botUUID)
+		return nil
+	}
+
+	err = p.launchBot(ctx, botUUID)
+	if err == nil {
+		return nil
+	}
+	if errors.Is(err, errRegistryClosed) {
+		p.log.Debug("skipping bot start during shutdown", "bot_uuid", botUUID)
+		p.releaseBot(ctx, botUUID)
+		return nil
 	}
 
-	for _, bot := range bots {
-		err = p.startBot(ctx, bot.UUID)
-		if err != nil {
-			p.log.Error("failed to start bot", "err", err.Error())
-		}
+	if isPermanentError(err) {
+		return p.failBot(ctx, botUUID, err)
 	}
+
+	p.log.Warn("failed to start bot, retrying", "bot_uuid", botUUID, "error", err.Error())
+	p.retryStartBot(botUUID, err)
+
+	return nil
 }
 
-func (p *Port) startBot(ctx context.Context, botUUID string) error {
-	if _, ok := p.bots[botUUID]; ok {
+func (p *Port) launchBot(ctx context.Context, botUUID string) error {
+	if _, ok := p.bot(botUUID); ok {
 		return nil
 	}
 
-	tgBot, err := newTelegramBot(ctx, botUUID, p.app, p.log)
+	tgBot, err := newTelegramBot(ctx, botUUID, p.app, p.tokens, p.log, p.handleBotFailure)
 	if err != nil {
 		return err
 	}
 
+	added, err := p.registry.add(botUUID, tgBot)
+	if err != nil || !added {
+		return err
+	}
+
 	err = tgBot.Start(ctx)
 	if err != nil {
+		p.registry.remove(botUUID)
+		tgBot.halt()
 		return err
 	}
 
-	p.bots[botUUID] = tgBot
-
 	return nil
 }
 
 func (p *Port) stopBot(ctx context.Context, botUUID string) error {
-	tgBot, ok := p.bots[botUUID]
-	if !ok {
-		return fmt.Errorf("bot not found: %s", botUUID)
+	if retry, ok := p.registry.takeRetry(botUUID); ok {
+		retry.cancel()
+		defer p.releaseBot(ctx, botUUID)
+		return p.app.Commands.UpdateStatus.Handle(ctx, command.UpdateStatus{
+			BotUUID: botUUID,
+			Status:  "stopped",
+		})
 	}
 
-	delete(p.bots, botUUID)
+	if tgBot, ok := p.registry.remove(botUUID); ok {
+		defer p.releaseBot(ctx, botUUID)
+		return tgBot.Stop(ctx)
+	}
+
+	claimed, err := p.claimBot(ctx, botUUID)
+	if err != nil {
+		return err
+	}
+	if !claimed {
+		return nil
+	}
 
-	return tgBot.Stop(ctx)
+	defer p.releaseBot(ctx, botUUID)
+	return p.app.Commands.UpdateStatus.Handle(ctx, command.UpdateStatus{
+		BotUUID: botUUID,
+		Status:  "stopped",
+	})
 }
@@ -0,0 +1,22 @@
+package telegram
+
+import (
+	tg "github.com/go-telegram-bot-api/telegram-bot-api"
+	"github.com/skip2/go-qrcode"
+)
+
+const ticketQRSize = 512
+
+func newTicketPhoto(toUserID int64, text string, ticket string, parseMode string) (tg.PhotoConfig, error) {
+	png, err := qrcode.Encode(ticket, qrcode.Medium, ticketQRSize)
+	if err != nil {
+		return tg.PhotoConfig{}, err
+	}
+
+	photo := tg.NewPhotoUpload(toUserID, tg.FileBytes{Name: "ticket.png", Bytes: png})
+	photo.Caption = text + "\n\n" + ticket
+	photo.ParseMode = parseMode
+	photo.ReplyMarkup = tg.NewRemoveKeyboard(true)
+
+	return photo, nil
+}
@@ -3,17 +3,22 @@ package mocks
 import (
 	"context"
 	"sync"
+	"time"
 
 	"github.com/bmstu-itstech/itsreg-bots/internal/domain/bots"
 )
 
 type mockBotRepository struct {
 	sync.RWMutex
-	m map[string]bots.Bot
+	m      map[string]bots.Bot
+	tokens bots.TokenCipher
 }
 
-func NewMockBotRepository() bots.Repository {
-	return &mockBotRepository{m: make(map[string]bots.Bot)}
+func NewMockBotRepository(tokens bots.TokenCipher) bots.Repository {
+	return &mockBotRepository{
+		m:      make(map[string]bots.Bot),
+		tokens: tokens,
+	}
 }
 
 func (r *mockBotRepository) Update(
@@ -43,7 +48,16 @@ func (r *mockBotRepository) UpdateOrCreate(_ context.Context, bot *bots.Bot) err
 	r.Lock()
 	defer r.Unlock()
 
-	r.m[bot.UUID] = *bot
+	stored := *bot
+	if stored.Token != "" {
+		sealed, err := r.tokens.Seal(stored.Token)
+		if err != nil {
+			return err
+		}
+		stored.SealedToken = sealed
+		stored.Token = ""
+	}
+	r.m[bot.UUID] = stored
 
 	return nil
 }
@@ -63,6 +77,21 @@ func (r *mockBotRepository) UpdateStatus(_ context.Context, uuid string, status
 	return nil
 }
 
+func (r *mockBotRepository) MarkFailed(_ context.Context, uuid string, reason string, failedAt time.Time) error {
+	r.Lock()
+	defer r.Unlock()
+
+	bot, ok := r.m[uuid]
+	if !ok {
+		return bots.BotNotFoundError{UUID: uuid}
+	}
+
+	bot.Fail(reason, failedAt)
+	r.m[uuid] = bot
+
+	return nil
+}
+
 func (r *mockBotRepository) Delete(_ context.Context, uuid string) error {
 	r.Lock()
 	defer r.Unlock()
@@ -93,7 +122,7 @@ func (r *mockBotRepository) UserBots(_ context.Context, userUUID string) ([]*bot
 	defer r.RUnlock()
 
 	return r.botsFilter(func(bot *bots.Bot) bool {
-		return bot.OwnerUUID == userUUID
+		return bot.CanSeeBot(userUUID) == nil
 	}), nil
 
 }
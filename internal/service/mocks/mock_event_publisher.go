@@ -0,0 +1,66 @@
+package mocks
+
+import (
+	"context"
+	"sync"
+
+	"github.com/bmstu-itstech/itsreg-bots/internal/domain/bots"
+)
+
+type mockEventStream struct {
+	sync.Mutex
+	events    []bots.Event
+	listeners map[chan bots.Event]string
+}
+
+func NewMockEventStream() (bots.EventPublisher, bots.EventSubscriber) {
+	s := &mockEventStream{
+		events:    make([]bots.Event, 0),
+		listeners: make(map[chan bots.Event]string),
+	}
+	return s, s
+}
+
+func (s *mockEventStream) Publish(_ context.Context, event bots.Event) error {
+	s.Lock()
+	defer s.Unlock()
+
+	event.ID = int64(len(s.events) + 1)
+	s.events = append(s.events, event)
+
+	for ch, botUUID := range s.listeners {
+		if botUUID == event.BotUUID {
+			select {
+			case ch <- event:
+			default:
+			}
+		}
+	}
+
+	return nil
+}
+
+func (s *mockEventStream) Subscribe(ctx context.Context, botUUID string, lastEventID int64) (<-chan bots.Event, error) {
+	s.Lock()
+	defer s.Unlock()
+
+	ch := make(chan bots.Event, len(s.events)+64)
+	if lastEventID > 0 {
+		for _, e := range s.events {
+			if e.BotUUID == botUUID && e.ID > lastEventID {
+				ch <- e
+			}
+		}
+	}
+	s.listeners[ch] = botUUID
+
+	go func() {
+		<-ctx.Done()
+		s.Lock()
+		defer s.Unlock()
+		delete(s.listeners, ch)
+		close(ch)
+	}()
+
+	return ch, nil
+}
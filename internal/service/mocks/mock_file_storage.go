@@ -0,0 +1,65 @@
+package mocks
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"sync"
+
+	"github.com/bmstu-itstech/itsreg-bots/internal/domain/bots"
+)
+
+type fileID struct {
+	BotUUID string
+	ID      string
+}
+
+type storedFile struct {
+	file    bots.File
+	content []byte
+}
+
+type mockFileStorage struct {
+	sync.RWMutex
+	m map[fileID]storedFile
+}
+
+func NewMockFileStorage() bots.FileStorage {
+	return &mockFileStorage{m: make(map[fileID]storedFile)}
+}
+
+func (s *mockFileStorage) Save(_ context.Context, file bots.File, content io.Reader) (bots.File, error) {
+	b, err := io.ReadAll(content)
+	if err != nil {
+		return bots.File{}, err
+	}
+	file.Size = int64(len(b))
+
+	s.Lock()
+	defer s.Unlock()
+
+	s.m[fileID{BotUUID: file.BotUUID, ID: file.ID}] = storedFile{file: file, content: b}
+
+	return file, nil
+}
+
+func (s *mockFileStorage) Open(_ context.Context, botUUID string, id string) (bots.File, io.ReadCloser, error) {
+	s.RLock()
+	defer s.RUnlock()
+
+	f, ok := s.m[fileID{BotUUID: botUUID, ID: id}]
+	if !ok {
+		return bots.File{}, nil, bots.FileNotFoundError{BotUUID: botUUID, ID: id}
+	}
+
+	return f.file, io.NopCloser(bytes.NewReader(f.content)), nil
+}
+
+func (s *mockFileStorage) Delete(_ context.Context, botUUID string, id string) error {
+	s.Lock()
+	defer s.Unlock()
+
+	delete(s.m, fileID{BotUUID: botUUID, ID: id})
+
+	return nil
+}
@@ -0,0 +1,84 @@
+package mocks
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/bmstu-itstech/itsreg-bots/internal/domain/bots"
+)
+
+type mockLeaseRepository struct {
+	sync.RWMutex
+	m map[string]bots.Lease
+}
+
+func NewMockLeaseRepository() bots.LeaseRepository {
+	return &mockLeaseRepository{m: make(map[string]bots.Lease)}
+}
+
+func (r *mockLeaseRepository) Acquire(_ context.Context, lease bots.Lease) error {
+	r.Lock()
+	defer r.Unlock()
+
+	current, ok := r.m[lease.BotUUID]
+	if ok && current.InstanceID != lease.InstanceID && !current.IsExpired(time.Now()) {
+		return bots.ErrLeaseHeld
+	}
+
+	r.m[lease.BotUUID] = lease
+	return nil
+}
+
+func (r *mockLeaseRepository) Renew(_ context.Context, instanceID string, expiresAt time.Time) error {
+	r.Lock()
+	defer r.Unlock()
+
+	now := time.Now()
+	for botUUID, lease := range r.m {
+		if lease.InstanceID == instanceID && !lease.IsExpired(now) {
+			lease.ExpiresAt = expiresAt
+			r.m[botUUID] = lease
+		}
+	}
+
+	return nil
+}
+
+func (r *mockLeaseRepository) Release(_ context.Context, botUUID string, instanceID string) error {
+	r.Lock()
+	defer r.Unlock()
+
+	if lease, ok := r.m[botUUID]; ok && lease.InstanceID == instanceID {
+		delete(r.m, botUUID)
+	}
+
+	return nil
+}
+
+func (r *mockLeaseRepository) Leases(_ context.Context) ([]bots.Lease, error) {
+	r.RLock()
+	defer r.RUnlock()
+
+	now := time.Now()
+	res := make([]bots.Lease, 0, len(r.m))
+	for _, lease := range r.m {
+		if !lease.IsExpired(now) {
+			res = append(res, lease)
+		}
+	}
+
+	return res, nil
+}
+
+func (r *mockLeaseRepository) Owner(_ context.Context, botUUID string) (string, error) {
+	r.RLock()
+	defer r.RUnlock()
+
+	lease, ok := r.m[botUUID]
+	if !ok || lease.IsExpired(time.Now()) {
+		return "", nil
+	}
+
+	return lease.InstanceID, nil
+}
@@ -41,6 +41,11 @@ func (s *mockSenderService) Publish(
 		UserID:  userID,
 		Text:    msg.Text,
 		Buttons: msg.Buttons,
+		Ticket:  msg.Ticket,
+
+		AttachmentType:   msg.Attachment.Type.String(),
+		AttachmentFileID: msg.Attachment.FileID,
+		Format:           msg.Format.String(),
 	})
 	if err != nil {
 		return err
@@ -55,4 +60,9 @@ type botMessage struct {
 	UserID  int64    `json:"user_id"`
 	Text    string   `json:"text"`
 	Buttons []string `json:"buttons"`
+	Ticket  string   `json:"ticket,omitempty"`
+
+	AttachmentType   string `json:"attachment_type,omitempty"`
+	AttachmentFileID string `json:"attachment_file_id,omitempty"`
+	Format           string `json:"format,omitempty"`
 }
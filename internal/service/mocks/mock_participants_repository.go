@@ -2,7 +2,9 @@ package mocks
 
 import (
 	"context"
+	"sort"
 	"sync"
+	"time"
 
 	"github.com/bmstu-itstech/itsreg-bots/internal/domain/bots"
 )
@@ -21,6 +23,22 @@ func NewMockParticipantsRepository() bots.ParticipantRepository {
 	return &mockParticipantsRepository{m: make(map[participantID]bots.Participant)}
 }
 
+func (r *mockParticipantsRepository) Participant(
+	_ context.Context,
+	botUUID string,
+	userID int64,
+) (*bots.Participant, error) {
+	r.RLock()
+	defer r.RUnlock()
+
+	prt, ok := r.m[participantID{BotUUID: botUUID, UserID: userID}]
+	if !ok {
+		return nil, bots.ParticipantNotFoundError{BotUUID: botUUID, UserID: userID}
+	}
+
+	return &prt, nil
+}
+
 func (r *mockParticipantsRepository) ParticipantsOfBot(
 	_ context.Context,
 	botUUID string,
@@ -38,6 +56,68 @@ func (r *mockParticipantsRepository) ParticipantsOfBot(
 	return prts, nil
 }
 
+func (r *mockParticipantsRepository) ParticipantsPage(
+	ctx context.Context,
+	botUUID string,
+	offset int,
+	limit int,
+) ([]*bots.Participant, int, error) {
+	prts, err := r.ParticipantsOfBot(ctx, botUUID)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	sort.Slice(prts, func(i, j int) bool {
+		return prts[i].UserID < prts[j].UserID
+	})
+
+	total := len(prts)
+	if offset > total {
+		offset = total
+	}
+	end := min(offset+limit, total)
+
+	return prts[offset:end], total, nil
+}
+
+func (r *mockParticipantsRepository) StalledParticipants(
+	_ context.Context,
+	botUUID string,
+	inactiveSince time.Time,
+) ([]*bots.Participant, error) {
+	r.RLock()
+	defer r.RUnlock()
+
+	prts := make([]*bots.Participant, 0)
+	for _, p := range r.m {
+		if p.BotUUID == botUUID && p.IsProcessing() && !p.IsPaused() && !p.IsBlocked() &&
+			!p.LastActivityAt.IsZero() && !p.LastActivityAt.After(inactiveSince) {
+			prts = append(prts, &p)
+		}
+	}
+
+	return prts, nil
+}
+
+func (r *mockParticipantsRepository) ExpiredParticipants(
+	_ context.Context,
+	botUUID string,
+	startedBefore time.Time,
+) ([]*bots.Participant, error) {
+	r.RLock()
+	defer r.RUnlock()
+
+	prts := make([]*bots.Participant, 0)
+	for _, p := range r.m {
+		if p.BotUUID == botUUID && p.IsProcessing() && !p.IsPaused() && !p.IsBlocked() &&
+			!p.FlowStartedAt.IsZero() && !p.FlowStartedAt.After(startedBefore) {
+			prts = append(prts, &p)
+		}
+	}
+
+	return prts, nil
+}
+
 func (r *mockParticipantsRepository) UpdateOrCreate(
 	ctx context.Context,
 	botUUID string,
@@ -70,3 +150,17 @@ func (r *mockParticipantsRepository) UpdateOrCreate(
 
 	return nil
 }
+
+func (r *mockParticipantsRepository) Delete(_ context.Context, botUUID string, userID int64) error {
+	r.Lock()
+	defer r.Unlock()
+
+	id := participantID{BotUUID: botUUID, UserID: userID}
+	if _, ok := r.m[id]; !ok {
+		return bots.ParticipantNotFoundError{BotUUID: botUUID, UserID: userID}
+	}
+
+	delete(r.m, id)
+
+	return nil
+}
@@ -0,0 +1,80 @@
+package mocks
+
+import (
+	"context"
+	"slices"
+	"sync"
+
+	"github.com/bmstu-itstech/itsreg-bots/internal/domain/bots"
+)
+
+type seatsID struct {
+	BotUUID  string
+	EntryKey string
+}
+
+type mockSeatsRepository struct {
+	sync.RWMutex
+	m map[seatsID]*bots.Seats
+}
+
+func NewMockSeatsRepository() bots.SeatsRepository {
+	return &mockSeatsRepository{m: make(map[seatsID]*bots.Seats)}
+}
+
+func (r *mockSeatsRepository) Seats(_ context.Context, botUUID string, entryKey string) (*bots.Seats, error) {
+	r.RLock()
+	defer r.RUnlock()
+
+	return r.seats(botUUID, entryKey)
+}
+
+func (r *mockSeatsRepository) UserEntries(_ context.Context, botUUID string, userID int64) ([]string, error) {
+	r.RLock()
+	defer r.RUnlock()
+
+	keys := make([]string, 0)
+	for id, seats := range r.m {
+		if id.BotUUID != botUUID {
+			continue
+		}
+		if _, ok := seats.Status(userID); ok {
+			keys = append(keys, id.EntryKey)
+		}
+	}
+	slices.Sort(keys)
+
+	return keys, nil
+}
+
+func (r *mockSeatsRepository) UpdateSeats(
+	ctx context.Context,
+	botUUID string,
+	entryKey string,
+	updateFn func(innerCtx context.Context, seats *bots.Seats) error,
+) error {
+	r.Lock()
+	defer r.Unlock()
+
+	seats, err := r.seats(botUUID, entryKey)
+	if err != nil {
+		return err
+	}
+
+	err = updateFn(ctx, seats)
+	if err != nil {
+		return err
+	}
+
+	r.m[seatsID{BotUUID: botUUID, EntryKey: entryKey}] = seats
+
+	return nil
+}
+
+func (r *mockSeatsRepository) seats(botUUID string, entryKey string) (*bots.Seats, error) {
+	seats, ok := r.m[seatsID{BotUUID: botUUID, EntryKey: entryKey}]
+	if !ok {
+		return bots.NewSeats(botUUID, entryKey)
+	}
+	return bots.UnmarshallSeatsFromDB(botUUID, entryKey, seats.Registered(), seats.Waitlisted())
+}
@@ -0,0 +1,56 @@
+package mocks
+
+import (
+	"context"
+	"sync"
+
+	"github.com/bmstu-itstech/itsreg-bots/internal/domain/bots"
+)
+
+type mockThreadRepository struct {
+	sync.RWMutex
+	m map[participantID][]bots.ThreadMessage
+}
+
+func NewMockThreadRepository() bots.ThreadRepository {
+	return &mockThreadRepository{m: make(map[participantID][]bots.ThreadMessage)}
+}
+
+func (r *mockThreadRepository) AddMessage(_ context.Context, msg bots.ThreadMessage) error {
+	r.Lock()
+	defer r.Unlock()
+
+	id := participantID{BotUUID: msg.BotUUID, UserID: msg.UserID}
+	r.m[id] = append(r.m[id], msg)
+
+	return nil
+}
+
+func (r *mockThreadRepository) Threads(_ context.Context, botUUID string) ([]bots.Thread, error) {
+	r.RLock()
+	defer r.RUnlock()
+
+	threads := make([]bots.Thread, 0)
+	for id, msgs := range r.m {
+		if id.BotUUID == botUUID && len(msgs) > 0 {
+			threads = append(threads, bots.Thread{
+				BotUUID:     id.BotUUID,
+				UserID:      id.UserID,
+				LastMessage: msgs[len(msgs)-1],
+			})
+		}
+	}
+
+	return threads, nil
+}
+
+func (r *mockThreadRepository) Messages(_ context.Context, botUUID string, userID int64) ([]bots.ThreadMessage, error) {
+	r.RLock()
+	defer r.RUnlock()
+
+	msgs := r.m[participantID{BotUUID: botUUID, UserID: userID}]
+	res := make([]bots.ThreadMessage, len(msgs))
+	copy(res, msgs)
+
+	return res, nil
+}
@@ -0,0 +1,105 @@
+package mocks
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/bmstu-itstech/itsreg-bots/internal/domain/bots"
+)
+
+type ticketID struct {
+	BotUUID string
+	Code    string
+}
+
+type mockTicketRepository struct {
+	sync.Mutex
+	bots         bots.Repository
+	participants bots.ParticipantRepository
+	m            map[ticketID]time.Time
+}
+
+func NewMockTicketRepository(
+	bots bots.Repository,
+	participants bots.ParticipantRepository,
+) bots.TicketRepository {
+	return &mockTicketRepository{
+		bots:         bots,
+		participants: participants,
+		m:            make(map[ticketID]time.Time),
+	}
+}
+
+func (r *mockTicketRepository) Tickets(ctx context.Context, botUUID string) ([]*bots.Ticket, error) {
+	r.Lock()
+	defer r.Unlock()
+
+	return r.tickets(ctx, botUUID)
+}
+
+func (r *mockTicketRepository) UpdateTicket(
+	ctx context.Context,
+	botUUID string,
+	code string,
+	updateFn func(innerCtx context.Context, ticket *bots.Ticket) error,
+) error {
+	r.Lock()
+	defer r.Unlock()
+
+	tickets, err := r.tickets(ctx, botUUID)
+	if err != nil {
+		return err
+	}
+
+	for _, ticket := range tickets {
+		if ticket.Code != code {
+			continue
+		}
+
+		err = updateFn(ctx, ticket)
+		if err != nil {
+			return err
+		}
+
+		if ticket.IsAttended() {
+			r.m[ticketID{BotUUID: botUUID, Code: code}] = ticket.AttendedAt
+		}
+		return nil
+	}
+
+	return bots.TicketNotFoundError{Code: code}
+}
+
+func (r *mockTicketRepository) tickets(ctx context.Context, botUUID string) ([]*bots.Ticket, error) {
+	bot, err := r.bots.Bot(ctx, botUUID)
+	if err != nil {
+		return nil, err
+	}
+
+	prts, err := r.participants.ParticipantsOfBot(ctx, botUUID)
+	if err != nil {
+		return nil, err
+	}
+
+	tickets := make([]*bots.Ticket, 0)
+	for _, block := range bot.Blocks() {
+		if block.Type != bots.TicketBlock {
+			continue
+		}
+		for _, prt := range prts {
+			ans, ok := prt.Answer(block.State)
+			if !ok {
+				continue
+			}
+			attendedAt := r.m[ticketID{BotUUID: botUUID, Code: ans.Text}]
+			ticket, err := bots.UnmarshallTicketFromDB(botUUID, prt.UserID, ans.Text, attendedAt)
+			if err != nil {
+				return nil, err
+			}
+			tickets = append(tickets, ticket)
+		}
+	}
+
+	return tickets, nil
+}
@@ -0,0 +1,33 @@
+package mocks
+
+import (
+	"errors"
+
+	"github.com/bmstu-itstech/itsreg-bots/internal/domain/bots"
+)
+
+const mockTokenKeyID = "mock"
+
+type mockTokenCipher struct{}
+
+func NewMockTokenCipher() bots.TokenCipher {
+	return mockTokenCipher{}
+}
+
+func (mockTokenCipher) Seal(token string) (bots.SealedToken, error) {
+	return bots.SealedToken{
+		KeyID:      mockTokenKeyID,
+		Ciphertext: []byte(token),
+	}, nil
+}
+
+func (mockTokenCipher) Open(sealed bots.SealedToken) (string, error) {
+	if sealed.KeyID != mockTokenKeyID || sealed.IsZero() {
+		return "", errors.New("token is not sealed")
+	}
+	return string(sealed.Ciphertext), nil
+}
+
+func (mockTokenCipher) Rotate(sealed bots.SealedToken) (bots.SealedToken, error) {
+	return sealed, nil
+}
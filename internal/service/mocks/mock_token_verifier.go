@@ -0,0 +1,32 @@
+package mocks
+
+import (
+	"context"
+	"strconv"
+	"strings"
+
+	"github.com/bmstu-itstech/itsreg-bots/internal/domain/bots"
+)
+
+type mockTokenVerifier struct{}
+
+func NewMockTokenVerifier() bots.TokenVerifier {
+	return mockTokenVerifier{}
+}
+
+func (mockTokenVerifier) Verify(_ context.Context, token string) (bots.TelegramIdentity, error) {
+	prefix, _, ok := strings.Cut(token, ":")
+	if !ok {
+		return bots.TelegramIdentity{}, bots.ErrInvalidToken
+	}
+
+	id, err := strconv.ParseInt(prefix, 10, 64)
+	if err != nil {
+		return bots.TelegramIdentity{}, bots.ErrInvalidToken
+	}
+
+	return bots.TelegramIdentity{
+		ID:       id,
+		Username: "bot" + prefix,
+	}, nil
+}
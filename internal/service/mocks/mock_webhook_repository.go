@@ -0,0 +1,70 @@
+package mocks
+
+import (
+	"context"
+	"sync"
+
+	"github.com/bmstu-itstech/itsreg-bots/internal/domain/bots"
+)
+
+type mockWebhookRepository struct {
+	sync.RWMutex
+	m map[string]bots.Webhook
+}
+
+func NewMockWebhookRepository() bots.WebhookRepository {
+	return &mockWebhookRepository{m: make(map[string]bots.Webhook)}
+}
+
+func (r *mockWebhookRepository) Create(_ context.Context, webhook bots.Webhook) error {
+	r.Lock()
+	defer r.Unlock()
+
+	r.m[webhook.UUID] = webhook
+
+	return nil
+}
+
+func (r *mockWebhookRepository) Delete(_ context.Context, botUUID string, uuid string) error {
+	r.Lock()
+	defer r.Unlock()
+
+	w, ok := r.m[uuid]
+	if !ok || w.BotUUID != botUUID {
+		return bots.WebhookNotFoundError{UUID: uuid}
+	}
+
+	delete(r.m, uuid)
+
+	return nil
+}
+
+func (r *mockWebhookRepository) BotWebhooks(_ context.Context, botUUID string) ([]bots.Webhook, error) {
+	r.RLock()
+	defer r.RUnlock()
+
+	res := make([]bots.Webhook, 0)
+	for _, w := range r.m {
+		if w.BotUUID == botUUID {
+			res = append(res, w)
+		}
+	}
+
+	return res, nil
+}
+
+func (r *mockWebhookRepository) Deliveries(
+	_ context.Context,
+	botUUID string,
+	webhookUUID string,
+) ([]bots.WebhookDelivery, error) {
+	r.RLock()
+	defer r.RUnlock()
+
+	w, ok := r.m[webhookUUID]
+	if !ok || w.BotUUID != botUUID {
+		return nil, bots.WebhookNotFoundError{UUID: webhookUUID}
+	}
+
+	return make([]bots.WebhookDelivery, 0), nil
+}
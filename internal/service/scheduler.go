@@ -0,0 +1,44 @@
+package service
+
+import (
+	"context"
+	"log/slog"
+	"os"
+	"strconv"
+	"time"
+)
+
+const (
+	remindInterval = time.Minute
+	expireInterval = time.Minute
+
+	pruneEventsInterval = time.Hour
+	eventsRetention     = 7 * 24 * time.Hour
+)
+
+func runPeriodically(
+	ctx context.Context,
+	logger *slog.Logger,
+	name string,
+	interval time.Duration,
+	fn func(ctx context.Context) error,
+) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			if err := fn(ctx); err != nil {
+				logger.Error("periodic task failed", "task", name, "error", err.Error())
+			}
+		}
+	}
+}
+
+func isEnabled(env string) bool {
+	enabled, _ := strconv.ParseBool(os.Getenv(env))
+	return enabled
+}
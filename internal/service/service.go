@@ -1,9 +1,11 @@
 package service
 
 import (
+	"context"
 	"errors"
 	"log/slog"
 	"os"
+	"time"
 
 	"github.com/ThreeDotsLabs/watermill/message"
 	"github.com/jmoiron/sqlx"
@@ -12,6 +14,7 @@ import (
 	"github.com/bmstu-itstech/itsreg-bots/internal/app/command"
 	"github.com/bmstu-itstech/itsreg-bots/internal/app/query"
 	"github.com/bmstu-itstech/itsreg-bots/internal/common/decorator"
+	"github.com/bmstu-itstech/itsreg-bots/internal/common/health"
 	"github.com/bmstu-itstech/itsreg-bots/internal/common/logs"
 	"github.com/bmstu-itstech/itsreg-bots/internal/common/logs/handlers/slogdiscard"
 	"github.com/bmstu-itstech/itsreg-bots/internal/common/metrics"
@@ -24,28 +27,73 @@ func NewApplication() (
 	app *app.Application,
 	msgCh <-chan *message.Message,
 	runCh <-chan *message.Message,
+	checker *health.Checker,
 	close func() error,
 ) {
 	logger := logs.DefaultLogger()
-	metricsClient := metrics.NoOp{}
+	metricsClient := metrics.Prometheus{}
 
 	url := os.Getenv("DATABASE_URI")
 	db := sqlx.MustConnect("postgres", url)
 
-	botsR := infra.NewPgBotsRepository(db)
+	tokens := infra.NewAESTokenCipher()
+	botsR := infra.NewPgBotsRepository(db, tokens)
 	participants := infra.NewPgParticipantsRepository(db)
+	webhooks := infra.NewPgWebhooksRepository(db)
+	threads := infra.NewPgThreadsRepository(db)
+	seats := infra.NewPgSeatsRepository(db)
+	tickets := infra.NewPgTicketsRepository(db)
+	files := infra.NewLocalFileStorage()
+	leases := infra.NewPgLeasesRepository(db)
+	verifier := infra.NewTelegramTokenVerifier()
 
-	msgPub, msgCh, senderClose := infra.NewNATSMessagesPublisher()
-	runPub, runCh, senderClose := infra.NewNATSRunnerPublisher()
+	msgPub, msgCh, msgClose := infra.NewNATSMessagesPublisher(leases)
+	runPub, runCh, runClose := infra.NewNATSRunnerPublisher()
+	streamPub, evtSub, streamClose := infra.NewNATSEventStream(db)
+	evtPub := infra.NewMultiEventPublisher(infra.NewPgWebhookEventPublisher(db), streamPub)
 
-	return newApplication(
-			logger, metricsClient, botsR, participants, msgPub, runPub,
-		), msgCh, runCh, func() error {
-			var err error
-			err = errors.Join(err, db.Close())
-			err = errors.Join(err, senderClose())
+	natsCheck, natsCheckClose := infra.NewNATSHealthCheck()
+	checker = health.NewChecker()
+	checker.Add("postgres", infra.NewPgHealthCheck(db))
+	checker.Add("nats", natsCheck)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	if isEnabled("WEBHOOK_DISPATCHER_ENABLED") {
+		go infra.NewWebhookDispatcher(db, logger).Run(ctx)
+	}
+
+	application := newApplication(
+		logger, metricsClient, botsR, participants, webhooks, threads, seats, tickets, files, leases, verifier, tokens,
+		msgPub, runPub, evtPub, evtSub,
+	)
+
+	if isEnabled("REMINDERS_ENABLED") {
+		go runPeriodically(ctx, logger, "remind participants", remindInterval, func(ctx context.Context) error {
+			return application.Commands.RemindParticipants.Handle(ctx, command.RemindParticipants{})
+		})
+	}
+	if isEnabled("EXPIRY_ENABLED") {
+		go runPeriodically(ctx, logger, "expire participants", expireInterval, func(ctx context.Context) error {
+			return application.Commands.ExpireParticipants.Handle(ctx, command.ExpireParticipants{})
+		})
+	}
+	if isEnabled("EVENTS_CLEANUP_ENABLED") {
+		go runPeriodically(ctx, logger, "prune bot events", pruneEventsInterval, func(ctx context.Context) error {
+			_, err := infra.PruneBotEvents(ctx, db, time.Now().Add(-eventsRetention))
 			return err
-		}
+		})
+	}
+
+	return application, msgCh, runCh, checker, func() error {
+		cancel()
+		var err error
+		err = errors.Join(err, db.Close())
+		err = errors.Join(err, msgClose())
+		err = errors.Join(err, runClose())
+		err = errors.Join(err, streamClose())
+		err = errors.Join(err, natsCheckClose())
+		return err
+	}
 }
 
 func NewComponentTestApplication() (
@@ -56,14 +104,24 @@ func NewComponentTestApplication() (
 	logger := slogdiscard.NewDiscardLogger()
 	metricsClient := metrics.NoOp{}
 
-	botsR := mocks.NewMockBotRepository()
+	tokens := mocks.NewMockTokenCipher()
+	botsR := mocks.NewMockBotRepository(tokens)
 	participants := mocks.NewMockParticipantsRepository()
+	webhooks := mocks.NewMockWebhookRepository()
+	threads := mocks.NewMockThreadRepository()
+	seats := mocks.NewMockSeatsRepository()
+	tickets := mocks.NewMockTicketRepository(botsR, participants)
+	files := mocks.NewMockFileStorage()
+	leases := mocks.NewMockLeaseRepository()
+	verifier := mocks.NewMockTokenVerifier()
 
 	msgPub, msgCh := mocks.NewMockMessagesPublisher()
 	runPub, runCh := mocks.NewMockRunnerPublisher()
+	evtPub, evtSub := mocks.NewMockEventStream()
 
 	return newApplication(
-		logger, metricsClient, botsR, participants, msgPub, runPub,
+		logger, metricsClient, botsR, participants, webhooks, threads, seats, tickets, files, leases, verifier, tokens,
+		msgPub, runPub, evtPub, evtSub,
 	), msgCh, runCh
 }
 
@@ -72,26 +130,85 @@ func newApplication(
 	metricsClient decorator.MetricsClient,
 	bots bots.Repository,
 	participants bots.ParticipantRepository,
+	webhooks bots.WebhookRepository,
+	threads bots.ThreadRepository,
+	seats bots.SeatsRepository,
+	tickets bots.TicketRepository,
+	files bots.FileStorage,
+	leases bots.LeaseRepository,
+	verifier bots.TokenVerifier,
+	tokens bots.TokenCipher,
 	msgPub bots.MessagesPublisher,
 	runPub bots.RunnerPublisher,
+	evtPub bots.EventPublisher,
+	evtSub bots.EventSubscriber,
 ) *app.Application {
 	return &app.Application{
 		Commands: app.Commands{
-			CreateBot:     command.NewCreateBotHandler(bots, logger, metricsClient),
+			CreateBot:     command.NewCreateBotHandler(bots, verifier, logger, metricsClient),
 			DeleteBot:     command.NewDeleteBotHandler(bots, runPub, logger, metricsClient),
-			StartBot:      command.NewStartBotHandler(bots, runPub, logger, metricsClient),
+			StartBot:      command.NewStartBotHandler(bots, runPub, verifier, tokens, logger, metricsClient),
 			StopBot:       command.NewStopBotHandler(bots, runPub, logger, metricsClient),
-			UpdateStatus:  command.NewUpdateStatusHandler(bots, logger, metricsClient),
-			Entry:         command.NewEntryHandler(bots, participants, msgPub, logger, metricsClient),
-			Process:       command.NewProcessHandler(bots, participants, msgPub, logger, metricsClient),
+			RestartBot:    command.NewRestartBotHandler(bots, runPub, logger, metricsClient),
+			UpdateStatus:  command.NewUpdateStatusHandler(bots, evtPub, logger, metricsClient),
+			Entry:         command.NewEntryHandler(bots, participants, msgPub, evtPub, seats, logger, metricsClient),
+			Process:       command.NewProcessHandler(bots, participants, msgPub, evtPub, seats, threads, logger, metricsClient),
 			CreateMailing: command.NewCreateMailingHandler(bots, logger, metricsClient),
-			StartMailing:  command.NewStartMailingHandler(bots, participants, msgPub, logger, metricsClient),
+			StartMailing:  command.NewStartMailingHandler(bots, participants, msgPub, evtPub, logger, metricsClient),
+
+			SetBotMember:    command.NewSetBotMemberHandler(bots, logger, metricsClient),
+			DeleteBotMember: command.NewDeleteBotMemberHandler(bots, logger, metricsClient),
+
+			CreateWebhook: command.NewCreateWebhookHandler(bots, webhooks, logger, metricsClient),
+			DeleteWebhook: command.NewDeleteWebhookHandler(bots, webhooks, logger, metricsClient),
+
+			ContactOperator:     command.NewContactOperatorHandler(participants, threads, logger, metricsClient),
+			SendOperatorMessage: command.NewSendOperatorMessageHandler(bots, participants, threads, msgPub, logger, metricsClient),
+			PauseParticipant:    command.NewPauseParticipantHandler(bots, participants, logger, metricsClient),
+			ResumeParticipant:   command.NewResumeParticipantHandler(bots, participants, msgPub, logger, metricsClient),
+
+			ResetParticipant:   command.NewResetParticipantHandler(bots, participants, msgPub, evtPub, seats, logger, metricsClient),
+			BlockParticipant:   command.NewBlockParticipantHandler(bots, participants, logger, metricsClient),
+			UnblockParticipant: command.NewUnblockParticipantHandler(bots, participants, logger, metricsClient),
+			DeleteParticipant:  command.NewDeleteParticipantHandler(bots, participants, seats, files, msgPub, logger, metricsClient),
+
+			CancelSeat:  command.NewCancelSeatHandler(bots, participants, seats, msgPub, logger, metricsClient),
+			ReleaseSeat: command.NewReleaseSeatHandler(bots, participants, seats, msgPub, logger, metricsClient),
+
+			CheckIn: command.NewCheckInHandler(bots, tickets, logger, metricsClient),
+
+			UploadFile:    command.NewUploadFileHandler(bots, files, logger, metricsClient),
+			ProcessUpload: command.NewProcessUploadHandler(bots, participants, files, msgPub, evtPub, seats, logger, metricsClient),
+
+			RemindParticipants: command.NewRemindParticipantsHandler(bots, participants, msgPub, logger, metricsClient),
+			ExpireParticipants: command.NewExpireParticipantsHandler(bots, participants, msgPub, logger, metricsClient),
+
+			ClaimBot:    command.NewClaimBotHandler(leases, logger, metricsClient),
+			ReleaseBot:  command.NewReleaseBotHandler(leases, logger, metricsClient),
+			RenewLeases: command.NewRenewLeasesHandler(leases, logger, metricsClient),
 		},
 		Queries: app.Queries{
-			AllAnswers:  query.NewGetAnswersTableHandler(bots, participants, logger, metricsClient),
-			GetBot:      query.NewGetBotHandler(bots, logger, metricsClient),
-			GetBots:     query.NewGetBotsHandler(bots, logger, metricsClient),
-			StartedBots: query.NewGetStartedBotsHandler(bots, logger, metricsClient),
+			AllAnswers:    query.NewGetAnswersTableHandler(bots, participants, tickets, logger, metricsClient),
+			AnswersStream: query.NewStreamAnswersHandler(bots, evtSub, logger, metricsClient),
+			GetBot:        query.NewGetBotHandler(bots, logger, metricsClient),
+			GetBots:       query.NewGetBotsHandler(bots, logger, metricsClient),
+			StartedBots:   query.NewGetStartedBotsHandler(bots, logger, metricsClient),
+			BotMembers:    query.NewGetBotMembersHandler(bots, logger, metricsClient),
+
+			Webhooks:          query.NewGetWebhooksHandler(bots, webhooks, logger, metricsClient),
+			WebhookDeliveries: query.NewGetWebhookDeliveriesHandler(bots, webhooks, logger, metricsClient),
+
+			Threads:        query.NewGetThreadsHandler(bots, threads, logger, metricsClient),
+			ThreadMessages: query.NewGetThreadMessagesHandler(bots, participants, threads, logger, metricsClient),
+
+			Participant:  query.NewGetParticipantHandler(bots, participants, logger, metricsClient),
+			Participants: query.NewGetParticipantsHandler(bots, participants, logger, metricsClient),
+
+			Seats: query.NewGetSeatsHandler(bots, seats, logger, metricsClient),
+
+			File: query.NewGetFileHandler(bots, files, logger, metricsClient),
+
+			Leases: query.NewGetLeasesHandler(leases, logger, metricsClient),
 		},
 	}
 }
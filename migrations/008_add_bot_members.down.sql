@@ -0,0 +1,3 @@
+DROP TABLE IF EXISTS bot_members;
+
+DROP TYPE IF EXISTS bot_role;
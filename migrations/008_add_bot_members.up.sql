@@ -0,0 +1,28 @@
+DO $$ BEGIN
+    CREATE TYPE BOT_ROLE AS ENUM ('owner', 'editor', 'operator', 'viewer');
+EXCEPTION
+    WHEN duplicate_object THEN null;
+END $$;
+
+CREATE TABLE IF NOT EXISTS bot_members (
+    bot_uuid  VARCHAR(36) NOT NULL,
+    user_uuid VARCHAR(36) NOT NULL,
+    role      BOT_ROLE    NOT NULL,
+
+    PRIMARY KEY ( bot_uuid, user_uuid ),
+
+    CONSTRAINT fk_bot_uuid
+        FOREIGN KEY ( bot_uuid )
+            REFERENCES bots ( uuid )
+            ON DELETE CASCADE
+);
+
+CREATE INDEX IF NOT EXISTS bot_members_user_uuid_idx
+    ON bot_members ( user_uuid );
+
+INSERT INTO bot_members
+    (bot_uuid, user_uuid, role)
+SELECT uuid, owner_uuid, 'owner'
+FROM   bots
+WHERE  owner_uuid <> ''
+ON CONFLICT DO NOTHING;
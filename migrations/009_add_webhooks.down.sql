@@ -0,0 +1,6 @@
+DROP TABLE IF EXISTS webhook_deliveries;
+DROP TABLE IF EXISTS webhook_events;
+DROP TABLE IF EXISTS webhooks;
+
+DROP TYPE IF EXISTS delivery_status;
+DROP TYPE IF EXISTS event_type;
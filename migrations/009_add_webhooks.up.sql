@@ -0,0 +1,65 @@
+CREATE TABLE IF NOT EXISTS webhooks (
+    uuid       VARCHAR(36)  PRIMARY KEY,
+    bot_uuid   VARCHAR(36)  NOT NULL,
+    url        TEXT         NOT NULL,
+    secret     VARCHAR(256) NOT NULL,
+    created_at TIMESTAMP    NOT NULL,
+
+    CONSTRAINT fk_bot_uuid
+        FOREIGN KEY ( bot_uuid )
+            REFERENCES bots ( uuid )
+            ON DELETE CASCADE
+);
+
+DO $$ BEGIN
+    CREATE TYPE EVENT_TYPE AS ENUM (
+        'participant.started',
+        'answer.given',
+        'flow.finished',
+        'mailing.finished',
+        'bot.failed'
+    );
+EXCEPTION
+    WHEN duplicate_object THEN null;
+END $$;
+
+CREATE TABLE IF NOT EXISTS webhook_events (
+    webhook_uuid VARCHAR(36) NOT NULL,
+    event        EVENT_TYPE  NOT NULL,
+
+    PRIMARY KEY ( webhook_uuid, event ),
+
+    CONSTRAINT fk_webhook_uuid
+        FOREIGN KEY ( webhook_uuid )
+            REFERENCES webhooks ( uuid )
+            ON DELETE CASCADE
+);
+
+DO $$ BEGIN
+    CREATE TYPE DELIVERY_STATUS AS ENUM ('pending', 'delivered', 'failed');
+EXCEPTION
+    WHEN duplicate_object THEN null;
+END $$;
+
+CREATE TABLE IF NOT EXISTS webhook_deliveries (
+    uuid            VARCHAR(36)     PRIMARY KEY,
+    webhook_uuid    VARCHAR(36)     NOT NULL,
+    event           EVENT_TYPE      NOT NULL,
+    payload         JSONB           NOT NULL,
+    status          DELIVERY_STATUS NOT NULL,
+    attempts        INTEGER         NOT NULL DEFAULT 0,
+    response_code   INTEGER,
+    last_error      TEXT,
+    next_attempt_at TIMESTAMP       NOT NULL,
+    created_at      TIMESTAMP       NOT NULL,
+    updated_at      TIMESTAMP       NOT NULL,
+
+    CONSTRAINT fk_webhook_uuid
+        FOREIGN KEY ( webhook_uuid )
+            REFERENCES webhooks ( uuid )
+            ON DELETE CASCADE
+);
+
+CREATE INDEX IF NOT EXISTS webhook_deliveries_pending_idx
+    ON webhook_deliveries ( next_attempt_at )
+    WHERE status = 'pending';
@@ -0,0 +1,20 @@
+CREATE TABLE IF NOT EXISTS bot_events (
+    id          BIGSERIAL   PRIMARY KEY,
+    bot_uuid    VARCHAR(36) NOT NULL,
+    type        EVENT_TYPE  NOT NULL,
+    user_id     BIGINT,
+    entry_key   VARCHAR(256),
+    state       INTEGER,
+    answer      TEXT,
+    recipients  INTEGER,
+    reason      TEXT,
+    occurred_at TIMESTAMP   NOT NULL,
+
+    CONSTRAINT fk_bot_uuid
+        FOREIGN KEY ( bot_uuid )
+            REFERENCES bots ( uuid )
+            ON DELETE CASCADE
+);
+
+CREATE INDEX IF NOT EXISTS bot_events_bot_uuid_id_idx
+    ON bot_events ( bot_uuid, id );
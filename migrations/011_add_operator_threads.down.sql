@@ -0,0 +1,6 @@
+DROP TABLE IF EXISTS thread_messages;
+
+DROP TYPE IF EXISTS message_direction;
+
+ALTER TABLE participants
+    DROP COLUMN IF EXISTS paused;
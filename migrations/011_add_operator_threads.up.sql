@@ -0,0 +1,26 @@
+ALTER TABLE participants
+    ADD COLUMN IF NOT EXISTS paused BOOLEAN NOT NULL DEFAULT FALSE;
+
+DO $$ BEGIN
+    CREATE TYPE MESSAGE_DIRECTION AS ENUM ('incoming', 'outgoing');
+EXCEPTION
+    WHEN duplicate_object THEN null;
+END $$;
+
+CREATE TABLE IF NOT EXISTS thread_messages (
+    id          BIGSERIAL         PRIMARY KEY,
+    bot_uuid    VARCHAR(36)       NOT NULL,
+    user_id     BIGINT            NOT NULL,
+    direction   MESSAGE_DIRECTION NOT NULL,
+    text        TEXT              NOT NULL,
+    author_uuid VARCHAR(36),
+    created_at  TIMESTAMP         NOT NULL,
+
+    CONSTRAINT fk_participant
+        FOREIGN KEY ( bot_uuid, user_id )
+            REFERENCES participants ( bot_uuid, user_id )
+            ON DELETE CASCADE
+);
+
+CREATE INDEX IF NOT EXISTS thread_messages_participant_idx
+    ON thread_messages ( bot_uuid, user_id, id );
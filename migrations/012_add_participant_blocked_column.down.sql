@@ -0,0 +1,2 @@
+ALTER TABLE participants
+    DROP COLUMN IF EXISTS blocked;
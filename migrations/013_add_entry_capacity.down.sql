@@ -0,0 +1,12 @@
+DROP TABLE IF EXISTS seats;
+
+DROP TYPE IF EXISTS SEAT_STATUS;
+
+ALTER TABLE entry_points
+    DROP COLUMN IF EXISTS capacity,
+    DROP COLUMN IF EXISTS full_state,
+    DROP COLUMN IF EXISTS waitlist_state,
+    DROP COLUMN IF EXISTS promoted_state;
+
+ALTER TABLE participants
+    DROP COLUMN IF EXISTS entry_key;
@@ -0,0 +1,29 @@
+ALTER TABLE participants
+    ADD COLUMN IF NOT EXISTS entry_key VARCHAR(256);
+
+ALTER TABLE entry_points
+    ADD COLUMN IF NOT EXISTS capacity       INTEGER,
+    ADD COLUMN IF NOT EXISTS full_state     INTEGER,
+    ADD COLUMN IF NOT EXISTS waitlist_state INTEGER,
+    ADD COLUMN IF NOT EXISTS promoted_state INTEGER;
+
+DO $$ BEGIN
+    CREATE TYPE SEAT_STATUS AS ENUM ('registered', 'waitlisted');
+EXCEPTION
+    WHEN duplicate_object THEN null;
+END $$;
+
+CREATE TABLE IF NOT EXISTS seats (
+    bot_uuid  VARCHAR(36)  NOT NULL,
+    entry_key VARCHAR(256) NOT NULL,
+    user_id   BIGINT       NOT NULL,
+    status    SEAT_STATUS  NOT NULL,
+    position  INTEGER      NOT NULL,
+
+    PRIMARY KEY ( bot_uuid, entry_key, user_id ),
+
+    CONSTRAINT fk_bot
+        FOREIGN KEY ( bot_uuid )
+            REFERENCES bots ( uuid )
+            ON DELETE CASCADE
+);
@@ -0,0 +1,6 @@
+ALTER TABLE entry_points
+    DROP COLUMN IF EXISTS opens_at,
+    DROP COLUMN IF EXISTS closes_at,
+    DROP COLUMN IF EXISTS timezone,
+    DROP COLUMN IF EXISTS not_open_text,
+    DROP COLUMN IF EXISTS closed_text;
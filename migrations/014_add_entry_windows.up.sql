@@ -0,0 +1,6 @@
+ALTER TABLE entry_points
+    ADD COLUMN IF NOT EXISTS opens_at      TIMESTAMP,
+    ADD COLUMN IF NOT EXISTS closes_at     TIMESTAMP,
+    ADD COLUMN IF NOT EXISTS timezone      VARCHAR(64),
+    ADD COLUMN IF NOT EXISTS not_open_text TEXT,
+    ADD COLUMN IF NOT EXISTS closed_text   TEXT;
@@ -0,0 +1,8 @@
+DROP INDEX IF EXISTS answers_unique_text_idx;
+
+ALTER TABLE answers
+    DROP COLUMN IF EXISTS is_unique;
+
+ALTER TABLE blocks
+    DROP COLUMN IF EXISTS is_unique,
+    DROP COLUMN IF EXISTS unique_text;
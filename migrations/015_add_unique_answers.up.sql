@@ -0,0 +1,10 @@
+ALTER TABLE blocks
+    ADD COLUMN IF NOT EXISTS is_unique   BOOLEAN NOT NULL DEFAULT FALSE,
+    ADD COLUMN IF NOT EXISTS unique_text TEXT;
+
+ALTER TABLE answers
+    ADD COLUMN IF NOT EXISTS is_unique BOOLEAN NOT NULL DEFAULT FALSE;
+
+CREATE UNIQUE INDEX IF NOT EXISTS answers_unique_text_idx
+    ON answers ( bot_uuid, state, LOWER(BTRIM(text)) )
+    WHERE is_unique;
@@ -0,0 +1,15 @@
+ALTER TYPE BLOCK_TYPE ADD VALUE IF NOT EXISTS 'ticket';
+
+CREATE TABLE IF NOT EXISTS check_ins (
+    bot_uuid    VARCHAR(36) NOT NULL,
+    code        TEXT        NOT NULL,
+    user_id     BIGINT      NOT NULL,
+    attended_at TIMESTAMP   NOT NULL,
+
+    PRIMARY KEY ( bot_uuid, code ),
+
+    CONSTRAINT fk_participant
+        FOREIGN KEY ( bot_uuid, user_id )
+            REFERENCES participants ( bot_uuid, user_id )
+            ON DELETE CASCADE
+);
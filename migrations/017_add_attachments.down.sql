@@ -0,0 +1,13 @@
+ALTER TABLE answers
+    DROP COLUMN IF EXISTS upload_type,
+    DROP COLUMN IF EXISTS telegram_file_id,
+    DROP COLUMN IF EXISTS file_id,
+    DROP COLUMN IF EXISTS file_name,
+    DROP COLUMN IF EXISTS mime_type,
+    DROP COLUMN IF EXISTS file_size;
+
+ALTER TABLE blocks
+    DROP COLUMN IF EXISTS attachment_type,
+    DROP COLUMN IF EXISTS attachment_file_id;
+
+DROP TYPE IF EXISTS ATTACHMENT_TYPE;
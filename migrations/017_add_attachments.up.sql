@@ -0,0 +1,19 @@
+ALTER TYPE BLOCK_TYPE ADD VALUE IF NOT EXISTS 'file';
+
+DO $$ BEGIN
+    CREATE TYPE ATTACHMENT_TYPE AS ENUM ('photo', 'document', 'voice');
+EXCEPTION
+    WHEN duplicate_object THEN null;
+END $$;
+
+ALTER TABLE blocks
+    ADD COLUMN IF NOT EXISTS attachment_type    ATTACHMENT_TYPE,
+    ADD COLUMN IF NOT EXISTS attachment_file_id VARCHAR(36);
+
+ALTER TABLE answers
+    ADD COLUMN IF NOT EXISTS upload_type      ATTACHMENT_TYPE,
+    ADD COLUMN IF NOT EXISTS telegram_file_id TEXT,
+    ADD COLUMN IF NOT EXISTS file_id          VARCHAR(36),
+    ADD COLUMN IF NOT EXISTS file_name        TEXT,
+    ADD COLUMN IF NOT EXISTS mime_type        TEXT,
+    ADD COLUMN IF NOT EXISTS file_size        BIGINT;
@@ -0,0 +1,4 @@
+ALTER TABLE blocks
+    DROP COLUMN IF EXISTS format;
+
+DROP TYPE IF EXISTS FORMAT_MODE;
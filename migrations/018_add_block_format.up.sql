@@ -0,0 +1,8 @@
+DO $$ BEGIN
+    CREATE TYPE FORMAT_MODE AS ENUM ('plain', 'markdown_v2', 'html');
+EXCEPTION
+    WHEN duplicate_object THEN null;
+END $$;
+
+ALTER TABLE blocks
+    ADD COLUMN IF NOT EXISTS format FORMAT_MODE;
@@ -0,0 +1,9 @@
+ALTER TABLE participants
+    DROP COLUMN IF EXISTS locale;
+
+ALTER TABLE options
+    DROP COLUMN IF EXISTS locale,
+    DROP COLUMN IF EXISTS translations;
+
+ALTER TABLE blocks
+    DROP COLUMN IF EXISTS translations;
@@ -0,0 +1,11 @@
+ALTER TYPE BLOCK_TYPE ADD VALUE IF NOT EXISTS 'language';
+
+ALTER TABLE blocks
+    ADD COLUMN IF NOT EXISTS translations JSONB;
+
+ALTER TABLE options
+    ADD COLUMN IF NOT EXISTS locale       VARCHAR(35),
+    ADD COLUMN IF NOT EXISTS translations JSONB;
+
+ALTER TABLE participants
+    ADD COLUMN IF NOT EXISTS locale VARCHAR(35);
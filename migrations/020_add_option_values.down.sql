@@ -0,0 +1,4 @@
+ALTER TABLE options
+    DROP COLUMN IF EXISTS value,
+    DROP COLUMN IF EXISTS option_id,
+    DROP COLUMN IF EXISTS aliases;
@@ -0,0 +1,6 @@
+ALTER TABLE options
+    ADD COLUMN IF NOT EXISTS value     TEXT,
+    ADD COLUMN IF NOT EXISTS option_id VARCHAR(64),
+    ADD COLUMN IF NOT EXISTS aliases   JSONB;
+
+UPDATE options SET value = text WHERE value IS NULL;
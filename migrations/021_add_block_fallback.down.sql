@@ -0,0 +1,6 @@
+ALTER TABLE blocks
+    DROP COLUMN IF EXISTS fallback,
+    DROP COLUMN IF EXISTS fallback_state,
+    DROP COLUMN IF EXISTS fallback_text;
+
+DROP TYPE IF EXISTS FALLBACK_MODE;
@@ -0,0 +1,10 @@
+DO $$ BEGIN
+    CREATE TYPE FALLBACK_MODE AS ENUM ('other', 'reject', 'state');
+EXCEPTION
+    WHEN duplicate_object THEN null;
+END $$;
+
+ALTER TABLE blocks
+    ADD COLUMN IF NOT EXISTS fallback       FALLBACK_MODE,
+    ADD COLUMN IF NOT EXISTS fallback_state INTEGER,
+    ADD COLUMN IF NOT EXISTS fallback_text  TEXT;
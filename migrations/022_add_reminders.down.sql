@@ -0,0 +1,11 @@
+DROP INDEX IF EXISTS participants_last_activity_idx;
+
+ALTER TABLE participants
+    DROP COLUMN IF EXISTS flow_entry,
+    DROP COLUMN IF EXISTS last_activity_at,
+    DROP COLUMN IF EXISTS reminders_sent;
+
+ALTER TABLE entry_points
+    DROP COLUMN IF EXISTS reminder_after_seconds,
+    DROP COLUMN IF EXISTS reminder_limit,
+    DROP COLUMN IF EXISTS reminder_text;
@@ -0,0 +1,13 @@
+ALTER TABLE entry_points
+    ADD COLUMN IF NOT EXISTS reminder_after_seconds INTEGER,
+    ADD COLUMN IF NOT EXISTS reminder_limit         INTEGER,
+    ADD COLUMN IF NOT EXISTS reminder_text          TEXT;
+
+ALTER TABLE participants
+    ADD COLUMN IF NOT EXISTS flow_entry       VARCHAR(256),
+    ADD COLUMN IF NOT EXISTS last_activity_at TIMESTAMP,
+    ADD COLUMN IF NOT EXISTS reminders_sent   INTEGER   NOT NULL DEFAULT 0;
+
+CREATE INDEX IF NOT EXISTS participants_last_activity_idx
+    ON participants ( bot_uuid, last_activity_at )
+    WHERE state IS NOT NULL;
@@ -0,0 +1,11 @@
+DROP INDEX IF EXISTS participants_flow_started_idx;
+
+ALTER TABLE participants
+    DROP COLUMN IF EXISTS flow_started_at;
+
+ALTER TABLE entry_points
+    DROP COLUMN IF EXISTS expiry_ttl_seconds,
+    DROP COLUMN IF EXISTS expiry_mode,
+    DROP COLUMN IF EXISTS expiry_text;
+
+DROP TYPE IF EXISTS EXPIRY_MODE;
@@ -0,0 +1,17 @@
+DO $$ BEGIN
+    CREATE TYPE EXPIRY_MODE AS ENUM ('notify', 'restart');
+EXCEPTION
+    WHEN duplicate_object THEN null;
+END $$;
+
+ALTER TABLE entry_points
+    ADD COLUMN IF NOT EXISTS expiry_ttl_seconds INTEGER,
+    ADD COLUMN IF NOT EXISTS expiry_mode        EXPIRY_MODE,
+    ADD COLUMN IF NOT EXISTS expiry_text        TEXT;
+
+ALTER TABLE participants
+    ADD COLUMN IF NOT EXISTS flow_started_at TIMESTAMP;
+
+CREATE INDEX IF NOT EXISTS participants_flow_started_idx
+    ON participants ( bot_uuid, flow_started_at )
+    WHERE state IS NOT NULL;
@@ -0,0 +1,3 @@
+ALTER TABLE bots
+    DROP COLUMN IF EXISTS last_error,
+    DROP COLUMN IF EXISTS failed_at;
@@ -0,0 +1,3 @@
+ALTER TABLE bots
+    ADD COLUMN IF NOT EXISTS last_error TEXT,
+    ADD COLUMN IF NOT EXISTS failed_at  TIMESTAMP;
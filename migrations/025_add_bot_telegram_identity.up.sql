@@ -0,0 +1,5 @@
+ALTER TABLE bots
+    ADD COLUMN IF NOT EXISTS telegram_id BIGINT,
+    ADD COLUMN IF NOT EXISTS username    VARCHAR(64);
+
+CREATE UNIQUE INDEX IF NOT EXISTS bots_telegram_id_idx ON bots (telegram_id) WHERE telegram_id IS NOT NULL;
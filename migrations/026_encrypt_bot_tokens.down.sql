@@ -0,0 +1,12 @@
+DO $$ BEGIN
+    IF EXISTS (SELECT 1 FROM bots WHERE token IS NULL) THEN
+        RAISE EXCEPTION 'bot tokens are stored only encrypted, run "go run ./cmd/reencrypt/reencrypt.go -decrypt" first';
+    END IF;
+END $$;
+
+ALTER TABLE bots
+    DROP COLUMN IF EXISTS token_ciphertext,
+    DROP COLUMN IF EXISTS token_key,
+    DROP COLUMN IF EXISTS token_key_id,
+    DROP COLUMN IF EXISTS token_mask,
+    ALTER COLUMN token SET NOT NULL;
@@ -0,0 +1,11 @@
+ALTER TABLE bots
+    ALTER COLUMN token DROP NOT NULL,
+    ADD COLUMN IF NOT EXISTS token_ciphertext BYTEA,
+    ADD COLUMN IF NOT EXISTS token_key        BYTEA,
+    ADD COLUMN IF NOT EXISTS token_key_id     VARCHAR(64),
+    ADD COLUMN IF NOT EXISTS token_mask       VARCHAR(256);
+
+UPDATE bots
+SET    token_mask = split_part(token, ':', 1) || ':' || repeat('*', greatest(length(split_part(token, ':', 2)) - 4, 0))
+                    || right(split_part(token, ':', 2), 4)
+WHERE  token IS NOT NULL AND token_mask IS NULL;
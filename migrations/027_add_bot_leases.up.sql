@@ -0,0 +1,7 @@
+CREATE TABLE IF NOT EXISTS bot_leases (
+    bot_uuid    VARCHAR(36) PRIMARY KEY REFERENCES bots (uuid) ON DELETE CASCADE,
+    instance_id VARCHAR(128) NOT NULL,
+    expires_at  TIMESTAMP    NOT NULL
+);
+
+CREATE INDEX IF NOT EXISTS bot_leases_instance_id_idx ON bot_leases (instance_id);
@@ -0,0 +1,2 @@
+ALTER TABLE webhook_deliveries
+    ADD COLUMN IF NOT EXISTS locked_until TIMESTAMP;
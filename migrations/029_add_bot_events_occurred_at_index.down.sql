@@ -0,0 +1 @@
+DROP INDEX IF EXISTS bot_events_occurred_at_idx;
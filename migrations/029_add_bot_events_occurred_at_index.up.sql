@@ -0,0 +1,2 @@
+CREATE INDEX IF NOT EXISTS bot_events_occurred_at_idx
+    ON bot_events ( occurred_at );